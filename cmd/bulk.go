@@ -0,0 +1,512 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jackchuka/gh-md/internal/executil"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/prune"
+	"github.com/jackchuka/gh-md/internal/render"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/jackchuka/gh-md/internal/status"
+	"github.com/jackchuka/gh-md/internal/writer"
+	"github.com/spf13/cobra"
+)
+
+// defaultExportFile is suggested when exporting a selection.
+const defaultExportFile = "gh-md-export.md"
+
+// bulkResult is the outcome of a bulk action on a single item.
+type bulkResult struct {
+	item   search.Item
+	result string // e.g. "pushed", "closed", "skipped", "failed"
+	detail string
+	failed bool
+}
+
+// label returns a short display label such as "owner/repo#123".
+func (r *bulkResult) label() string {
+	return fmt.Sprintf("%s/%s#%d", r.item.Owner, r.item.Repo, r.item.Number)
+}
+
+func (r *bulkResult) set(result, detail string) {
+	r.result, r.detail = result, detail
+}
+
+func (r *bulkResult) fail(err error) {
+	r.result, r.detail, r.failed = "failed", err.Error(), true
+}
+
+// remoteAction applies a change to a single item on GitHub. It returns the
+// result to report, or "" if the item was left alone (detail says why).
+type remoteAction func(client *github.Client, parsed *parser.ParsedFile) (result, detail string, err error)
+
+// executeBulkAction runs an action on every selected item and prints a summary
+// of the results per item.
+func executeBulkAction(cmd *cobra.Command, items []search.Item, action search.Action) error {
+	p := output.NewPrinter(cmd)
+
+	for _, item := range items {
+		if item.Archived && action != search.ActionCancel {
+			return fmt.Errorf("%s/%s#%d is archived; restore it first with 'gh md restore'", item.Owner, item.Repo, item.Number)
+		}
+	}
+
+	switch action {
+	case search.ActionCancel:
+		return nil
+
+	case search.ActionCopyPath:
+		paths := make([]string, len(items))
+		for i, item := range items {
+			paths[i] = item.FilePath
+		}
+		if err := executil.CopyToClipboard(strings.Join(paths, "\n")); err != nil {
+			return err
+		}
+		p.Printf("Copied %d paths to clipboard\n", len(paths))
+		return nil
+
+	case search.ActionOpenEditor:
+		return openAllInEditor(items)
+	}
+
+	results := make([]bulkResult, len(items))
+	for i, item := range items {
+		results[i].item = item
+	}
+
+	var err error
+	switch action {
+	case search.ActionViewBrowser:
+		for i := range results {
+			if err := executil.OpenInBrowser(results[i].item.URL); err != nil {
+				results[i].fail(err)
+				continue
+			}
+			results[i].set("opened", results[i].item.URL)
+		}
+
+	case search.ActionPush:
+		err = bulkPush(cmd, results)
+
+	case search.ActionPullFresh:
+		err = bulkRemote(cmd, results, "Pulling", nil)
+
+	case search.ActionClose:
+		err = bulkRemote(cmd, results, "Closing", closeItem)
+
+	case search.ActionAddLabel, search.ActionRemoveLabel:
+		var name string
+		name, err = prompt(cmd, "Label", "")
+		if err != nil || name == "" {
+			return err
+		}
+		err = bulkRemote(cmd, results, "Labeling", labelItems(name, action == search.ActionAddLabel))
+
+	case search.ActionAssignMe:
+		err = bulkRemote(cmd, results, "Assigning", assignViewer())
+
+	case search.ActionPruneLocal:
+		var ok bool
+		ok, err = confirm(cmd, fmt.Sprintf("Delete %d local file(s)?", len(items)))
+		if err != nil || !ok {
+			return err
+		}
+		bulkPrune(p, results)
+
+	case search.ActionExport:
+		var path string
+		path, err = prompt(cmd, "Export to", defaultExportFile)
+		if err != nil {
+			return err
+		}
+		bulkExport(results, path)
+
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return printBulkReport(p, results)
+}
+
+// openAllInEditor opens the selected files together, grouped by the editor
+// configured for their repository.
+func openAllInEditor(items []search.Item) error {
+	var editors []string
+	paths := make(map[string][]string)
+	for _, item := range items {
+		settings, err := meta.ResolveSettings(item.Owner, item.Repo)
+		if err != nil {
+			return fmt.Errorf("failed to load settings: %w", err)
+		}
+		if _, ok := paths[settings.Editor]; !ok {
+			editors = append(editors, settings.Editor)
+		}
+		paths[settings.Editor] = append(paths[settings.Editor], item.FilePath)
+	}
+
+	for _, editor := range editors {
+		if err := executil.OpenInEditor(editor, paths[editor]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkPush pushes local changes of the selected items, skipping unchanged items and conflicts.
+func bulkPush(cmd *cobra.Command, results []bulkResult) error {
+	p := output.NewPrinter(cmd)
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var repos []string
+	for i := range results {
+		repos = append(repos, results[i].item.Owner+"/"+results[i].item.Repo)
+	}
+	recordHistoryRepos(p, history.ActionEdit, repos)
+
+	var mu sync.Mutex
+	done := 0
+	s := newSpinner(cmd.ErrOrStderr(), fmt.Sprintf("Pushing 0/%d...", len(results)))
+	s.Start()
+	forEachConcurrent(len(results), pushConcurrency, func(i int) {
+		r := &results[i]
+		pushBulkItem(client, r)
+
+		mu.Lock()
+		done++
+		s.Suffix = fmt.Sprintf(" Pushing %d/%d...", done, len(results))
+		mu.Unlock()
+	})
+	s.Stop()
+
+	updateIndex(p, repos...)
+	recordHistoryRepos(p, history.ActionPush, repos)
+	return nil
+}
+
+func pushBulkItem(client *github.Client, r *bulkResult) {
+	parsed, err := parser.ParseFile(r.item.FilePath)
+	if err != nil {
+		r.fail(fmt.Errorf("failed to parse file: %w", err))
+		return
+	}
+	if parsed.ID == "" {
+		r.set("skipped", "draft; push it on its own to create it")
+		return
+	}
+	if len(status.LocalStates(parsed)) == 0 {
+		r.set("skipped", "no changes")
+		return
+	}
+
+	b := batchItem{parsed: parsed}
+	prepareBatchItem(client, &b)
+	switch {
+	case b.err != nil:
+		r.fail(b.err)
+		return
+	case b.conflict:
+		r.set("skipped", "conflict; remote changed since last pull")
+		return
+	}
+
+	if err := executeChanges(client, b.parsed, b.plan, b.journal, nopProgress{}); err != nil {
+		r.fail(err)
+		return
+	}
+	r.set("pushed", describePlan(b.plan))
+	if err := repullItem(client, b.parsed); err != nil {
+		r.detail = fmt.Sprintf("failed to sync local file: %v", err)
+	} else if err := b.journal.Remove(); err != nil {
+		r.detail = fmt.Sprintf("failed to remove push journal: %v", err)
+	}
+}
+
+// bulkRemote applies fn to every selected item on GitHub, then pulls the item
+// so the local file reflects the change. With a nil fn the items are only pulled.
+// Files with unpushed local changes are not overwritten.
+func bulkRemote(cmd *cobra.Command, results []bulkResult, verb string, fn remoteAction) error {
+	p := output.NewPrinter(cmd)
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var repos []string
+	for i := range results {
+		repos = append(repos, results[i].item.Owner+"/"+results[i].item.Repo)
+	}
+	recordHistoryRepos(p, history.ActionEdit, repos)
+
+	var mu sync.Mutex
+	done := 0
+	s := newSpinner(cmd.ErrOrStderr(), fmt.Sprintf("%s 0/%d...", verb, len(results)))
+	s.Start()
+	forEachConcurrent(len(results), pushConcurrency, func(i int) {
+		r := &results[i]
+		applyRemote(client, r, fn)
+
+		mu.Lock()
+		done++
+		s.Suffix = fmt.Sprintf(" %s %d/%d...", verb, done, len(results))
+		mu.Unlock()
+	})
+	s.Stop()
+
+	updateIndex(p, repos...)
+	recordHistoryRepos(p, history.ActionPull, repos)
+	return nil
+}
+
+func applyRemote(client *github.Client, r *bulkResult, fn remoteAction) {
+	parsed, err := parser.ParseFile(r.item.FilePath)
+	if err != nil {
+		r.fail(fmt.Errorf("failed to parse file: %w", err))
+		return
+	}
+	if parsed.ID == "" {
+		r.set("skipped", "draft; not created on GitHub yet")
+		return
+	}
+
+	r.set("pulled", "")
+	if fn != nil {
+		result, detail, err := fn(client, parsed)
+		if err != nil {
+			r.fail(err)
+			return
+		}
+		if result == "" {
+			r.set("skipped", detail)
+			return
+		}
+		r.set(result, detail)
+	}
+
+	err = pullItem(client, parsed, false)
+	switch {
+	case errors.Is(err, writer.ErrLocallyModified) && fn == nil:
+		r.set("skipped", "unpushed local changes; push them first")
+	case errors.Is(err, writer.ErrLocallyModified):
+		r.detail = "local file not refreshed: unpushed local changes"
+	case err != nil && fn == nil:
+		r.fail(err)
+	case err != nil:
+		r.detail = fmt.Sprintf("failed to sync local file: %v", err)
+	}
+}
+
+// closeItem closes an open issue, pull request or discussion.
+func closeItem(client *github.Client, parsed *parser.ParsedFile) (string, string, error) {
+	if strings.ToLower(parsed.State) != "open" {
+		return "", "already " + strings.ToLower(parsed.State), nil
+	}
+
+	var err error
+	switch parsed.ItemType {
+	case github.ItemTypeIssue:
+		err = client.CloseIssue(parsed.ID)
+	case github.ItemTypePullRequest:
+		err = client.ClosePullRequest(parsed.ID)
+	case github.ItemTypeDiscussion:
+		err = client.CloseDiscussion(parsed.ID)
+	default:
+		err = fmt.Errorf("unknown item type: %s", parsed.ItemType)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return "closed", "", nil
+}
+
+// labelItems returns an action that adds or removes the named label. Label IDs
+// are looked up once per repository.
+func labelItems(name string, add bool) remoteAction {
+	var mu sync.Mutex
+	ids := make(map[string]string)
+
+	return func(client *github.Client, parsed *parser.ParsedFile) (string, string, error) {
+		has := false
+		for _, l := range parsed.Labels {
+			if strings.EqualFold(l, name) {
+				has = true
+				break
+			}
+		}
+		if add && has {
+			return "", "already labeled " + name, nil
+		}
+		if !add && !has {
+			return "", "not labeled " + name, nil
+		}
+
+		repoKey := parsed.Owner + "/" + parsed.Repo
+		mu.Lock()
+		id, ok := ids[repoKey]
+		mu.Unlock()
+		if !ok {
+			var err error
+			id, err = client.LabelID(parsed.Owner, parsed.Repo, name)
+			if err != nil {
+				return "", "", err
+			}
+			mu.Lock()
+			ids[repoKey] = id
+			mu.Unlock()
+		}
+
+		if add {
+			if err := client.AddLabels(parsed.ID, []string{id}); err != nil {
+				return "", "", err
+			}
+			return "labeled", "+" + name, nil
+		}
+		if err := client.RemoveLabels(parsed.ID, []string{id}); err != nil {
+			return "", "", err
+		}
+		return "unlabeled", "-" + name, nil
+	}
+}
+
+// assignViewer returns an action that assigns the authenticated user. The
+// user's ID is fetched on first use.
+func assignViewer() remoteAction {
+	var once sync.Once
+	var viewerID string
+	var viewerErr error
+
+	return func(client *github.Client, parsed *parser.ParsedFile) (string, string, error) {
+		if parsed.ItemType == github.ItemTypeDiscussion {
+			return "", "discussions can't be assigned", nil
+		}
+
+		once.Do(func() { viewerID, viewerErr = client.ViewerID() })
+		if viewerErr != nil {
+			return "", "", viewerErr
+		}
+
+		if err := client.AddAssignees(parsed.ID, []string{viewerID}); err != nil {
+			return "", "", err
+		}
+		return "assigned", "", nil
+	}
+}
+
+// bulkPrune deletes the local files of the selected items. Files with unpushed
+// local changes are kept.
+func bulkPrune(p *output.Printer, results []bulkResult) {
+	var files []prune.PruneResult
+	var pruned []*bulkResult
+	for i := range results {
+		r := &results[i]
+		parsed, err := parser.ParseFile(r.item.FilePath)
+		if err != nil {
+			r.fail(fmt.Errorf("failed to parse file: %w", err))
+			continue
+		}
+		if parsed.Modified || parsed.ID == "" {
+			r.set("skipped", "unpushed local changes")
+			continue
+		}
+		files = append(files, prune.PruneResult{
+			Path:     parsed.FilePath,
+			ItemType: parsed.ItemType,
+			Number:   parsed.Number,
+			State:    parsed.State,
+			Owner:    parsed.Owner,
+			Repo:     parsed.Repo,
+		})
+		pruned = append(pruned, r)
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	repos := make([]string, len(files))
+	for i, f := range files {
+		repos[i] = f.Owner + "/" + f.Repo
+	}
+	recordHistoryRepos(p, history.ActionEdit, repos)
+
+	deleted, err := prune.DeleteFiles(files)
+	for i, r := range pruned {
+		switch {
+		case i < deleted:
+			r.set("pruned", files[i].RelativePath())
+		case i == deleted && err != nil:
+			r.fail(err)
+		default:
+			r.set("skipped", "stopped after an earlier failure")
+		}
+	}
+
+	updateIndex(p, repos...)
+	recordHistoryRepos(p, history.ActionPrune, repos)
+}
+
+// bulkExport writes the selected items to a single markdown file.
+func bulkExport(results []bulkResult, path string) {
+	var files []*parser.ParsedFile
+	var exported []*bulkResult
+	for i := range results {
+		r := &results[i]
+		parsed, err := parser.ParseFile(r.item.FilePath)
+		if err != nil {
+			r.fail(fmt.Errorf("failed to parse file: %w", err))
+			continue
+		}
+		files = append(files, parsed)
+		exported = append(exported, r)
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	err := os.WriteFile(path, []byte(render.Markdown(files)), 0644)
+	for _, r := range exported {
+		if err != nil {
+			r.fail(fmt.Errorf("failed to write export: %w", err))
+			continue
+		}
+		r.set("exported", path)
+	}
+}
+
+func printBulkReport(p *output.Printer, results []bulkResult) error {
+	p.Print("")
+
+	failed := 0
+	t := p.NewTable("ITEM", "RESULT", "DETAIL")
+	for i := range results {
+		r := &results[i]
+		if r.failed {
+			failed++
+		}
+		t.Row(r.label(), r.result, r.detail)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d item(s) failed", failed, len(results))
+	}
+	return nil
+}
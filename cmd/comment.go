@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/assets"
+	"github.com/jackchuka/gh-md/internal/comment"
+	"github.com/jackchuka/gh-md/internal/executil"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/journal"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/jackchuka/gh-md/internal/writer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentReply   bool
+	commentReplyTo string
+	commentBody    string
+	commentNoQuote bool
+	commentNoPush  bool
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment [file-path | url]",
+	Short: "Write and post a single comment on an item",
+	Long: `Write a comment in a temporary buffer and post just that comment, without
+editing the item's file by hand.
+
+The buffer ends with a block describing the item and the reply target; it is
+removed before posting. Leaving the buffer empty or unchanged cancels.
+
+With --reply, pick a comment or review thread to respond to first. Its text is
+quoted at the top of the buffer (unless --no-quote). Discussion comments and
+pull request review threads get a threaded reply; for issue and pull request
+comments, which have no threads, a top-level comment quoting them is posted.
+
+The comment is inserted into the item's file as a new-comment draft and pushed
+on its own; other local edits to the file are left alone. With --no-push the
+draft is only inserted, to be pushed later with 'gh md push'.
+
+When run without arguments inside a git repository, opens FZF to select
+a file from the current repo.
+
+Examples:
+  gh md comment                                   # Smart: FZF selector for current repo
+  gh md comment owner/repo/issues/123
+  gh md comment --reply https://github.com/owner/repo/pull/45
+  gh md comment --body 'Fixed in #46' owner/repo/issues/123
+  echo 'Thanks!' | gh md comment --body - owner/repo/discussions/7
+  gh md comment --reply-to DC_kwDOABC <file>      # Reply to a known comment ID
+  gh md comment --no-push <file>                  # Only insert the draft`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runComment,
+}
+
+func init() {
+	rootCmd.AddCommand(commentCmd)
+
+	commentCmd.Flags().BoolVar(&commentReply, "reply", false, "Pick a comment or review thread to respond to")
+	commentCmd.Flags().StringVar(&commentReplyTo, "reply-to", "", "Reply to the comment or review thread with this ID")
+	commentCmd.Flags().StringVar(&commentBody, "body", "", "Comment text instead of opening an editor (- reads stdin)")
+	commentCmd.Flags().BoolVar(&commentNoQuote, "no-quote", false, "Don't quote the comment being responded to")
+	commentCmd.Flags().BoolVar(&commentNoPush, "no-push", false, "Only insert the comment as a draft, without pushing")
+	commentCmd.MarkFlagsMutuallyExclusive("reply", "reply-to")
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	var filePath string
+	var err error
+
+	if len(args) == 0 {
+		filePath, err = selectRepoFile()
+		if err != nil {
+			return err
+		}
+		if filePath == "" {
+			// User cancelled
+			return nil
+		}
+	} else {
+		filePath, err = parser.ResolveFilePath(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parser.ParseFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+	if commentReplyTo != "" && parsed.ItemType == github.ItemTypeIssue {
+		return fmt.Errorf("issues do not support threaded replies; use --reply to quote a comment instead")
+	}
+	j, err := openCommentJournal(parsed)
+	if err != nil {
+		return err
+	}
+
+	var target *comment.Target
+	if commentReply {
+		target, err = pickCommentTarget(cmd, parsed)
+		if err != nil || target == nil {
+			return err
+		}
+	} else if commentReplyTo != "" {
+		target = &comment.Target{ReplyTo: commentReplyTo, Label: commentReplyTo}
+	}
+
+	var body string
+	switch commentBody {
+	case "":
+		body, err = editComment(parsed, target, !commentNoQuote)
+		if err != nil {
+			return err
+		}
+	case "-":
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read comment: %w", err)
+		}
+		body = strings.TrimSpace(string(data))
+	default:
+		body = strings.TrimSpace(commentBody)
+	}
+	if body == "" {
+		output.NewPrinter(cmd).Print("Empty comment, nothing to post.")
+		return nil
+	}
+
+	replyTo := ""
+	if target != nil {
+		replyTo = target.ReplyTo
+	}
+	return postComment(cmd, parsed, j, body, replyTo, !commentNoPush)
+}
+
+// commentOnItem lets the user pick what to respond to, if the item has comments,
+// then writes a comment in the editor and posts it.
+func commentOnItem(cmd *cobra.Command, item *search.Item) error {
+	parsed, err := parser.ParseFile(item.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+	j, err := openCommentJournal(parsed)
+	if err != nil {
+		return err
+	}
+
+	target, err := pickCommentTarget(cmd, parsed)
+	if err != nil || target == nil {
+		return err
+	}
+
+	body, err := editComment(parsed, target, true)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		output.NewPrinter(cmd).Print("Empty comment, nothing to post.")
+		return nil
+	}
+
+	return postComment(cmd, parsed, j, body, target.ReplyTo, true)
+}
+
+// pickCommentTarget asks whether to write a new comment or respond to one of the
+// item's comments or review threads. A new comment is returned as a target
+// without text; nil means the user cancelled. Items without comments skip the
+// question. Without fzf, the choice is read from stdin.
+func pickCommentTarget(cmd *cobra.Command, parsed *parser.ParsedFile) (*comment.Target, error) {
+	content, err := os.ReadFile(parsed.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	choices := append([]comment.Target{{Label: "New comment"}}, comment.Targets(parsed, string(content))...)
+	if len(choices) == 1 {
+		return &choices[0], nil
+	}
+
+	labels := make([]string, len(choices))
+	for i, c := range choices {
+		labels[i] = c.Label
+	}
+
+	header := fmt.Sprintf("Comment on %s/%s #%d: %s", parsed.Owner, parsed.Repo, parsed.Number, parsed.Title)
+	var i int
+	if search.CheckFZFInstalled() == nil {
+		i, err = search.Pick(header, labels)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		p := output.NewPrinter(cmd)
+		p.Print(header)
+		for n, label := range labels {
+			p.Printf("  %d) %s\n", n+1, label)
+		}
+		answer, err := prompt(cmd, "Respond to", "1")
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(choices) {
+			return nil, fmt.Errorf("invalid choice: %s", answer)
+		}
+		i = n - 1
+	}
+
+	if i < 0 {
+		return nil, nil
+	}
+	return &choices[i], nil
+}
+
+// editComment opens the comment buffer in the editor and returns the comment
+// written, or "" if the user left it empty or unchanged.
+func editComment(parsed *parser.ParsedFile, target *comment.Target, quote bool) (string, error) {
+	if target != nil && target.ReplyTo == "" && target.Body == "" {
+		target = nil // A new comment
+	}
+	initial := comment.Buffer(parsed, target, quote)
+
+	settings, err := meta.ResolveSettings(parsed.Owner, parsed.Repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "gh-md-comment-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create comment buffer: %w", err)
+	}
+	bufPath := f.Name()
+	_, err = f.WriteString(initial)
+	_ = f.Close()
+	defer func() { _ = os.Remove(bufPath) }()
+	if err != nil {
+		return "", fmt.Errorf("failed to write comment buffer: %w", err)
+	}
+
+	if err := executil.OpenInEditor(settings.Editor, bufPath); err != nil {
+		return "", err
+	}
+
+	buf, err := os.ReadFile(bufPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read comment buffer: %w", err)
+	}
+	return comment.ParseBuffer(string(buf), initial), nil
+}
+
+// openCommentJournal checks that an item can be commented on and opens its push
+// journal. It runs before the editor opens, so a comment isn't written only to
+// be rejected.
+func openCommentJournal(parsed *parser.ParsedFile) (*journal.Journal, error) {
+	if parsed.ID == "" {
+		return nil, fmt.Errorf("%s has no GitHub ID; run 'gh md pull' first", parsed.FilePath)
+	}
+
+	j, err := journal.Open(parsed.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open push journal: %w", err)
+	}
+	if j.Exists() {
+		return nil, fmt.Errorf("%s has an interrupted push; run 'gh md push --resume' first", parsed.FilePath)
+	}
+	return j, nil
+}
+
+// postComment inserts body into the item's file as a new-comment draft replying
+// to replyTo, and with push, posts only that draft and re-pulls the item. j is
+// the item's push journal from openCommentJournal.
+func postComment(cmd *cobra.Command, parsed *parser.ParsedFile, j *journal.Journal, body, replyTo string, push bool) error {
+	p := output.NewPrinter(cmd)
+
+	content, err := os.ReadFile(parsed.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	updated, localID := writer.AddDraft(string(content), body, replyTo)
+	if err := writer.WriteFile(parsed.FilePath, updated); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	recordHistory(p, history.ActionEdit, parsed.Owner, parsed.Repo)
+
+	if !push {
+		p.Printf("Added comment draft to %s\n", parsed.FilePath)
+		return nil
+	}
+
+	body, err = assets.Restore(body, parsed.FilePath)
+	if err != nil {
+		return err
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	plan := changePlan{
+		newComments: []parser.ParsedComment{{Body: body, ParentID: replyTo, LocalID: localID}},
+	}
+	s := newSpinner(cmd.ErrOrStderr(), "")
+	err = executeChanges(client, parsed, plan, j, spinnerProgress{p: p, s: s})
+	s.Stop()
+	if err != nil {
+		p.Errorf("The comment is kept as a draft in %s; push the file to retry.\n", parsed.FilePath)
+		return err
+	}
+
+	// Re-pull so the comment shows up as posted, unless that would overwrite other edits
+	s.Suffix = " Syncing local file..."
+	s.Start()
+	err = pullItem(client, parsed, false)
+	s.Stop()
+	switch {
+	case errors.Is(err, writer.ErrLocallyModified):
+		// Keep the journal so pushing the file later won't post the comment again
+		p.Print("The file has other unpushed changes, so it was not re-pulled.")
+		return nil
+	case err != nil:
+		p.Errorf("Warning: failed to sync local file: %v\n", err)
+		p.Errorf("Run 'gh md pull' to sync manually\n")
+		return nil
+	}
+
+	if err := j.Remove(); err != nil {
+		p.Errorf("Warning: failed to remove push journal: %v\n", err)
+	}
+
+	updateIndex(p, parsed.Owner+"/"+parsed.Repo)
+	recordHistory(p, history.ActionPush, parsed.Owner, parsed.Repo)
+	return nil
+}
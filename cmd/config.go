@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configRepo   string
+	configFormat string
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get and set gh-md configuration",
+	Long: `Get and set gh-md configuration.
+
+User configuration is stored in ~/.config/gh-md/config.yaml
+(or $XDG_CONFIG_HOME/gh-md/config.yaml, overridable via GH_MD_CONFIG).
+Per-repo overrides are stored in the repo's .gh-md-meta.yaml file (use --repo).
+
+Precedence (highest first): flag > repo > user > default
+
+Keys:
+  types                Item types to pull and browse (issues, prs, discussions)
+  open_only            Fetch only open items when pulling
+  limit                Max items to pull per type (0 = no limit)
+  labels               Only pull issues and PRs carrying one of these labels
+  repos                Repositories (owner/repo) included by pull --all
+  format               Default output format
+  editor               Editor command (overrides $EDITOR)
+  history              Record pull, push and prune as git commits in the gh-md root
+  assets               Download image and file attachments when pulling
+  prune.types          Item types eligible for pruning
+  prune.closed_days    Only prune items closed more than this many days ago
+  prune.stale_months   Also prune open items not updated in this many months
+  prune.keep_labels    Never prune items carrying one of these labels
+  prune.keep_assigned  Never prune items assigned to you
+  prune.keep_pinned    Never prune pinned issues
+
+List values are comma-separated. Setting an empty value unsets the key.
+
+Examples:
+  gh md config list
+  gh md config get open_only
+  gh md config set types issues,prs
+  gh md config set open_only true --repo owner/repo
+  gh md config set history true                  # Enable git-backed history
+  gh md config set labels ""                     # Unset`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a configuration key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration key in the user config (or repo with --repo)",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configuration keys with their effective values and sources",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+
+	configCmd.PersistentFlags().StringVar(&configRepo, "repo", "", "Repository (owner/repo) for per-repo overrides")
+	configListCmd.Flags().StringVar(&configFormat, "format", "text", "Output format (text, json, yaml)")
+}
+
+// configLayers holds each level of configuration for a key lookup.
+type configLayers struct {
+	owner    string
+	repo     string
+	defaults *config.Settings
+	user     *config.Settings
+	repoMeta *meta.Meta
+}
+
+func loadConfigLayers() (*configLayers, error) {
+	user, err := config.LoadUserSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	layers := &configLayers{
+		defaults: config.DefaultSettings(),
+		user:     user,
+	}
+
+	if configRepo != "" {
+		input, err := github.ParseInput(configRepo)
+		if err != nil {
+			return nil, err
+		}
+		layers.owner, layers.repo = input.Owner, input.Repo
+
+		layers.repoMeta, err = meta.Load(input.Owner, input.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load repo metadata: %w", err)
+		}
+	}
+
+	return layers, nil
+}
+
+// lookup returns the effective value for a key and the layer it came from.
+func (l *configLayers) lookup(key string) (value, source string, err error) {
+	if l.repoMeta != nil && l.repoMeta.Settings != nil {
+		if v, ok, err := l.repoMeta.Settings.Get(key); err != nil {
+			return "", "", err
+		} else if ok {
+			return v, "repo", nil
+		}
+	}
+
+	if v, ok, err := l.user.Get(key); err != nil {
+		return "", "", err
+	} else if ok {
+		return v, "user", nil
+	}
+
+	if v, ok, err := l.defaults.Get(key); err != nil {
+		return "", "", err
+	} else if ok {
+		return v, "default", nil
+	}
+
+	return "", "-", nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return err
+	}
+
+	value, _, err := layers.lookup(args[0])
+	if err != nil {
+		return err
+	}
+
+	output.NewPrinter(cmd).Print(value)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	p := output.NewPrinter(cmd)
+	key, value := args[0], args[1]
+
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return err
+	}
+
+	if layers.repoMeta != nil {
+		if layers.repoMeta.Settings == nil {
+			layers.repoMeta.Settings = &config.Settings{}
+		}
+		if err := layers.repoMeta.Settings.Set(key, value); err != nil {
+			return err
+		}
+		if layers.repoMeta.Settings.IsEmpty() {
+			layers.repoMeta.Settings = nil
+		}
+		if err := meta.Save(layers.owner, layers.repo, layers.repoMeta); err != nil {
+			return fmt.Errorf("failed to save repo metadata: %w", err)
+		}
+		p.Printf("Set %s for %s/%s\n", key, layers.owner, layers.repo)
+		return nil
+	}
+
+	if err := layers.user.Set(key, value); err != nil {
+		return err
+	}
+	if err := config.SaveUserSettings(layers.user); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	path, _ := config.GetUserConfigPath()
+	p.Printf("Set %s in %s\n", key, path)
+	return nil
+}
+
+type configEntryOutput struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(cmd, configFormat, nil, false)
+	if err != nil {
+		return err
+	}
+
+	keys := config.SettingKeys()
+	entries := make([]configEntryOutput, 0, len(keys))
+	for _, key := range keys {
+		value, source, err := layers.lookup(key)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, configEntryOutput{Key: key, Value: value, Source: source})
+	}
+
+	return output.List(p,
+		[]string{"KEY", "VALUE", "SOURCE"},
+		entries,
+		func(e configEntryOutput) []string {
+			value := e.Value
+			if value == "" {
+				value = "-"
+			}
+			return []string{e.Key, value, e.Source}
+		},
+	)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jackchuka/gh-md/internal/assets"
+	"github.com/jackchuka/gh-md/internal/diff"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/writer"
+	"github.com/spf13/cobra"
+)
+
+var diffRemoteOnly bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [file-path | url]",
+	Short: "Show differences between a local file and GitHub",
+	Long: `Show a unified diff between a local file and the current item on GitHub.
+
+The remote item is rendered exactly as 'gh md pull' would write it. Lines
+starting with + exist only locally, lines starting with - only on GitHub.
+Volatile fields such as last_pulled are ignored.
+
+With --remote-only, the file as it was last pulled is compared with GitHub
+instead, so + lines are changes made upstream since the last pull.
+
+When run without arguments inside a git repository, opens FZF to select
+a file from the current repo.
+
+Examples:
+  gh md diff                                      # Smart: FZF selector for current repo
+  gh md diff owner/repo/issues/123
+  gh md diff https://github.com/owner/repo/issues/123
+  gh md diff --remote-only <file>                 # What changed upstream since last pull`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().BoolVar(&diffRemoteOnly, "remote-only", false, "Show only changes made on GitHub since the last pull")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	p := output.NewPrinter(cmd)
+
+	var filePath string
+	var err error
+
+	if len(args) == 0 {
+		filePath, err = selectRepoFile()
+		if err != nil {
+			return err
+		}
+		if filePath == "" {
+			// User cancelled
+			return nil
+		}
+	} else {
+		filePath, err = parser.ResolveFilePath(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parser.ParseFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	if parsed.ItemType == "" {
+		return fmt.Errorf("could not determine item type from path: %s", filePath)
+	}
+
+	// Read the local side up front so a missing base copy fails before any API call
+	var local, localName string
+	if diffRemoteOnly {
+		local, err = writer.ReadBase(filePath)
+		if err != nil {
+			return err
+		}
+		localName = "last pull"
+	} else {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		local = string(data)
+		localName = "local"
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	s := newSpinner(cmd.ErrOrStderr(), fmt.Sprintf("Fetching %s #%d...", parsed.ItemType, parsed.Number))
+	s.Start()
+	remote, err := renderRemoteItem(client, parsed)
+	s.Stop()
+	if err != nil {
+		return err
+	}
+	// Link attachments that were downloaded, as pull does
+	if remote, err = assets.Localize(remote, filePath, nil); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s/%s#%d", parsed.Owner, parsed.Repo, parsed.Number)
+	a, aName := remote, name+" (github)"
+	b, bName := local, fmt.Sprintf("%s (%s)", name, localName)
+	if diffRemoteOnly {
+		a, aName, b, bName = b, bName, a, aName
+	}
+
+	d := diff.Unified(
+		aName,
+		bName,
+		writer.RemoveFrontmatterFields(a, writer.VolatileFields...),
+		writer.RemoveFrontmatterFields(b, writer.VolatileFields...),
+		diff.DefaultContext,
+	)
+
+	if d == "" {
+		p.Print("No differences.")
+		return nil
+	}
+
+	if output.ColorEnabled() {
+		d = output.ColorizeDiff(d)
+	}
+	p.Printf("%s", d)
+	return nil
+}
+
+// renderRemoteItem fetches the current remote item and renders it as 'gh md pull' would.
+func renderRemoteItem(client *github.Client, parsed *parser.ParsedFile) (string, error) {
+	switch parsed.ItemType {
+	case github.ItemTypeIssue:
+		issue, err := client.FetchIssue(parsed.Owner, parsed.Repo, parsed.Number)
+		if err != nil {
+			return "", err
+		}
+		return writer.IssueToMarkdown(issue)
+
+	case github.ItemTypePullRequest:
+		pr, err := client.FetchPullRequest(parsed.Owner, parsed.Repo, parsed.Number)
+		if err != nil {
+			return "", err
+		}
+		return writer.PullRequestToMarkdown(pr)
+
+	case github.ItemTypeDiscussion:
+		d, err := client.FetchDiscussion(parsed.Owner, parsed.Repo, parsed.Number)
+		if err != nil {
+			return "", err
+		}
+		return writer.DiscussionToMarkdown(d)
+
+	default:
+		return "", fmt.Errorf("unknown item type: %s", parsed.ItemType)
+	}
+}
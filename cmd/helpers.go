@@ -1,11 +1,19 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/index"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
 	"github.com/spf13/cobra"
 )
 
@@ -23,3 +31,138 @@ func registerItemTypeFlags(cmd *cobra.Command, issues, prs, discussions *bool, v
 	cmd.Flags().BoolVar(prs, "prs", false, fmt.Sprintf("%s only pull requests", verb))
 	cmd.Flags().BoolVar(discussions, "discussions", false, fmt.Sprintf("%s only discussions", verb))
 }
+
+// resolveTypes returns the item types selected by the --issues, --prs and --discussions flags.
+// When no type flag is given, the configured default types are used.
+func resolveTypes(issues, prs, discussions bool, settings *config.Settings) (bool, bool, bool) {
+	if issues || prs || discussions {
+		return issues, prs, discussions
+	}
+	return settings.IncludesType(config.TypeIssues),
+		settings.IncludesType(config.TypePRs),
+		settings.IncludesType(config.TypeDiscussions)
+}
+
+// resolveFormat returns the --format flag value if it was given explicitly,
+// otherwise the configured default format.
+func resolveFormat(cmd *cobra.Command, flagValue string, settings *config.Settings) string {
+	if cmd.Flags().Changed("format") || settings.Format == "" {
+		return flagValue
+	}
+	return settings.Format
+}
+
+// newPrinter returns a printer in the format given with --format, or the
+// configured default format. The list-only formats (csv, tsv, markdown, ndjson
+// and templates) are rejected unless listOutput is set; a configured default
+// in one of them falls back to text instead.
+func newPrinter(cmd *cobra.Command, flagValue string, settings *config.Settings, listOutput bool) (*output.Printer, error) {
+	value := flagValue
+	if settings != nil {
+		value = resolveFormat(cmd, flagValue, settings)
+	}
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if format.IsListOnly() && !listOutput {
+		if settings == nil || cmd.Flags().Changed("format") {
+			return nil, fmt.Errorf("format %q is only supported by list output (e.g. 'gh md --list', 'search --list', 'repos' and 'prune')", value)
+		}
+		format = output.FormatText
+	}
+	return output.NewPrinter(cmd).WithFormat(format), nil
+}
+
+// recordHistory commits changes under a repository to the gh-md history if it is
+// enabled. Failures are only warnings since the operation itself already succeeded.
+func recordHistory(p *output.Printer, action, owner, repo string) {
+	if _, err := history.Record(action, owner, repo); err != nil {
+		p.Errorf("Warning: failed to record history: %v\n", err)
+	}
+}
+
+// updateIndex refreshes the metadata cache and, if it has been built, the search
+// index for changed files of each distinct repository ("owner/repo"). Failures are
+// only warnings; both catch up on the next read.
+func updateIndex(p *output.Printer, repos ...string) {
+	seen := make(map[string]bool)
+	for _, r := range repos {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		if err := parser.RefreshMetadataCache(r); err != nil {
+			p.Errorf("Warning: failed to update metadata cache: %v\n", err)
+		}
+		if err := index.Update(r); err != nil {
+			p.Errorf("Warning: failed to update search index: %v\n", err)
+		}
+	}
+}
+
+// recordHistoryRepos records history for each distinct repository ("owner/repo").
+func recordHistoryRepos(p *output.Printer, action string, repos []string) {
+	seen := make(map[string]bool)
+	for _, r := range repos {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		owner, repo, _ := strings.Cut(r, "/")
+		recordHistory(p, action, owner, repo)
+	}
+}
+
+// confirm asks a yes/no question on stdin. Anything but "y" or "yes" is a no.
+func confirm(cmd *cobra.Command, prompt string) (bool, error) {
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s [y/N]: ", prompt)
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// prompt asks for a line of input on stdin, returning def if the answer is empty.
+func prompt(cmd *cobra.Command, question, def string) (string, error) {
+	if def != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s [%s]: ", question, def)
+	} else {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: ", question)
+	}
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// forEachConcurrent calls fn for each index in [0, n) with at most limit calls in flight.
+func forEachConcurrent(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for i := range n {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jackchuka/gh-md/internal/executil"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listFZFReload string
+	listToggle    string
+	listFZFOpen   bool
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print the item list of the FZF selector",
+	Long: `Print the item list of the FZF selector from its saved state, optionally
+changing a filter first. Used by the selector's key bindings to reload the
+list in place; not meant to be run by hand.
+
+Toggles:
+  issues, prs, discussions   Show or hide an item type
+  state                      Cycle all, open, closed
+  mine                       Switch between all items and items you authored,
+                             are assigned to or are asked to review
+  sort                       Cycle updated, created, number
+
+With --fzf-open, the arguments are item files to open in the browser.
+
+Examples:
+  gh md list --fzf-reload /tmp/gh-md-selector-1.json --toggle state
+  gh md list --fzf-open owner/repo/issues/123.md`,
+	Hidden: true,
+	RunE:   runList,
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listFZFReload, "fzf-reload", "", "Selector state file to print the list from")
+	listCmd.Flags().StringVar(&listToggle, "toggle", "", "Filter to change before printing: issues, prs, discussions, state, mine, sort")
+	listCmd.Flags().BoolVar(&listFZFOpen, "fzf-open", false, "Open the item files given as arguments in the browser")
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	if listFZFOpen {
+		return openFilesInBrowser(args)
+	}
+	if listFZFReload == "" {
+		return fmt.Errorf("--fzf-reload or --fzf-open is required")
+	}
+
+	state, err := search.LoadSelectorState(listFZFReload)
+	if err != nil {
+		return err
+	}
+
+	if listToggle != "" {
+		if err := state.Toggle(listToggle); err != nil {
+			return err
+		}
+	}
+	if state.Mine && state.User == "" {
+		if state.User, err = search.GetCurrentUser(); err != nil {
+			return err
+		}
+	}
+
+	if err := state.Refresh(); err != nil {
+		return fmt.Errorf("failed to scan files: %w", err)
+	}
+	if err := state.Save(listFZFReload); err != nil {
+		return err
+	}
+
+	return state.WriteLines(cmd.OutOrStdout())
+}
+
+// openFilesInBrowser opens the GitHub pages of local item files.
+func openFilesInBrowser(paths []string) error {
+	for _, path := range paths {
+		parsed, err := parser.ParseFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse file: %w", err)
+		}
+		seg, ok := parsed.ItemType.URLSegment()
+		if !ok || parsed.Number == 0 {
+			continue
+		}
+		url := fmt.Sprintf("https://github.com/%s/%s/%s/%d", parsed.Owner, parsed.Repo, seg, parsed.Number)
+		if err := executil.OpenInBrowser(url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logLimit  int
+	logPatch  bool
+	logFormat string
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [file-path | url | owner/repo]",
+	Short: "Show the change history of an item or repository",
+	Long: `Show the change history recorded in the gh-md root.
+
+History is opt-in: with 'gh md config set history true', the gh-md root becomes
+a git repository and pull, push and prune each create a commit per repository,
+such as "pull owner/repo: 12 issues updated". Local edits are committed first
+as "edit owner/repo: ..." so they can be recovered after being pulled over.
+
+Without arguments, the history of the whole root is shown.
+
+Examples:
+  gh md log owner/repo/issues/123              # History of one issue
+  gh md log https://github.com/owner/repo/issues/123 -p
+  gh md log owner/repo --limit 20              # Recent commits for a repo
+  gh md log --format=json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLog,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().IntVarP(&logLimit, "limit", "n", 0, "Show at most this many commits (0 = all)")
+	logCmd.Flags().BoolVarP(&logPatch, "patch", "p", false, "Show the diff of each commit")
+	logCmd.Flags().StringVar(&logFormat, "format", "text", "Output format (text, json, yaml)")
+}
+
+// logEntryOutput is the structured output for a history commit.
+type logEntryOutput struct {
+	history.Entry `yaml:",inline"`
+	Patch         string `json:"patch,omitempty" yaml:"patch,omitempty"`
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, logFormat, settings, false)
+	if err != nil {
+		return err
+	}
+
+	var path string
+	if len(args) > 0 {
+		if repo, ok := batchRepoArg(args[0]); ok {
+			root, err := config.GetRootDir()
+			if err != nil {
+				return err
+			}
+			path = filepath.Join(root, filepath.FromSlash(repo))
+		} else if path, err = parser.ResolveFilePath(args[0]); err != nil {
+			return err
+		}
+	}
+
+	entries, err := history.Log(path, logLimit)
+	if err != nil {
+		if errors.Is(err, history.ErrNotEnabled) {
+			return err
+		}
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if len(entries) == 0 && !p.IsStructured() {
+		p.Print("No history recorded yet.")
+		return nil
+	}
+
+	items := make([]logEntryOutput, len(entries))
+	for i, e := range entries {
+		items[i] = logEntryOutput{Entry: e}
+		if logPatch {
+			if items[i].Patch, err = history.Patch(e.Commit, path); err != nil {
+				return fmt.Errorf("failed to read commit %s: %w", e.ShortCommit(), err)
+			}
+		}
+	}
+
+	if !logPatch || p.IsStructured() {
+		return output.List(p,
+			[]string{"COMMIT", "DATE", "CHANGE"},
+			items,
+			func(e logEntryOutput) []string {
+				return []string{e.ShortCommit(), output.FormatTime(&e.Date, output.TimestampDisplay), e.Subject}
+			},
+		)
+	}
+
+	for i, e := range items {
+		if i > 0 {
+			p.Print("")
+		}
+		header := fmt.Sprintf("commit %s  %s  %s", e.ShortCommit(), output.FormatTime(&e.Date, output.TimestampDisplay), e.Subject)
+		patch := e.Patch + "\n"
+		if output.ColorEnabled() {
+			header = output.Colorize(output.ColorBold, header)
+			patch = output.ColorizeDiff(patch)
+		}
+		p.Print(header)
+		p.Printf("%s", patch)
+	}
+	return nil
+}
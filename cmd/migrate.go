@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/migrate"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateDryRun bool
+	migrateFormat string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [owner/repo]",
+	Short: "Upgrade local files to the current format",
+	Long: `Upgrade local markdown files, their base copies and .gh-md-meta.yaml files
+to the current schema version.
+
+Originals are copied to ~/.gh-md/.gh-md-backup/<timestamp>/ before being rewritten.
+Files written by a newer version of gh-md are reported and left untouched;
+other commands refuse to read them until gh-md is upgraded.
+
+Examples:
+  gh md migrate --dry-run          # Show which files would change
+  gh md migrate                    # Migrate all repos
+  gh md migrate owner/repo         # Migrate a single repo
+  gh md migrate --dry-run --format=json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show files that would be migrated without changing them")
+	migrateCmd.Flags().StringVar(&migrateFormat, "format", "text", "Output format (text, json, yaml)")
+}
+
+type migrateOutput struct {
+	Path  string   `json:"path" yaml:"path"`
+	Kind  string   `json:"kind" yaml:"kind"`
+	From  int      `json:"from" yaml:"from"`
+	To    int      `json:"to" yaml:"to"`
+	Steps []string `json:"steps" yaml:"steps"`
+	Error string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, migrateFormat, settings, false)
+	if err != nil {
+		return err
+	}
+
+	var repoFilter string
+	if len(args) > 0 {
+		input, err := github.ParseInput(args[0])
+		if err != nil {
+			return err
+		}
+		repoFilter = input.FullName()
+	}
+
+	changes, err := migrate.Plan(repoFilter)
+	if err != nil {
+		return fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	if len(changes) == 0 {
+		p.Print("All files are up to date.")
+		return nil
+	}
+
+	items := make([]migrateOutput, len(changes))
+	unsupported := 0
+	for i, c := range changes {
+		items[i] = migrateOutput{
+			Path:  c.RelPath,
+			Kind:  string(c.Kind),
+			From:  c.From,
+			To:    c.To,
+			Steps: c.Steps,
+		}
+		if c.Unsupported() {
+			items[i].Error = "written by a newer gh-md"
+			unsupported++
+		}
+	}
+
+	if migrateDryRun || p.IsStructured() {
+		if !p.IsStructured() {
+			p.Printf("Would migrate %d file(s):\n\n", len(changes)-unsupported)
+		}
+		if err := output.List(p,
+			[]string{"PATH", "KIND", "VERSION", "CHANGES"},
+			items,
+			func(m migrateOutput) []string {
+				steps := strings.Join(m.Steps, "; ")
+				if m.Error != "" {
+					steps = m.Error
+				}
+				return []string{m.Path, m.Kind, fmt.Sprintf("%d -> %d", m.From, m.To), steps}
+			},
+		); err != nil {
+			return err
+		}
+		if migrateDryRun {
+			if !p.IsStructured() {
+				p.Print("\nRun without --dry-run to apply.")
+			}
+			return nil
+		}
+	}
+
+	backupDir, err := migrate.NewBackupDir()
+	if err != nil {
+		return err
+	}
+
+	migrated, err := migrate.Apply(changes, backupDir)
+	if err != nil {
+		return fmt.Errorf("migration failed after %d file(s): %w", migrated, err)
+	}
+
+	if !p.IsStructured() {
+		p.Printf("Migrated %d file(s). Backups saved to %s\n", migrated, backupDir)
+		if unsupported > 0 {
+			p.Errorf("Skipped %d file(s) written by a newer version of gh-md; upgrade gh-md to read them.\n", unsupported)
+		}
+	}
+
+	return nil
+}
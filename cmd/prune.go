@@ -2,42 +2,77 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/jackchuka/gh-md/internal/config"
 	"github.com/jackchuka/gh-md/internal/gitcontext"
 	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/meta"
 	"github.com/jackchuka/gh-md/internal/output"
 	"github.com/jackchuka/gh-md/internal/prune"
+	"github.com/jackchuka/gh-md/internal/search"
 	"github.com/spf13/cobra"
 )
 
 var (
-	pruneConfirm bool
-	pruneFormat  string
+	pruneConfirm      bool
+	pruneFormat       string
+	pruneColumns      string
+	pruneClosedDays   int
+	pruneStaleMonths  int
+	pruneKeepLabels   []string
+	pruneKeepAssigned bool
+	pruneKeepPinned   bool
+	pruneFilter       string
+	pruneArchive      bool
+	pruneRefresh      bool
 )
 
 var pruneCmd = &cobra.Command{
 	Use:   "prune [repo]",
 	Short: "Delete local markdown files for closed/merged items",
-	Long: `Delete local markdown files for merged PRs and closed issues/PRs.
+	Long: `Delete local markdown files for closed and merged items.
 
 By default, this command performs a dry-run and lists files that would be deleted.
 Use --confirm to actually delete the files.
 
-State is determined from local file frontmatter only (no network requests).
-If local state is stale, run 'gh md pull --include-closed' first to refresh.
+By default, state is determined from local file frontmatter only (no network
+requests). With --refresh, the current state of every candidate and every open
+local item is fetched from GitHub first, in batches, and the frontmatter of
+items whose state changed is updated, even on a dry-run. Files with unpushed
+changes keep their local state.
 
 Prunable items:
-  - Issues: state == "closed"
+  - Issues and discussions: state == "closed"
   - Pull Requests: state == "merged" or state == "closed"
-  - Discussions: state == "closed"
+  - With --closed-days N, only items closed more than N days ago
+  - With --stale-months N, also open items not updated in N months
+  - With --filter, also items matching the CEL expression (see 'gh md --help')
+
+Items with a --keep-label label, assigned to you (--keep-assigned) or pinned
+(--keep-pinned) are never pruned, and neither are files with unpushed changes
+or drafts. Every policy flag can be set as a default with
+'gh md config set prune.<key>'. The rule that matched is listed for each file.
+
+With --archive, files are moved into a compressed archive per repository and
+month (owner/repo/.archive/2026-10.tar.gz) instead of being deleted. Archived
+items are hidden from browsing and search unless --include-archived is given,
+and 'gh md restore' brings them back.
 
 Examples:
   gh md prune                    # Dry-run: list files that would be deleted
   gh md prune --confirm          # Actually delete files
+  gh md prune --archive          # Archive instead of delete (with --confirm)
+  gh md prune --refresh          # Check state on GitHub before deciding
   gh md prune owner/repo         # Dry-run for specific repo only
   gh md prune gh-md              # Partial match (resolves to owner/repo)
   gh md prune owner/repo --confirm
-  gh md prune --format=json      # Output as JSON for scripting`,
+  gh md prune --format=json      # Output as JSON for scripting
+  gh md prune --format=csv --columns repo,number,state
+  gh md prune --closed-days 30   # Only items closed over a month ago
+  gh md prune --stale-months 6 --keep-label pinned --keep-assigned
+  gh md prune --filter 'item_type == "discussion" && comment_count == 0'`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPrune,
 }
@@ -46,15 +81,35 @@ func init() {
 	rootCmd.AddCommand(pruneCmd)
 
 	pruneCmd.Flags().BoolVar(&pruneConfirm, "confirm", false, "Actually delete files (default is dry-run)")
-	pruneCmd.Flags().StringVar(&pruneFormat, "format", "text", "Output format (text, json, yaml)")
+	pruneCmd.Flags().StringVar(&pruneFormat, "format", "text", "Output format (text, json, yaml, csv, tsv, markdown, ndjson, template=<go template>)")
+	pruneCmd.Flags().StringVar(&pruneColumns, "columns", "", "Comma-separated columns for table output: "+output.ColumnNames(pruneFileColumns))
+	pruneCmd.Flags().IntVar(&pruneClosedDays, "closed-days", 0, "Only prune items closed more than this many days ago")
+	pruneCmd.Flags().IntVar(&pruneStaleMonths, "stale-months", 0, "Also prune open items not updated in this many months")
+	pruneCmd.Flags().StringSliceVar(&pruneKeepLabels, "keep-label", nil, "Never prune items with this label (repeatable)")
+	pruneCmd.Flags().BoolVar(&pruneKeepAssigned, "keep-assigned", false, "Never prune items assigned to you")
+	pruneCmd.Flags().BoolVar(&pruneKeepPinned, "keep-pinned", false, "Never prune pinned issues")
+	pruneCmd.Flags().StringVar(&pruneFilter, "filter", "", "Also prune items matching this CEL expression")
+	pruneCmd.Flags().BoolVar(&pruneArchive, "archive", false, "Move files into the repository's archive instead of deleting them")
+	pruneCmd.Flags().BoolVar(&pruneRefresh, "refresh", false, "Update local state from GitHub before finding files to prune")
 }
 
 // pruneResultOutput is the structured output for prune results.
 type pruneResultOutput struct {
-	DryRun  bool              `json:"dry_run" yaml:"dry_run"`
-	Deleted int               `json:"deleted" yaml:"deleted"`
-	Files   []pruneFileOutput `json:"files" yaml:"files"`
-	Total   int               `json:"total" yaml:"total"`
+	DryRun    bool                 `json:"dry_run" yaml:"dry_run"`
+	Archive   bool                 `json:"archive" yaml:"archive"`
+	Refreshed []pruneRefreshOutput `json:"refreshed,omitempty" yaml:"refreshed,omitempty"`
+	Deleted   int                  `json:"deleted" yaml:"deleted"`
+	Files     []pruneFileOutput    `json:"files" yaml:"files"`
+	Total     int                  `json:"total" yaml:"total"`
+}
+
+// pruneRefreshOutput is an item whose state --refresh found changed on GitHub.
+type pruneRefreshOutput struct {
+	Path    string `json:"path" yaml:"path"`
+	Number  int    `json:"number" yaml:"number"`
+	From    string `json:"from" yaml:"from"`
+	To      string `json:"to" yaml:"to"`
+	Skipped bool   `json:"skipped,omitempty" yaml:"skipped,omitempty"`
 }
 
 type pruneFileOutput struct {
@@ -64,10 +119,42 @@ type pruneFileOutput struct {
 	State    string `json:"state" yaml:"state"`
 	Owner    string `json:"owner" yaml:"owner"`
 	Repo     string `json:"repo" yaml:"repo"`
+	Rule     string `json:"rule" yaml:"rule"`
+}
+
+// pruneFileColumns are the columns --columns can choose for prunable files.
+var pruneFileColumns = []output.Column[pruneFileOutput]{
+	{Name: "repo", Value: func(f pruneFileOutput) string { return f.Owner + "/" + f.Repo }},
+	{Name: "owner", Value: func(f pruneFileOutput) string { return f.Owner }},
+	{
+		Name:    "number",
+		Value:   func(f pruneFileOutput) string { return strconv.Itoa(f.Number) },
+		Display: func(f pruneFileOutput) string { return fmt.Sprintf("#%d", f.Number) },
+	},
+	{Name: "type", Value: func(f pruneFileOutput) string { return f.ItemType }},
+	{Name: "state", Value: func(f pruneFileOutput) string { return f.State }},
+	{Name: "rule", Value: func(f pruneFileOutput) string { return f.Rule }},
+	{Name: "path", Value: func(f pruneFileOutput) string { return f.Path }},
 }
 
 func runPrune(cmd *cobra.Command, args []string) error {
-	p := output.NewPrinter(cmd).WithFormat(output.ParseFormat(pruneFormat))
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, pruneFormat, settings, true)
+	if err != nil {
+		return err
+	}
+
+	// Table formats, or text with --columns, list the files as a table
+	var cols []output.Column[pruneFileOutput]
+	if p.IsListOnly() || cmd.Flags().Changed("columns") {
+		cols, err = output.SelectColumns(pruneFileColumns, pruneColumns, "repo", "number", "type", "rule", "path")
+		if err != nil {
+			return err
+		}
+	}
 
 	var repoFilter string
 	if len(args) > 0 {
@@ -79,10 +166,24 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	} else if ctx, err := gitcontext.Detect(); err == nil {
 		// Smart context: default to current repo
 		repoFilter = ctx.FullName()
-		p.Printf("Detected repository: %s\n", repoFilter)
+		if !p.IsStructured() && cols == nil {
+			p.Printf("Detected repository: %s\n", repoFilter)
+		}
+	}
+
+	opts, err := pruneOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	var refreshed []pruneRefreshOutput
+	if pruneRefresh {
+		if refreshed, err = refreshPruneState(cmd, p, repoFilter, opts, !p.IsStructured() && cols == nil); err != nil {
+			return err
+		}
 	}
 
-	files, err := prune.FindPrunableFiles(repoFilter)
+	files, err := prune.FindPrunableFiles(repoFilter, opts)
 	if err != nil {
 		return fmt.Errorf("failed to find prunable files: %w", err)
 	}
@@ -90,12 +191,17 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	if len(files) == 0 {
 		if p.IsStructured() {
 			return p.Structured(pruneResultOutput{
-				DryRun:  !pruneConfirm,
-				Deleted: 0,
-				Files:   []pruneFileOutput{},
-				Total:   0,
+				DryRun:    !pruneConfirm,
+				Archive:   pruneArchive,
+				Refreshed: refreshed,
+				Deleted:   0,
+				Files:     []pruneFileOutput{},
+				Total:     0,
 			})
 		}
+		if cols != nil {
+			return output.ListColumns(p, cols, []pruneFileOutput{})
+		}
 		p.Print("No files to prune.")
 		return nil
 	}
@@ -110,46 +216,160 @@ func runPrune(cmd *cobra.Command, args []string) error {
 			State:    f.State,
 			Owner:    f.Owner,
 			Repo:     f.Repo,
+			Rule:     f.Rule,
 		}
 	}
 
 	if pruneConfirm {
-		// Actually delete files
-		deleted, err := prune.DeleteFiles(files)
+		repos := make([]string, len(files))
+		for i, f := range files {
+			repos[i] = f.Owner + "/" + f.Repo
+		}
+		recordHistoryRepos(p, history.ActionEdit, repos)
+
+		// Actually delete (or archive) files
+		verb := "Deleted"
+		var deleted int
+		if pruneArchive {
+			verb = "Archived"
+			deleted, err = prune.ArchiveFiles(files)
+		} else {
+			deleted, err = prune.DeleteFiles(files)
+		}
+		updateIndex(p, repos...)
+		recordHistoryRepos(p, history.ActionPrune, repos)
 		if err != nil {
 			return fmt.Errorf("failed to delete files: %w", err)
 		}
 
 		if p.IsStructured() {
 			return p.Structured(pruneResultOutput{
-				DryRun:  false,
-				Deleted: deleted,
-				Files:   outputFiles,
-				Total:   len(files),
+				DryRun:    false,
+				Archive:   pruneArchive,
+				Refreshed: refreshed,
+				Deleted:   deleted,
+				Files:     outputFiles,
+				Total:     len(files),
 			})
 		}
+		if cols != nil {
+			return output.ListColumns(p, cols, outputFiles)
+		}
 
-		p.Printf("Deleted %d files:\n", deleted)
+		p.Printf("%s %d files:\n", verb, deleted)
 		for _, f := range files {
-			p.Printf("  %s (%s)\n", f.RelativePath(), f.State)
+			p.Printf("  %s (%s)\n", f.RelativePath(), f.Rule)
 		}
 	} else {
 		// Dry-run: just list files
 		if p.IsStructured() {
 			return p.Structured(pruneResultOutput{
-				DryRun:  true,
-				Deleted: 0,
-				Files:   outputFiles,
-				Total:   len(files),
+				DryRun:    true,
+				Archive:   pruneArchive,
+				Refreshed: refreshed,
+				Deleted:   0,
+				Files:     outputFiles,
+				Total:     len(files),
 			})
 		}
+		if cols != nil {
+			return output.ListColumns(p, cols, outputFiles)
+		}
 
-		p.Printf("Would delete %d files:\n", len(files))
+		verb := "delete"
+		if pruneArchive {
+			verb = "archive"
+		}
+		p.Printf("Would %s %d files:\n", verb, len(files))
 		for _, f := range files {
-			p.Printf("  %s (%s)\n", f.RelativePath(), f.State)
+			p.Printf("  %s (%s)\n", f.RelativePath(), f.Rule)
 		}
-		p.Print("\nRun with --confirm to delete these files.")
+		p.Printf("\nRun with --confirm to %s these files.\n", verb)
 	}
 
 	return nil
 }
+
+// refreshPruneState updates the local state of prune candidates and open items
+// from GitHub, printing what changed when verbose.
+func refreshPruneState(cmd *cobra.Command, p *output.Printer, repoFilter string, opts prune.Options, verbose bool) ([]pruneRefreshOutput, error) {
+	client, err := github.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	s := newSpinner(cmd.ErrOrStderr(), "Refreshing state from GitHub...")
+	s.Start()
+	changes, err := prune.Refresh(client, repoFilter, opts)
+	s.Stop()
+
+	var repos []string
+	out := make([]pruneRefreshOutput, len(changes))
+	for i, c := range changes {
+		if !c.Skipped {
+			repos = append(repos, c.Owner+"/"+c.Repo)
+		}
+		out[i] = pruneRefreshOutput{
+			Path:    c.RelativePath(),
+			Number:  c.Number,
+			From:    c.From,
+			To:      c.To,
+			Skipped: c.Skipped,
+		}
+	}
+	updateIndex(p, repos...)
+	recordHistoryRepos(p, history.ActionPull, repos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh state: %w", err)
+	}
+
+	if verbose && len(out) > 0 {
+		p.Printf("Refreshed %d items:\n", len(out))
+		for _, c := range out {
+			if c.Skipped {
+				p.Printf("  %s (%s on GitHub; kept %s, has unpushed changes)\n", c.Path, c.To, c.From)
+			} else {
+				p.Printf("  %s (%s -> %s)\n", c.Path, c.From, c.To)
+			}
+		}
+		p.Print("")
+	}
+	return out, nil
+}
+
+// pruneOptions builds the prune policy overrides from the flags that were set.
+func pruneOptions(cmd *cobra.Command) (prune.Options, error) {
+	flags := cmd.Flags()
+	policy := &config.PrunePolicy{}
+	if flags.Changed("closed-days") {
+		if pruneClosedDays < 0 {
+			return prune.Options{}, fmt.Errorf("--closed-days must not be negative")
+		}
+		policy.ClosedDays = &pruneClosedDays
+	}
+	if flags.Changed("stale-months") {
+		if pruneStaleMonths < 0 {
+			return prune.Options{}, fmt.Errorf("--stale-months must not be negative")
+		}
+		policy.StaleMonths = &pruneStaleMonths
+	}
+	if flags.Changed("keep-label") {
+		policy.KeepLabels = pruneKeepLabels
+	}
+	if flags.Changed("keep-assigned") {
+		policy.KeepAssigned = &pruneKeepAssigned
+	}
+	if flags.Changed("keep-pinned") {
+		policy.KeepPinned = &pruneKeepPinned
+	}
+
+	opts := prune.Options{Policy: policy, CurrentUser: search.GetCurrentUser}
+	if pruneFilter != "" {
+		prg, err := search.CompileCELFilter(pruneFilter)
+		if err != nil {
+			return prune.Options{}, err
+		}
+		opts.Filter = prg
+	}
+	return opts, nil
+}
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/jackchuka/gh-md/internal/assets"
+	"github.com/jackchuka/gh-md/internal/config"
 	"github.com/jackchuka/gh-md/internal/discovery"
 	"github.com/jackchuka/gh-md/internal/gitcontext"
 	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
 	"github.com/jackchuka/gh-md/internal/meta"
 	"github.com/jackchuka/gh-md/internal/output"
 	"github.com/jackchuka/gh-md/internal/writer"
@@ -21,6 +26,8 @@ var (
 	pullOpenOnly    bool
 	pullFull        bool
 	pullAllRepos    bool
+	pullOverwrite   bool
+	pullAssets      bool
 )
 
 var pullCmd = &cobra.Command{
@@ -37,9 +44,22 @@ When run with explicit arguments, pulls the specified items.
 Partial repo names are resolved against locally managed repos.
 
 By default, all items (open and closed) are fetched for accurate state tracking.
+Defaults for item types, --open-only, --limit, label filters and the repositories
+included by --all can be set with 'gh md config set'. Flags always take precedence.
 Incremental sync is used automatically - only items updated since the last pull are fetched.
+A label filter is applied by GitHub, so --limit counts matching issues and pull
+requests only; use --full after changing it to fetch older items that now match.
+Discussions have no labels to filter on and are always pulled.
 Single-item pulls (e.g., owner/repo/issues/123) always fetch regardless of state.
 
+Local edits are never overwritten silently: new-comment drafts are carried over
+into the freshly pulled file, and files with other unpushed edits are skipped
+with a warning. Use --overwrite to discard local changes.
+
+With --assets (or 'gh md config set assets true'), image and file attachments are
+downloaded to an assets/ directory beside each item and links are rewritten to
+the local copies. Push turns them back into GitHub URLs.
+
 Examples:
   gh md pull                           # Smart pull based on current git context
   gh md pull owner/repo
@@ -47,6 +67,8 @@ Examples:
   gh md pull owner/repo --issues --limit 10
   gh md pull owner/repo --open-only
   gh md pull owner/repo --full
+  gh md pull owner/repo --assets       # Download attachments for offline use
+  gh md pull owner/repo/issues/123 --overwrite   # Discard local edits
   gh md pull --all
   gh md pull https://github.com/owner/repo/issues/123
   gh md pull owner/repo/issues/123.md`,
@@ -62,6 +84,8 @@ func init() {
 	pullCmd.Flags().BoolVar(&pullOpenOnly, "open-only", false, "Fetch only open items (default fetches all states)")
 	pullCmd.Flags().BoolVar(&pullFull, "full", false, "Full sync - ignore last sync timestamp")
 	pullCmd.Flags().BoolVar(&pullAllRepos, "all", false, "Pull all managed repositories")
+	pullCmd.Flags().BoolVar(&pullOverwrite, "overwrite", false, "Overwrite local files even if they have unpushed changes")
+	pullCmd.Flags().BoolVar(&pullAssets, "assets", false, "Download attachments and link local copies")
 }
 
 func runPull(cmd *cobra.Command, args []string) error {
@@ -137,9 +161,23 @@ func runSmartPull(cmd *cobra.Command) error {
 func runPullAll(cmd *cobra.Command) error {
 	p := output.NewPrinter(cmd)
 
-	repos, err := discovery.DiscoverManagedRepos()
+	settings, err := meta.ResolveSettings("", "")
 	if err != nil {
-		return fmt.Errorf("failed to discover repositories: %w", err)
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	var repos []discovery.ManagedRepo
+	if len(settings.Repos) > 0 {
+		// Configured repository list replaces discovery
+		for _, slug := range settings.Repos {
+			owner, repo, _ := strings.Cut(slug, "/")
+			repos = append(repos, discovery.ManagedRepo{Owner: owner, Repo: repo})
+		}
+	} else {
+		repos, err = discovery.DiscoverManagedRepos()
+		if err != nil {
+			return fmt.Errorf("failed to discover repositories: %w", err)
+		}
 	}
 
 	if len(repos) == 0 {
@@ -173,13 +211,75 @@ func runPullAll(cmd *cobra.Command) error {
 	return nil
 }
 
+// pullOptions holds the effective pull options after layering flags over settings.
+type pullOptions struct {
+	issues      bool
+	prs         bool
+	discussions bool
+	limit       int
+	openOnly    bool
+	labels      []string
+	assets      bool
+}
+
+// resolvePullOptions applies explicitly given flags over the resolved settings.
+func resolvePullOptions(cmd *cobra.Command, settings *config.Settings) pullOptions {
+	opts := pullOptions{
+		limit:    pullLimit,
+		openOnly: pullOpenOnly,
+		labels:   settings.Labels,
+	}
+	opts.issues, opts.prs, opts.discussions = resolveTypes(pullIssues, pullPRs, pullDiscussions, settings)
+
+	if !cmd.Flags().Changed("limit") && settings.Limit != nil {
+		opts.limit = *settings.Limit
+	}
+	if !cmd.Flags().Changed("open-only") && settings.OpenOnly != nil {
+		opts.openOnly = *settings.OpenOnly
+	}
+	opts.assets = pullAssets || (!cmd.Flags().Changed("assets") && settings.AssetsEnabled())
+
+	return opts
+}
+
+// newWriteOptions returns the options for writing pulled items, with a downloader
+// when attachments are saved locally.
+func newWriteOptions(overwrite, withAssets bool) (writer.WriteOptions, error) {
+	opts := writer.WriteOptions{Overwrite: overwrite}
+	if withAssets {
+		d, err := assets.NewDownloader()
+		if err != nil {
+			return opts, err
+		}
+		opts.Assets = d
+	}
+	return opts, nil
+}
+
 func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) error {
+	p := output.NewPrinter(cmd)
+
+	// Snapshot local edits before pulled content lands on top of them
+	recordHistory(p, history.ActionEdit, owner, repo)
+	defer recordHistory(p, history.ActionPull, owner, repo)
+	defer updateIndex(p, owner+"/"+repo)
+
 	// Load sync metadata
 	md, err := meta.Load(owner, repo)
 	if err != nil {
 		return fmt.Errorf("failed to load sync metadata: %w", err)
 	}
 
+	settings, err := meta.ResolveSettings(owner, repo)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	opts := resolvePullOptions(cmd, settings)
+	writeOpts, err := newWriteOptions(pullOverwrite, opts.assets)
+	if err != nil {
+		return err
+	}
+
 	// Get since timestamps (nil if --full or no prior sync)
 	var issuesSince, pullsSince, discussionsSince *time.Time
 	if !pullFull && md.Sync != nil {
@@ -191,9 +291,6 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 	// Track sync start time for saving later
 	syncStart := time.Now()
 
-	// If no type flags are set, pull all types
-	pullAll := !pullIssues && !pullPRs && !pullDiscussions
-
 	var totalErrors []error
 
 	handlers := []struct {
@@ -202,7 +299,7 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 		run      func() error
 	}{
 		{
-			enabled:  pullAll || pullIssues,
+			enabled:  opts.issues,
 			itemType: github.ItemTypeIssue,
 			run: func() error {
 				return pullAllItems(
@@ -211,15 +308,16 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 					repo,
 					github.ItemTypeIssue,
 					func(progress github.ProgressFunc) ([]github.Issue, error) {
-						return client.FetchIssues(owner, repo, pullLimit, pullOpenOnly, issuesSince, progress)
+						return client.FetchIssues(owner, repo, opts.limit, opts.openOnly, opts.labels, issuesSince, progress)
 					},
 					writer.WriteIssue,
+					writeOpts,
 					func(i *github.Issue) int { return i.Number },
 				)
 			},
 		},
 		{
-			enabled:  pullAll || pullPRs,
+			enabled:  opts.prs,
 			itemType: github.ItemTypePullRequest,
 			run: func() error {
 				return pullAllItems(
@@ -228,15 +326,16 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 					repo,
 					github.ItemTypePullRequest,
 					func(progress github.ProgressFunc) ([]github.PullRequest, error) {
-						return client.FetchPullRequests(owner, repo, pullLimit, pullOpenOnly, pullsSince, progress)
+						return client.FetchPullRequests(owner, repo, opts.limit, opts.openOnly, opts.labels, pullsSince, progress)
 					},
 					writer.WritePullRequest,
+					writeOpts,
 					func(pr *github.PullRequest) int { return pr.Number },
 				)
 			},
 		},
 		{
-			enabled:  pullAll || pullDiscussions,
+			enabled:  opts.discussions,
 			itemType: github.ItemTypeDiscussion,
 			run: func() error {
 				return pullAllItems(
@@ -245,9 +344,10 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 					repo,
 					github.ItemTypeDiscussion,
 					func(progress github.ProgressFunc) ([]github.Discussion, error) {
-						return client.FetchDiscussions(owner, repo, pullLimit, pullOpenOnly, discussionsSince, progress)
+						return client.FetchDiscussions(owner, repo, opts.limit, opts.openOnly, discussionsSince, progress)
 					},
 					writer.WriteDiscussion,
+					writeOpts,
 					func(d *github.Discussion) int { return d.Number },
 				)
 			},
@@ -263,23 +363,21 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 		}
 	}
 
-	p := output.NewPrinter(cmd)
-
 	// Save sync timestamps on success
 	if len(totalErrors) == 0 {
 		if md.Sync == nil {
 			md.Sync = &meta.SyncTimestamps{}
 		}
 		// Save previous timestamps for --new flag
-		if pullAll || pullIssues {
+		if opts.issues {
 			md.Sync.PrevIssues = md.Sync.Issues
 			md.Sync.Issues = &syncStart
 		}
-		if pullAll || pullPRs {
+		if opts.prs {
 			md.Sync.PrevPulls = md.Sync.Pulls
 			md.Sync.Pulls = &syncStart
 		}
-		if pullAll || pullDiscussions {
+		if opts.discussions {
 			md.Sync.PrevDiscussions = md.Sync.Discussions
 			md.Sync.Discussions = &syncStart
 		}
@@ -300,6 +398,15 @@ func pullRepo(cmd *cobra.Command, client *github.Client, owner, repo string) err
 }
 
 func pullSingleItem(cmd *cobra.Command, client *github.Client, input *github.ParsedInput) error {
+	settings, err := meta.ResolveSettings(input.Owner, input.Repo)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	writeOpts, err := newWriteOptions(pullOverwrite, resolvePullOptions(cmd, settings).assets)
+	if err != nil {
+		return err
+	}
+
 	handlers := map[github.ItemType]func() error{
 		github.ItemTypeIssue: func() error {
 			return pullSingle(
@@ -307,6 +414,7 @@ func pullSingleItem(cmd *cobra.Command, client *github.Client, input *github.Par
 				input,
 				func() (*github.Issue, error) { return client.FetchIssue(input.Owner, input.Repo, input.Number) },
 				writer.WriteIssue,
+				writeOpts,
 				"issue",
 				func(i *github.Issue) int { return i.Number },
 			)
@@ -319,6 +427,7 @@ func pullSingleItem(cmd *cobra.Command, client *github.Client, input *github.Par
 					return client.FetchPullRequest(input.Owner, input.Repo, input.Number)
 				},
 				writer.WritePullRequest,
+				writeOpts,
 				"PR",
 				func(pr *github.PullRequest) int { return pr.Number },
 			)
@@ -331,6 +440,7 @@ func pullSingleItem(cmd *cobra.Command, client *github.Client, input *github.Par
 					return client.FetchDiscussion(input.Owner, input.Repo, input.Number)
 				},
 				writer.WriteDiscussion,
+				writeOpts,
 				"discussion",
 				func(d *github.Discussion) int { return d.Number },
 			)
@@ -341,6 +451,12 @@ func pullSingleItem(cmd *cobra.Command, client *github.Client, input *github.Par
 	if !ok {
 		return fmt.Errorf("unsupported item type: %s", input.ItemType)
 	}
+
+	p := output.NewPrinter(cmd)
+	recordHistory(p, history.ActionEdit, input.Owner, input.Repo)
+	defer recordHistory(p, history.ActionPull, input.Owner, input.Repo)
+	defer updateIndex(p, input.FullName())
+
 	return handler()
 }
 
@@ -348,7 +464,8 @@ func pullSingle[T any](
 	cmd *cobra.Command,
 	input *github.ParsedInput,
 	fetch func() (*T, error),
-	write func(*T) (string, error),
+	write func(*T, writer.WriteOptions) (string, error),
+	writeOpts writer.WriteOptions,
 	writtenLabel string,
 	number func(*T) int,
 ) error {
@@ -360,7 +477,10 @@ func pullSingle[T any](
 	if err != nil {
 		return err
 	}
-	path, err := write(item)
+	path, err := write(item, writeOpts)
+	if errors.Is(err, writer.ErrLocallyModified) {
+		return fmt.Errorf("%s has unpushed local changes; push them first or use --overwrite to discard them", path)
+	}
 	if err != nil {
 		return err
 	}
@@ -373,7 +493,8 @@ func pullAllItems[T any](
 	owner, repo string,
 	itemType github.ItemType,
 	fetch func(github.ProgressFunc) ([]T, error),
-	write func(*T) (string, error),
+	write func(*T, writer.WriteOptions) (string, error),
+	writeOpts writer.WriteOptions,
 	number func(*T) int,
 ) error {
 	p := output.NewPrinter(cmd)
@@ -396,13 +517,25 @@ func pullAllItems[T any](
 		return nil
 	}
 
+	var skipped []string
 	for i := range items {
 		item := &items[i]
-		if _, err := write(item); err != nil {
+		path, err := write(item, writeOpts)
+		if errors.Is(err, writer.ErrLocallyModified) {
+			skipped = append(skipped, path)
+			continue
+		}
+		if err != nil {
 			return fmt.Errorf("failed to write %s #%d: %w", itemType.Display(), number(item), err)
 		}
 	}
 
-	p.Printf("Wrote %d %s\n", len(items), plural)
+	p.Printf("Wrote %d %s\n", len(items)-len(skipped), plural)
+	if len(skipped) > 0 {
+		p.Errorf("Skipped %d %s with unpushed local changes (push them or use --overwrite):\n", len(skipped), plural)
+		for _, path := range skipped {
+			p.Errorf("  %s\n", path)
+		}
+	}
 	return nil
 }
@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/briandowns/spinner"
+	"github.com/jackchuka/gh-md/internal/assets"
 	"github.com/jackchuka/gh-md/internal/gitcontext"
 	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/journal"
+	"github.com/jackchuka/gh-md/internal/meta"
 	"github.com/jackchuka/gh-md/internal/output"
 	"github.com/jackchuka/gh-md/internal/parser"
 	"github.com/jackchuka/gh-md/internal/search"
@@ -15,12 +20,19 @@ import (
 )
 
 var (
-	pushForce  bool
-	pushDryRun bool
+	pushForce       bool
+	pushDryRun      bool
+	pushAll         bool
+	pushFilter      string
+	pushYes         bool
+	pushConcurrency int
+	pushQueue       bool
+	pushFlush       bool
+	pushResume      bool
 )
 
 var pushCmd = &cobra.Command{
-	Use:   "push [file-path | url]",
+	Use:   "push [file-path | url | owner/repo]",
 	Short: "Push local changes back to GitHub",
 	Long: `Push local markdown changes back to GitHub.
 
@@ -33,11 +45,29 @@ Supports pushing:
   - New comments
   - Edited comments
 
+Batch mode pushes every locally modified file at once. It is used with --all,
+--filter, or a repository argument. A plan of all changes is shown and confirmed
+before anything is pushed; conflicting files are skipped unless --force is given.
+
+Offline, --queue records a snapshot of the file(s) instead of pushing. Run
+--flush later to replay queued pushes in order; each is checked for conflicts
+again, and skipped or failed pushes stay queued.
+
+Each push is journaled operation by operation. If a push is interrupted, run it
+again or use --resume to retry every unfinished push; operations that were
+already applied, such as posting a new comment, are not repeated.
+
 Examples:
   gh md push                                      # Smart: FZF selector for current repo
   gh md push ~/.gh-md/owner/repo/issues/123.md
   gh md push https://github.com/owner/repo/issues/123
-  gh md push --dry-run <file>`,
+  gh md push --dry-run <file>
+  gh md push --all                                # All modified files
+  gh md push owner/repo                           # Modified files in a repo
+  gh md push --filter 'labels.exists(l, l == "bug")' --yes
+  gh md push --queue <file>                       # Queue while offline
+  gh md push --flush                              # Replay queued pushes
+  gh md push --resume                             # Retry interrupted pushes`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPush,
 }
@@ -47,6 +77,15 @@ func init() {
 
 	pushCmd.Flags().BoolVar(&pushForce, "force", false, "Push even if remote has newer changes")
 	pushCmd.Flags().BoolVar(&pushDryRun, "dry-run", false, "Show what would be pushed without making changes")
+	pushCmd.Flags().BoolVar(&pushAll, "all", false, "Push all locally modified files")
+	pushCmd.Flags().StringVar(&pushFilter, "filter", "", "Push locally modified files matching a CEL expression")
+	pushCmd.Flags().BoolVarP(&pushYes, "yes", "y", false, "Skip the confirmation prompt in batch mode")
+	pushCmd.Flags().IntVar(&pushConcurrency, "concurrency", 4, "Maximum number of files pushed in parallel in batch mode")
+	pushCmd.Flags().BoolVar(&pushQueue, "queue", false, "Queue the push to replay later with --flush (works offline)")
+	pushCmd.Flags().BoolVar(&pushFlush, "flush", false, "Replay queued pushes")
+	pushCmd.Flags().BoolVar(&pushResume, "resume", false, "Retry interrupted pushes without repeating applied changes")
+	pushCmd.MarkFlagsMutuallyExclusive("queue", "flush", "resume")
+	pushCmd.MarkFlagsMutuallyExclusive("queue", "dry-run")
 }
 
 // changePlan represents all changes to be pushed.
@@ -60,11 +99,46 @@ type changePlan struct {
 func runPush(cmd *cobra.Command, args []string) error {
 	p := output.NewPrinter(cmd)
 
+	if pushFlush {
+		if len(args) > 0 || pushAll || pushFilter != "" {
+			return fmt.Errorf("--flush replays the whole queue and cannot be combined with files or filters")
+		}
+		return runFlushQueue(cmd)
+	}
+
+	if pushResume {
+		if pushAll || pushFilter != "" {
+			return fmt.Errorf("--resume cannot be combined with --all or --filter")
+		}
+		return runResume(cmd, args)
+	}
+
+	// Batch mode
+	if pushAll || pushFilter != "" {
+		var repoFilter string
+		if len(args) > 0 {
+			if pushAll {
+				return fmt.Errorf("--all flag cannot be used with a specific repository")
+			}
+			repo, ok := batchRepoArg(args[0])
+			if !ok {
+				return fmt.Errorf("--filter requires a repository argument, not a file: %s", args[0])
+			}
+			repoFilter = repo
+		}
+		return runBatchPush(cmd, repoFilter)
+	}
+	if len(args) == 1 {
+		if repo, ok := batchRepoArg(args[0]); ok {
+			return runBatchPush(cmd, repo)
+		}
+	}
+
 	var filePath string
 	var err error
 
 	if len(args) == 0 {
-		filePath, err = selectFileForPush()
+		filePath, err = selectRepoFile()
 		if err != nil {
 			return err
 		}
@@ -80,6 +154,75 @@ func runPush(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if pushQueue {
+		return queueFiles(p, []string{filePath})
+	}
+
+	return pushFile(cmd, filePath)
+}
+
+// runResume retries interrupted pushes recorded in the journal, optionally
+// limited to a repository or a single file.
+func runResume(cmd *cobra.Command, args []string) error {
+	p := output.NewPrinter(cmd)
+
+	var repoFilter string
+	if len(args) == 1 {
+		if repo, ok := batchRepoArg(args[0]); ok {
+			repoFilter = repo
+		} else {
+			filePath, err := parser.ResolveFilePath(args[0])
+			if err != nil {
+				return err
+			}
+			j, err := journal.Open(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to open push journal: %w", err)
+			}
+			if !j.Exists() {
+				return fmt.Errorf("no interrupted push for %s", filePath)
+			}
+			return pushFile(cmd, filePath)
+		}
+	}
+
+	journals, err := journal.List(repoFilter)
+	if err != nil {
+		return fmt.Errorf("failed to list push journals: %w", err)
+	}
+	if len(journals) == 0 {
+		p.Print("No interrupted pushes.")
+		return nil
+	}
+
+	failed := 0
+	for _, j := range journals {
+		filePath, err := j.ItemPath()
+		if err != nil {
+			return err
+		}
+		if err := pushFile(cmd, filePath); err != nil {
+			p.Errorf("%s: %v\n", j.Item, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d interrupted push(es) failed", failed, len(journals))
+	}
+	return nil
+}
+
+// pushFile pushes a single local file, resuming an interrupted push if one was journaled.
+func pushFile(cmd *cobra.Command, filePath string) error {
+	p := output.NewPrinter(cmd)
+
+	if !pushDryRun {
+		if _, err := assignDraftIDs(filePath); err != nil {
+			return err
+		}
+	}
+
 	// Parse the markdown file
 	parsed, err := parser.ParseFile(filePath)
 	if err != nil {
@@ -90,6 +233,19 @@ func runPush(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine item type from path: %s", filePath)
 	}
 
+	if err := restoreAssetLinks(parsed); err != nil {
+		return err
+	}
+
+	j, err := journal.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open push journal: %w", err)
+	}
+	if j.Exists() {
+		p.Printf("Resuming interrupted push of %s #%d (started %s)\n",
+			parsed.ItemType, parsed.Number, output.FormatTime(&j.Started, output.TimestampDisplay))
+	}
+
 	// Create GitHub client
 	client, err := github.NewClient()
 	if err != nil {
@@ -113,7 +269,8 @@ func runPush(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to fetch remote comments: %w", err)
 	}
 
-	if remoteState.UpdatedAt.After(parsed.Updated) {
+	// When resuming, the remote may have been updated by the interrupted push itself
+	if remoteState.UpdatedAt.After(parsed.Updated) && !j.CoversUpdate(remoteState.UpdatedAt) {
 		if !pushForce {
 			p.Errorf("Conflict: remote has been updated since last pull\n")
 			p.Errorf("  Local:  %s\n", output.FormatTime(&parsed.Updated, output.TimestampDisplay))
@@ -136,11 +293,16 @@ func runPush(cmd *cobra.Command, args []string) error {
 	// Check if there are any changes
 	if !hasChanges(plan) {
 		p.Printf("No changes to push for %s #%d\n", parsed.ItemType, parsed.Number)
-		return nil
+		return j.Remove()
 	}
 
+	recordHistory(p, history.ActionEdit, parsed.Owner, parsed.Repo)
+
 	// Execute changes
-	if err := executeChanges(p, client, parsed, plan, s); err != nil {
+	err = executeChanges(client, parsed, plan, j, spinnerProgress{p: p, s: s})
+	s.Stop()
+	if err != nil {
+		p.Errorf("Push interrupted. Run 'gh md push --resume' to retry; applied changes will not be repeated.\n")
 		return err
 	}
 
@@ -151,10 +313,18 @@ func runPush(cmd *cobra.Command, args []string) error {
 	err = repullItem(client, parsed)
 	s.Stop()
 	if err != nil {
+		// Keep the journal so pushing the unsynced file again won't repeat changes
 		p.Errorf("Warning: failed to sync local file: %v\n", err)
 		p.Errorf("Run 'gh md pull' to sync manually\n")
+		return nil
+	}
+
+	if err := j.Remove(); err != nil {
+		p.Errorf("Warning: failed to remove push journal: %v\n", err)
 	}
 
+	updateIndex(p, parsed.Owner+"/"+parsed.Repo)
+	recordHistory(p, history.ActionPush, parsed.Owner, parsed.Repo)
 	return nil
 }
 
@@ -246,117 +416,272 @@ func printDryRun(p *output.Printer, parsed *parser.ParsedFile, plan changePlan)
 	}
 }
 
-func executeChanges(p *output.Printer, client *github.Client, parsed *parser.ParsedFile, plan changePlan, s *spinner.Spinner) error {
-	// 1. Update title/body
-	s.Suffix = fmt.Sprintf(" Pushing %s #%d...", parsed.ItemType, parsed.Number)
-	s.Start()
+// pushProgress receives progress updates while changes are pushed.
+type pushProgress interface {
+	Start(msg string) // A step is starting
+	Done(msg string)  // A step completed successfully
+}
 
-	var err error
-	switch parsed.ItemType {
-	case github.ItemTypeIssue:
-		err = client.UpdateIssue(parsed.ID, parsed.Title, parsed.Body)
-	case github.ItemTypePullRequest:
-		err = client.UpdatePullRequest(parsed.ID, parsed.Title, parsed.Body)
-	case github.ItemTypeDiscussion:
-		err = client.UpdateDiscussion(parsed.ID, parsed.Title, parsed.Body)
-	}
+// spinnerProgress reports push progress with a spinner and a line per completed step.
+type spinnerProgress struct {
+	p *output.Printer
+	s *spinner.Spinner
+}
 
-	s.Stop()
-	if err != nil {
-		return err
+func (sp spinnerProgress) Start(msg string) {
+	sp.s.Suffix = " " + msg
+	sp.s.Start()
+}
+
+func (sp spinnerProgress) Done(msg string) {
+	sp.s.Stop()
+	sp.p.Print(msg)
+}
+
+// pushOp is a single mutation of a push, keyed for the push journal.
+type pushOp struct {
+	key   string
+	start string // Progress message while running
+	done  string // Message once applied
+	run   func() error
+}
+
+// planOps turns a change plan into the ordered list of mutations to apply.
+func planOps(client *github.Client, parsed *parser.ParsedFile, plan changePlan) []pushOp {
+	var ops []pushOp
+
+	// 1. Update title/body
+	if plan.titleBodyChanged {
+		ops = append(ops, pushOp{
+			key:   journal.Key("title_body", parsed.Title, parsed.Body),
+			start: fmt.Sprintf("Pushing %s #%d...", parsed.ItemType, parsed.Number),
+			done:  fmt.Sprintf("Pushed %s #%d", parsed.ItemType, parsed.Number),
+			run: func() error {
+				switch parsed.ItemType {
+				case github.ItemTypeIssue:
+					return client.UpdateIssue(parsed.ID, parsed.Title, parsed.Body)
+				case github.ItemTypePullRequest:
+					return client.UpdatePullRequest(parsed.ID, parsed.Title, parsed.Body)
+				case github.ItemTypeDiscussion:
+					return client.UpdateDiscussion(parsed.ID, parsed.Title, parsed.Body)
+				}
+				return nil
+			},
+		})
 	}
-	p.Printf("Pushed %s #%d\n", parsed.ItemType, parsed.Number)
 
 	// 2. Update state (issues and PRs only)
 	if plan.stateChange != "" && parsed.ItemType != github.ItemTypeDiscussion {
-		s.Suffix = fmt.Sprintf(" Updating state to %s...", plan.stateChange)
-		s.Start()
+		ops = append(ops, pushOp{
+			key:   journal.Key("state", plan.stateChange),
+			start: fmt.Sprintf("Updating state to %s...", plan.stateChange),
+			done:  fmt.Sprintf("State changed to %s", plan.stateChange),
+			run: func() error {
+				var err error
+				switch parsed.ItemType {
+				case github.ItemTypeIssue:
+					if plan.stateChange == "close" {
+						err = client.CloseIssue(parsed.ID)
+					} else {
+						err = client.ReopenIssue(parsed.ID)
+					}
+				case github.ItemTypePullRequest:
+					if plan.stateChange == "close" {
+						err = client.ClosePullRequest(parsed.ID)
+					} else {
+						err = client.ReopenPullRequest(parsed.ID)
+					}
+				}
+				if err != nil {
+					return fmt.Errorf("failed to %s: %w", plan.stateChange, err)
+				}
+				return nil
+			},
+		})
+	}
 
-		switch parsed.ItemType {
-		case github.ItemTypeIssue:
-			if plan.stateChange == "close" {
-				err = client.CloseIssue(parsed.ID)
-			} else {
-				err = client.ReopenIssue(parsed.ID)
-			}
-		case github.ItemTypePullRequest:
-			if plan.stateChange == "close" {
-				err = client.ClosePullRequest(parsed.ID)
-			} else {
-				err = client.ReopenPullRequest(parsed.ID)
-			}
-		}
+	// 3. Update existing comments
+	for _, c := range plan.editedComments {
+		ops = append(ops, pushOp{
+			key:   journal.Key("edit_comment", c.ID, c.Body),
+			start: fmt.Sprintf("Updating comment %s...", c.ID),
+			done:  fmt.Sprintf("Updated comment %s", c.ID),
+			run: func() error {
+				var err error
+				if parsed.ItemType == github.ItemTypeDiscussion {
+					err = client.UpdateDiscussionComment(c.ID, c.Body)
+				} else {
+					err = client.UpdateIssueComment(c.ID, c.Body)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to update comment %s: %w", c.ID, err)
+				}
+				return nil
+			},
+		})
+	}
 
-		s.Stop()
-		if err != nil {
-			return fmt.Errorf("failed to %s: %w", plan.stateChange, err)
+	// 4. Add new comments
+	// Drafts are keyed by their local ID; identical drafts without one are told
+	// apart by their occurrence so each is posted once.
+	seen := make(map[string]int)
+	for _, c := range plan.newComments {
+		occurrence := seen[c.ParentID+"\x00"+c.Body]
+		seen[c.ParentID+"\x00"+c.Body]++
+
+		key := journal.Key("new_comment", c.ParentID, c.Body, fmt.Sprint(occurrence))
+		if c.LocalID != "" {
+			key = journal.Key("new_comment", c.LocalID)
 		}
-		p.Printf("State changed to %s\n", plan.stateChange)
+
+		ops = append(ops, pushOp{
+			key:   key,
+			start: "Adding new comment...",
+			done:  "Added new comment",
+			run: func() error {
+				var id string
+				var err error
+				switch parsed.ItemType {
+				case github.ItemTypeDiscussion:
+					if c.ParentID != "" {
+						// Reply to existing discussion comment
+						id, err = client.AddDiscussionCommentReply(c.ParentID, c.Body)
+					} else {
+						// Top-level discussion comment
+						id, err = client.AddDiscussionComment(parsed.ID, c.Body)
+					}
+				case github.ItemTypePullRequest:
+					if c.ParentID != "" {
+						// Reply to review thread
+						id, err = client.AddReviewThreadReply(c.ParentID, c.Body)
+					} else {
+						// Regular PR comment
+						id, err = client.AddComment(parsed.ID, c.Body)
+					}
+				default:
+					// Issues use the same mutation for all comments
+					id, err = client.AddComment(parsed.ID, c.Body)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to add comment: %w", err)
+				}
+
+				// Best effort: the journal already keeps a retry from posting it again
+				if c.LocalID != "" {
+					_ = markDraftPosted(parsed.FilePath, c.LocalID, id)
+				}
+				return nil
+			},
+		})
 	}
 
-	// 3. Update existing comments
-	for _, c := range plan.editedComments {
-		s.Suffix = fmt.Sprintf(" Updating comment %s...", c.ID)
-		s.Start()
+	return ops
+}
 
-		if parsed.ItemType == github.ItemTypeDiscussion {
-			err = client.UpdateDiscussionComment(c.ID, c.Body)
-		} else {
-			err = client.UpdateIssueComment(c.ID, c.Body)
+// executeChanges applies a change plan, recording each operation in the push
+// journal. Operations the journal marks as applied by an interrupted push are skipped.
+func executeChanges(client *github.Client, parsed *parser.ParsedFile, plan changePlan, j *journal.Journal, progress pushProgress) error {
+	ops := planOps(client, parsed, plan)
+
+	planned := make([]journal.Op, len(ops))
+	for i, op := range ops {
+		planned[i] = journal.Op{Key: op.key, Description: op.done}
+	}
+	if err := j.Record(planned...); err != nil {
+		return fmt.Errorf("failed to record push journal: %w", err)
+	}
+
+	for _, op := range ops {
+		if j.IsDone(op.key) {
+			progress.Done(op.done + " (already applied)")
+			continue
 		}
 
-		s.Stop()
-		if err != nil {
-			return fmt.Errorf("failed to update comment %s: %w", c.ID, err)
+		progress.Start(op.start)
+		if err := op.run(); err != nil {
+			return err
+		}
+		if err := j.MarkDone(op.key); err != nil {
+			return fmt.Errorf("failed to record push journal: %w", err)
 		}
-		p.Printf("Updated comment %s\n", c.ID)
+		progress.Done(op.done)
 	}
 
-	// 4. Add new comments
-	for _, c := range plan.newComments {
-		s.Suffix = " Adding new comment..."
-		s.Start()
+	return nil
+}
 
-		switch parsed.ItemType {
-		case github.ItemTypeDiscussion:
-			if c.ParentID != "" {
-				// Reply to existing discussion comment
-				err = client.AddDiscussionCommentReply(c.ParentID, c.Body)
-			} else {
-				// Top-level discussion comment
-				err = client.AddDiscussionComment(parsed.ID, c.Body)
-			}
-		case github.ItemTypePullRequest:
-			if c.ParentID != "" {
-				// Reply to review thread
-				err = client.AddReviewThreadReply(c.ParentID, c.Body)
-			} else {
-				// Regular PR comment
-				err = client.AddComment(parsed.ID, c.Body)
-			}
-		default:
-			// Issues use the same mutation for all comments
-			err = client.AddComment(parsed.ID, c.Body)
-		}
+// restoreAssetLinks turns links to downloaded attachments back into GitHub URLs
+// in the body and comments, failing for local images GitHub doesn't have.
+func restoreAssetLinks(parsed *parser.ParsedFile) error {
+	body, err := assets.Restore(parsed.Body, parsed.FilePath)
+	if err != nil {
+		return err
+	}
+	parsed.Body = body
 
-		s.Stop()
+	for i := range parsed.Comments {
+		body, err := assets.Restore(parsed.Comments[i].Body, parsed.FilePath)
 		if err != nil {
-			return fmt.Errorf("failed to add comment: %w", err)
+			return err
 		}
-		p.Printf("Added new comment\n")
+		parsed.Comments[i].Body = body
 	}
-
 	return nil
 }
 
+// assignDraftIDs gives the new-comment drafts in the file at path local IDs
+// before anything is pushed. Reports whether the file changed.
+func assignDraftIDs(path string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+	updated, changed := writer.AssignLocalIDs(string(content))
+	if !changed {
+		return false, nil
+	}
+	return true, writer.WriteFile(path, updated)
+}
+
+// markDraftPosted patches the draft with localID in the file at path with the
+// ID of the comment created from it, so it is not posted again before the next pull.
+func markDraftPosted(path, localID, commentID string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, ok := writer.MarkPosted(string(content), localID, commentID)
+	if !ok {
+		return nil
+	}
+	return writer.WriteFile(path, updated)
+}
+
+// repullItem refreshes the local file after a push. Local edits were just pushed,
+// so the file is overwritten.
 func repullItem(client *github.Client, parsed *parser.ParsedFile) error {
+	return pullItem(client, parsed, true)
+}
+
+// pullItem fetches an item and rewrites its local file. Without overwrite, files
+// with unpushed local changes are left alone and writer.ErrLocallyModified is returned.
+func pullItem(client *github.Client, parsed *parser.ParsedFile, overwrite bool) error {
+	settings, err := meta.ResolveSettings(parsed.Owner, parsed.Repo)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	opts, err := newWriteOptions(overwrite, settings.AssetsEnabled())
+	if err != nil {
+		return err
+	}
+
 	switch parsed.ItemType {
 	case github.ItemTypeIssue:
 		issue, err := client.FetchIssue(parsed.Owner, parsed.Repo, parsed.Number)
 		if err != nil {
 			return err
 		}
-		_, err = writer.WriteIssue(issue)
+		_, err = writer.WriteIssue(issue, opts)
 		return err
 
 	case github.ItemTypePullRequest:
@@ -364,7 +689,7 @@ func repullItem(client *github.Client, parsed *parser.ParsedFile) error {
 		if err != nil {
 			return err
 		}
-		_, err = writer.WritePullRequest(pr)
+		_, err = writer.WritePullRequest(pr, opts)
 		return err
 
 	case github.ItemTypeDiscussion:
@@ -372,7 +697,7 @@ func repullItem(client *github.Client, parsed *parser.ParsedFile) error {
 		if err != nil {
 			return err
 		}
-		_, err = writer.WriteDiscussion(d)
+		_, err = writer.WriteDiscussion(d, opts)
 		return err
 
 	default:
@@ -380,7 +705,9 @@ func repullItem(client *github.Client, parsed *parser.ParsedFile) error {
 	}
 }
 
-func selectFileForPush() (string, error) {
+// selectRepoFile opens FZF to pick a local file, pre-filtered to the current repo.
+// Returns "" if the user cancelled.
+func selectRepoFile() (string, error) {
 	// Check for FZF
 	if err := search.CheckFZFInstalled(); err != nil {
 		return "", err
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/journal"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/jackchuka/gh-md/internal/status"
+	"github.com/spf13/cobra"
+)
+
+// batchItem is a single file in a batch push.
+type batchItem struct {
+	parsed   *parser.ParsedFile
+	plan     changePlan
+	journal  *journal.Journal
+	conflict bool
+	err      error // Set when preparing or pushing failed
+	pushed   bool
+}
+
+// label returns a short display label such as "owner/repo#123".
+func (b *batchItem) label() string {
+	return fmt.Sprintf("%s/%s#%d", b.parsed.Owner, b.parsed.Repo, b.parsed.Number)
+}
+
+// batchRepoArg reports whether arg names a repository rather than a single item,
+// returning the repository in "owner/repo" format.
+func batchRepoArg(arg string) (string, bool) {
+	if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+		return "", false
+	}
+	input, err := github.ParseInput(arg)
+	if err != nil || input.Number > 0 {
+		return "", false
+	}
+	return input.FullName(), true
+}
+
+// runBatchPush pushes every locally modified file in repoFilter (all repos if empty)
+// that matches the --filter expression.
+func runBatchPush(cmd *cobra.Command, repoFilter string) error {
+	p := output.NewPrinter(cmd)
+
+	items, err := collectBatchItems(cmd, repoFilter)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		p.Print("No local changes to push.")
+		return nil
+	}
+
+	if pushQueue {
+		paths := make([]string, len(items))
+		for i := range items {
+			paths[i] = items[i].parsed.FilePath
+		}
+		return queueFiles(p, paths)
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	// Build a change plan for every file
+	s := newSpinner(cmd.ErrOrStderr(), fmt.Sprintf("Checking %d file(s) for conflicts...", len(items)))
+	s.Start()
+	forEachConcurrent(len(items), pushConcurrency, func(i int) {
+		prepareBatchItem(client, &items[i])
+	})
+	s.Stop()
+
+	if err := printBatchPlan(p, items); err != nil {
+		return err
+	}
+
+	var ready []*batchItem
+	for i := range items {
+		if items[i].err == nil && (!items[i].conflict || pushForce) {
+			ready = append(ready, &items[i])
+		}
+	}
+
+	if pushDryRun {
+		return nil
+	}
+
+	if len(ready) == 0 {
+		p.Print("\nNothing to push.")
+		return nil
+	}
+
+	if !pushYes {
+		ok, err := confirm(cmd, fmt.Sprintf("\nPush %d file(s)?", len(ready)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.Print("Aborted.")
+			return nil
+		}
+	}
+
+	repos := make([]string, len(ready))
+	for i, b := range ready {
+		repos[i] = b.parsed.Owner + "/" + b.parsed.Repo
+	}
+	recordHistoryRepos(p, history.ActionEdit, repos)
+
+	// Apply changes
+	var mu sync.Mutex
+	done := 0
+	s = newSpinner(cmd.ErrOrStderr(), fmt.Sprintf("Pushing 0/%d...", len(ready)))
+	s.Start()
+	forEachConcurrent(len(ready), pushConcurrency, func(i int) {
+		b := ready[i]
+		b.err = executeChanges(client, b.parsed, b.plan, b.journal, nopProgress{})
+		if b.err == nil {
+			b.pushed = true
+			// Local edits were just pushed; refresh the file
+			if err := repullItem(client, b.parsed); err != nil {
+				b.err = fmt.Errorf("pushed, but failed to sync local file: %w", err)
+			} else if err := b.journal.Remove(); err != nil {
+				b.err = fmt.Errorf("pushed, but failed to remove push journal: %w", err)
+			}
+		}
+
+		mu.Lock()
+		done++
+		s.Suffix = fmt.Sprintf(" Pushing %d/%d...", done, len(ready))
+		mu.Unlock()
+	})
+	s.Stop()
+
+	updateIndex(p, repos...)
+	recordHistoryRepos(p, history.ActionPush, repos)
+
+	return printBatchReport(p, items)
+}
+
+// collectBatchItems returns locally modified files, narrowed by --filter if given.
+// Drafts are excluded since they have no GitHub counterpart to push to.
+func collectBatchItems(cmd *cobra.Command, repoFilter string) ([]batchItem, error) {
+	entries, err := status.Scan(repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	var match func(*parser.ParsedFile) bool
+	if pushFilter != "" {
+		prg, err := search.CompileCELFilter(pushFilter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter expression: %w", err)
+		}
+
+		s := newSpinner(cmd.ErrOrStderr(), "Getting GitHub username...")
+		s.Start()
+		username, err := search.GetCurrentUser()
+		s.Stop()
+		if err != nil {
+			if !pushQueue {
+				return nil, err
+			}
+			// Queueing works offline; 'user' just won't match anyone
+			username = ""
+		}
+
+		match = func(parsed *parser.ParsedFile) bool {
+			ok, err := search.MatchParsedFile(prg, parsed, username)
+			return err == nil && ok
+		}
+	}
+
+	var items []batchItem
+	for _, e := range entries {
+		if e.Has(status.StateDraft) || e.File.ItemType == "" {
+			continue
+		}
+		if match != nil && !match(e.File) {
+			continue
+		}
+		items = append(items, batchItem{parsed: e.File})
+	}
+
+	return items, nil
+}
+
+// prepareBatchItem fetches the remote state of an item and builds its change plan.
+func prepareBatchItem(client *github.Client, b *batchItem) {
+	if !pushDryRun {
+		changed, err := assignDraftIDs(b.parsed.FilePath)
+		if err != nil {
+			b.err = err
+			return
+		}
+		if changed {
+			if b.parsed, err = parser.ParseFile(b.parsed.FilePath); err != nil {
+				b.err = fmt.Errorf("failed to parse file: %w", err)
+				return
+			}
+		}
+	}
+	parsed := b.parsed
+
+	if err := restoreAssetLinks(parsed); err != nil {
+		b.err = err
+		return
+	}
+
+	j, err := journal.Open(parsed.FilePath)
+	if err != nil {
+		b.err = fmt.Errorf("failed to open push journal: %w", err)
+		return
+	}
+	b.journal = j
+
+	remoteState, err := client.FetchRemoteState(parsed.ItemType, parsed.Owner, parsed.Repo, parsed.Number)
+	if err != nil {
+		b.err = fmt.Errorf("failed to fetch remote state: %w", err)
+		return
+	}
+
+	remoteComments, err := client.FetchComments(parsed.ItemType, parsed.Owner, parsed.Repo, parsed.Number)
+	if err != nil {
+		b.err = fmt.Errorf("failed to fetch remote comments: %w", err)
+		return
+	}
+
+	// An interrupted push may have updated the remote itself
+	b.conflict = remoteState.UpdatedAt.After(parsed.Updated) && !j.CoversUpdate(remoteState.UpdatedAt)
+	b.plan = buildChangePlan(parsed, remoteState, remoteComments)
+}
+
+// describePlan summarizes a change plan in a single line.
+func describePlan(plan changePlan) string {
+	var parts []string
+	if plan.titleBodyChanged {
+		parts = append(parts, "title/body")
+	}
+	if plan.stateChange != "" {
+		parts = append(parts, plan.stateChange)
+	}
+	if n := len(plan.newComments); n > 0 {
+		parts = append(parts, fmt.Sprintf("+%d comment(s)", n))
+	}
+	if n := len(plan.editedComments); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d edited comment(s)", n))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printBatchPlan(p *output.Printer, items []batchItem) error {
+	p.Printf("Plan for %d file(s):\n\n", len(items))
+
+	t := p.NewTable("ITEM", "TITLE", "CHANGES", "STATUS")
+	for i := range items {
+		b := &items[i]
+		state := "ready"
+		switch {
+		case b.err != nil:
+			state = "error: " + b.err.Error()
+		case b.conflict && pushForce:
+			state = "conflict (forced)"
+		case b.conflict:
+			state = "conflict (skipped, use --force)"
+		}
+		t.Row(b.label(), b.parsed.Title, describePlan(b.plan), state)
+	}
+	return t.Flush()
+}
+
+func printBatchReport(p *output.Printer, items []batchItem) error {
+	p.Print("")
+
+	failed := 0
+	t := p.NewTable("ITEM", "RESULT", "DETAIL")
+	for i := range items {
+		b := &items[i]
+		switch {
+		case b.err != nil && b.pushed:
+			t.Row(b.label(), "pushed", b.err.Error())
+		case b.err != nil:
+			failed++
+			t.Row(b.label(), "failed", b.err.Error())
+		case b.pushed:
+			t.Row(b.label(), "pushed", describePlan(b.plan))
+		default:
+			t.Row(b.label(), "skipped", "conflict")
+		}
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) failed to push", failed, len(items))
+	}
+	return nil
+}
+
+// nopProgress discards push progress; batch pushes report once per file instead.
+type nopProgress struct{}
+
+func (nopProgress) Start(string) {}
+func (nopProgress) Done(string)  {}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/journal"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/queue"
+	"github.com/spf13/cobra"
+)
+
+// queueFiles snapshots files into the push queue without contacting GitHub.
+func queueFiles(p *output.Printer, paths []string) error {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return err
+	}
+
+	q, err := queue.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load push queue: %w", err)
+	}
+
+	for _, path := range paths {
+		// Queued drafts get local IDs now so a retried flush recognises them
+		if _, err := assignDraftIDs(path); err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		parsed, err := parser.ParseContent(string(content), path)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if parsed.ItemType == "" || parsed.ID == "" {
+			return fmt.Errorf("%s is not a pulled GitHub item and cannot be queued", path)
+		}
+		if err := restoreAssetLinks(parsed); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(root, absPath)
+		if err != nil {
+			return fmt.Errorf("%s is outside the gh-md root: %w", path, err)
+		}
+
+		q.Add(filepath.ToSlash(relPath), string(content))
+		p.Printf("Queued %s #%d (%s/%s)\n", parsed.ItemType, parsed.Number, parsed.Owner, parsed.Repo)
+	}
+
+	if err := queue.Save(q); err != nil {
+		return fmt.Errorf("failed to save push queue: %w", err)
+	}
+
+	p.Printf("%d push(es) queued. Run 'gh md push --flush' when back online.\n", len(q.Entries))
+	return nil
+}
+
+// flushResult is the outcome of replaying a single queued push.
+type flushResult struct {
+	Item   string `json:"item" yaml:"item"`
+	Result string `json:"result" yaml:"result"` // applied, skipped or failed
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// runFlushQueue replays queued pushes in order. Applied entries are removed from
+// the queue; skipped and failed entries are kept so they can be retried.
+func runFlushQueue(cmd *cobra.Command) error {
+	p := output.NewPrinter(cmd)
+
+	q, err := queue.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load push queue: %w", err)
+	}
+
+	if len(q.Entries) == 0 {
+		p.Print("Push queue is empty.")
+		return nil
+	}
+
+	if pushDryRun {
+		p.Printf("%d queued push(es):\n\n", len(q.Entries))
+		t := p.NewTable("PATH", "QUEUED")
+		for _, e := range q.Entries {
+			t.Row(e.Path, output.FormatTime(&e.QueuedAt, output.TimestampDisplay))
+		}
+		return t.Flush()
+	}
+
+	root, err := config.GetRootDir()
+	if err != nil {
+		return err
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	// Iterate over a copy since applied entries are removed as we go
+	entries := append([]queue.Entry(nil), q.Entries...)
+
+	repos := make([]string, len(entries))
+	for i, e := range entries {
+		// Queued paths are relative to the root: owner/repo/<type>/<number>.md
+		owner, rest, _ := strings.Cut(e.Path, "/")
+		repo, _, _ := strings.Cut(rest, "/")
+		repos[i] = owner + "/" + repo
+	}
+	recordHistoryRepos(p, history.ActionEdit, repos)
+	defer recordHistoryRepos(p, history.ActionPush, repos)
+	defer updateIndex(p, repos...)
+	results := make([]flushResult, 0, len(entries))
+	failed := 0
+
+	s := newSpinner(cmd.ErrOrStderr(), "")
+	for i, e := range entries {
+		s.Suffix = fmt.Sprintf(" Replaying %d/%d: %s...", i+1, len(entries), e.Path)
+		s.Start()
+		result := flushEntry(client, filepath.Join(root, filepath.FromSlash(e.Path)), e)
+		s.Stop()
+
+		results = append(results, result)
+		switch result.Result {
+		case "applied":
+			q.Remove(e.Path)
+			if err := queue.Save(q); err != nil {
+				return fmt.Errorf("failed to save push queue: %w", err)
+			}
+		case "failed":
+			failed++
+		}
+	}
+
+	if err := output.List(p,
+		[]string{"ITEM", "RESULT", "DETAIL"},
+		results,
+		func(r flushResult) []string { return []string{r.Item, r.Result, r.Detail} },
+	); err != nil {
+		return err
+	}
+
+	if len(q.Entries) > 0 && !p.IsStructured() {
+		p.Printf("\n%d push(es) remain queued.\n", len(q.Entries))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d queued push(es) failed", failed, len(entries))
+	}
+	return nil
+}
+
+// flushEntry pushes a queued snapshot, re-checking for conflicts first.
+func flushEntry(client *github.Client, path string, e queue.Entry) flushResult {
+	result := flushResult{Item: e.Path}
+
+	parsed, err := parser.ParseContent(e.Content, path)
+	if err != nil {
+		result.Result, result.Detail = "failed", fmt.Sprintf("failed to parse snapshot: %v", err)
+		return result
+	}
+	result.Item = fmt.Sprintf("%s/%s#%d", parsed.Owner, parsed.Repo, parsed.Number)
+
+	if err := restoreAssetLinks(parsed); err != nil {
+		result.Result, result.Detail = "failed", err.Error()
+		return result
+	}
+
+	j, err := journal.Open(path)
+	if err != nil {
+		result.Result, result.Detail = "failed", fmt.Sprintf("failed to open push journal: %v", err)
+		return result
+	}
+
+	remoteState, err := client.FetchRemoteState(parsed.ItemType, parsed.Owner, parsed.Repo, parsed.Number)
+	if err != nil {
+		result.Result, result.Detail = "failed", fmt.Sprintf("failed to fetch remote state: %v", err)
+		return result
+	}
+
+	// An interrupted flush may have updated the remote itself
+	if remoteState.UpdatedAt.After(parsed.Updated) && !j.CoversUpdate(remoteState.UpdatedAt) && !pushForce {
+		result.Result = "skipped"
+		result.Detail = "remote updated since last pull; pull and queue again, or flush with --force"
+		return result
+	}
+
+	remoteComments, err := client.FetchComments(parsed.ItemType, parsed.Owner, parsed.Repo, parsed.Number)
+	if err != nil {
+		result.Result, result.Detail = "failed", fmt.Sprintf("failed to fetch remote comments: %v", err)
+		return result
+	}
+
+	// Only refresh the local file afterwards if it wasn't edited after being queued.
+	// Checked up front since pushing marks posted drafts in the file.
+	current, err := os.ReadFile(path)
+	unchanged := err == nil && string(current) == e.Content
+
+	plan := buildChangePlan(parsed, remoteState, remoteComments)
+	if err := executeChanges(client, parsed, plan, j, nopProgress{}); err != nil {
+		result.Result, result.Detail = "failed", err.Error()
+		return result
+	}
+
+	result.Result, result.Detail = "applied", describePlan(plan)
+
+	if !unchanged {
+		// The queued snapshot is fully pushed, so the journal protects nothing
+		result.Detail += "; local file changed since it was queued, review it and pull with --overwrite"
+	} else if err := repullItem(client, parsed); err != nil {
+		result.Detail += fmt.Sprintf("; failed to sync local file: %v", err)
+		return result
+	}
+	if err := j.Remove(); err != nil {
+		result.Detail += fmt.Sprintf("; failed to remove push journal: %v", err)
+	}
+
+	return result
+}
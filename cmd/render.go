@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renderWidth int
+	renderColor string
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render <file-path | url>",
+	Short: "Print a local file as readable terminal output",
+	Long: `Print a local file as readable terminal output: a header with state, labels
+and assignees, the body with markdown styling and comments as a thread.
+Frontmatter and gh-md markers are not shown.
+
+Used as the FZF preview. Inside FZF the preview width is taken from
+$FZF_PREVIEW_COLUMNS.
+
+Examples:
+  gh md render owner/repo/issues/123
+  gh md render owner/repo/issues/123 --width 100 --color always | less -R`,
+	Args:   cobra.ExactArgs(1),
+	Hidden: true,
+	RunE:   runRender,
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().IntVar(&renderWidth, "width", 0, "Wrap width in columns (default: preview or terminal width)")
+	renderCmd.Flags().StringVar(&renderColor, "color", "auto", "Use color: auto, always, never")
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	filePath, err := parser.ResolveFilePath(args[0])
+	if err != nil {
+		return err
+	}
+
+	parsed, err := parser.ParseFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	var color bool
+	switch renderColor {
+	case "auto":
+		color = output.ColorEnabled()
+	case "always":
+		color = true
+	case "never":
+		color = false
+	default:
+		return fmt.Errorf("invalid --color %q (expected auto, always, never)", renderColor)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), render.Item(parsed, render.Options{
+		Width: resolveRenderWidth(),
+		Color: color,
+	}))
+	return err
+}
+
+// resolveRenderWidth returns --width, else the FZF preview width, else the
+// terminal width.
+func resolveRenderWidth() int {
+	if renderWidth > 0 {
+		return renderWidth
+	}
+	if n, err := strconv.Atoi(os.Getenv("FZF_PREVIEW_COLUMNS")); err == nil && n > 0 {
+		// Leave a column so fzf doesn't wrap lines that fill the window exactly
+		return n - 1
+	}
+	if w, _, err := term.FromEnv().Size(); err == nil && w > 0 {
+		return w
+	}
+	return render.DefaultWidth
+}
@@ -4,11 +4,15 @@ import (
 	"fmt"
 
 	"github.com/jackchuka/gh-md/internal/discovery"
+	"github.com/jackchuka/gh-md/internal/meta"
 	"github.com/jackchuka/gh-md/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var reposFormat string
+var (
+	reposFormat  string
+	reposColumns string
+)
 
 var reposCmd = &cobra.Command{
 	Use:   "repos",
@@ -20,14 +24,16 @@ Shows each repository with its last sync time.
 Examples:
   gh md repos                  # List all repos
   gh md repos --format=json    # Output as JSON
-  gh md repos --format=yaml    # Output as YAML`,
+  gh md repos --format=yaml    # Output as YAML
+  gh md repos --format=csv --columns repo,last_sync`,
 	RunE: runRepos,
 }
 
 func init() {
 	rootCmd.AddCommand(reposCmd)
 
-	reposCmd.Flags().StringVar(&reposFormat, "format", "text", "Output format (text, json, yaml)")
+	reposCmd.Flags().StringVar(&reposFormat, "format", "text", "Output format (text, json, yaml, csv, tsv, markdown, ndjson, template=<go template>)")
+	reposCmd.Flags().StringVar(&reposColumns, "columns", "", "Comma-separated columns for table formats: "+output.ColumnNames(repoColumns))
 }
 
 type repoOutput struct {
@@ -37,8 +43,23 @@ type repoOutput struct {
 	LastSync string `json:"last_sync" yaml:"last_sync"`
 }
 
+// repoColumns are the columns --columns can choose for repositories.
+var repoColumns = []output.Column[repoOutput]{
+	{Name: "repo", Value: func(r repoOutput) string { return r.Owner + "/" + r.Repo }},
+	{Name: "owner", Value: func(r repoOutput) string { return r.Owner }},
+	{Name: "path", Value: func(r repoOutput) string { return r.Path }},
+	{Name: "last_sync", Value: func(r repoOutput) string { return r.LastSync }},
+}
+
 func runRepos(cmd *cobra.Command, args []string) error {
-	p := output.NewPrinter(cmd).WithFormat(output.ParseFormat(reposFormat))
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, reposFormat, settings, true)
+	if err != nil {
+		return err
+	}
 
 	repos, err := discovery.DiscoverManagedRepos()
 	if err != nil {
@@ -61,11 +82,9 @@ func runRepos(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	return output.List(p,
-		[]string{"REPOSITORY", "LAST SYNC"},
-		items,
-		func(r repoOutput) []string {
-			return []string{r.Owner + "/" + r.Repo, r.LastSync}
-		},
-	)
+	cols, err := output.SelectColumns(repoColumns, reposColumns, "repo", "last_sync")
+	if err != nil {
+		return err
+	}
+	return output.ListColumns(p, cols, items)
 }
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jackchuka/gh-md/internal/archive"
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/history"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreList    bool
+	restoreFormat  string
+	restoreColumns string
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <item>...",
+	Short: "Bring back items archived by prune",
+	Long: `Restore item files that 'gh md prune --archive' moved into a repository's
+archive, together with their attachments.
+
+Items are given as URLs, owner/repo/<type>/<number> or paths. The newest
+archived copy is restored, and every archived copy is removed. Items that exist
+locally are left alone; pull them to update them instead.
+
+With --list, prints the archived items of a repository (or all repositories)
+instead.
+
+Examples:
+  gh md restore owner/repo/issues/123
+  gh md restore https://github.com/owner/repo/pull/45
+  gh md restore --list                   # All archived items
+  gh md restore --list owner/repo --format=json`,
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().BoolVar(&restoreList, "list", false, "List archived items instead of restoring")
+	restoreCmd.Flags().StringVar(&restoreFormat, "format", "text", "Output format for --list (text, json, yaml, csv, tsv, markdown, ndjson, template=<go template>)")
+	restoreCmd.Flags().StringVar(&restoreColumns, "columns", "", "Comma-separated columns for table formats (only with --list): "+output.ColumnNames(itemColumns))
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	if restoreList {
+		return listArchived(cmd, args)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("requires at least 1 item to restore (or --list)")
+	}
+
+	root, err := config.GetRootDir()
+	if err != nil {
+		return err
+	}
+
+	// Resolve every argument before restoring anything
+	paths := make([]string, len(args))
+	for i, arg := range args {
+		input, err := github.ParseInput(arg)
+		if err != nil {
+			return err
+		}
+		dirName, ok := input.ItemType.DirName()
+		if !ok || input.Number == 0 {
+			return fmt.Errorf("%s is not an item (expected URL or owner/repo/<type>/<number>)", arg)
+		}
+		paths[i] = filepath.Join(root, input.Owner, input.Repo, dirName, fmt.Sprintf("%d.md", input.Number))
+	}
+
+	p := output.NewPrinter(cmd)
+	for _, path := range paths {
+		if err := restoreFile(p, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreFile restores an archived item file, then updates the index and
+// records history for its repository.
+func restoreFile(p *output.Printer, path string) error {
+	rel := path
+	if root, err := config.GetRootDir(); err == nil {
+		if r, err := filepath.Rel(root, path); err == nil {
+			rel = r
+		}
+	}
+
+	if err := archive.Restore(path); err != nil {
+		switch {
+		case errors.Is(err, archive.ErrNotArchived):
+			return fmt.Errorf("%s is not archived", rel)
+		case errors.Is(err, archive.ErrExists):
+			return fmt.Errorf("%s already exists locally; pull it to update it", rel)
+		}
+		return fmt.Errorf("failed to restore %s: %w", rel, err)
+	}
+
+	owner := filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(path))))
+	repo := filepath.Base(filepath.Dir(filepath.Dir(path)))
+	updateIndex(p, owner+"/"+repo)
+	recordHistory(p, history.ActionRestore, owner, repo)
+
+	p.Printf("Restored %s\n", rel)
+	return nil
+}
+
+func listArchived(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("--list accepts at most one repository")
+	}
+
+	var repo string
+	if len(args) > 0 {
+		input, err := github.ParseInput(args[0])
+		if err != nil {
+			return err
+		}
+		repo = input.FullName()
+	}
+
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, restoreFormat, settings, true)
+	if err != nil {
+		return err
+	}
+
+	var items []search.Item
+	err = archive.Walk(repo, func(a *archive.Item) error {
+		item := search.NewItem(a.Parsed)
+		item.Archived = true
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read archives: %w", err)
+	}
+
+	if len(items) == 0 {
+		p.Print("No archived items found.")
+		return nil
+	}
+	search.SortItems(items, search.SortUpdated, false)
+	return outputItems(p, items, restoreColumns)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +26,12 @@ var (
 	rootAssigned    bool
 	rootList        bool
 	rootFormat      string
+	rootColumns     string
+	rootSort        string
+	rootReverse     bool
+	rootLimit       int
+	rootTUI         bool
+	rootArchived    bool
 )
 
 var rootCmd = &cobra.Command{
@@ -35,6 +42,7 @@ var rootCmd = &cobra.Command{
 making them easy to browse and feed to AI agents.
 
 Data is stored in ~/.gh-md/ (configurable via GH_MD_ROOT env var).
+Defaults are read from ~/.config/gh-md/config.yaml (see 'gh md config').
 
 When run without a subcommand, opens an interactive FZF selector to browse
 local files. Use flags to filter:
@@ -48,10 +56,21 @@ local files. Use flags to filter:
   gh md gh-md                        # Partial match (resolves to owner/repo)
   gh md --list                       # Print matches without FZF
   gh md --list --format=json         # Output as JSON for scripting
+  gh md --list --format=csv --columns repo,number,labels,updated
+  gh md --list --format 'template={{.Number}} {{.Title}}'
+  gh md --list --sort comments --limit 10    # Most discussed items
+  gh md --sort title --reverse       # Browse sorted Z to A
+  gh md --tui                        # Built-in terminal UI (used when fzf is missing)
+  gh md --include-archived --filter 'title.contains("crash")'
 
 CEL filter variables:
   user, now, item_type, state, title, body, author,
-  assigned, reviewers, labels, created, updated, owner, repo, number`,
+  assigned, reviewers, labels, created, updated, owner, repo, number,
+  comments (list of {author, body, created}), comment_count, last_commenter,
+  participants, draft, head_ref, base_ref, category, parent, children, last_pulled,
+  closed, pinned
+
+  gh md --filter 'last_commenter != user && user in participants'`,
 	Args:         cobra.MaximumNArgs(1),
 	SilenceUsage: true,
 	RunE:         runRoot,
@@ -63,7 +82,14 @@ func init() {
 	rootCmd.Flags().BoolVar(&rootNew, "new", false, "Show items updated since last pull")
 	rootCmd.Flags().BoolVar(&rootAssigned, "assigned", false, "Show items assigned to you")
 	rootCmd.Flags().BoolVar(&rootList, "list", false, "Print matches without interactive FZF")
-	rootCmd.Flags().StringVar(&rootFormat, "format", "text", "Output format: text, json, yaml (only with --list)")
+	rootCmd.Flags().StringVar(&rootFormat, "format", "text", "Output format: text, json, yaml, csv, tsv, markdown, ndjson or template=<go template> (only with --list; default from config)")
+	rootCmd.Flags().StringVar(&rootColumns, "columns", "", "Comma-separated columns for table formats (only with --list): "+output.ColumnNames(itemColumns))
+	rootCmd.Flags().StringVar(&rootSort, "sort", "updated", "Sort by: updated, created, number, comments, reactions, title, repo")
+	rootCmd.Flags().BoolVar(&rootReverse, "reverse", false, "Reverse the sort order")
+	rootCmd.Flags().IntVar(&rootLimit, "limit", 0, "Show at most this many items (0 = all)")
+	rootCmd.Flags().BoolVar(&rootTUI, "tui", false, "Browse in the built-in terminal UI instead of FZF")
+	rootCmd.Flags().BoolVar(&rootArchived, "include-archived", false, "Also show items archived by 'gh md prune --archive'")
+	rootCmd.MarkFlagsMutuallyExclusive("include-archived", "new")
 }
 
 func Execute() {
@@ -73,13 +99,6 @@ func Execute() {
 }
 
 func runRoot(cmd *cobra.Command, args []string) error {
-	// Check for FZF unless using --list mode
-	if !rootList {
-		if err := search.CheckFZFInstalled(); err != nil {
-			return err
-		}
-	}
-
 	// Get repo from positional argument, with partial match support
 	var repo string
 	if len(args) > 0 {
@@ -90,8 +109,28 @@ func runRoot(cmd *cobra.Command, args []string) error {
 		repo = input.FullName()
 	}
 
+	owner, name, _ := strings.Cut(repo, "/")
+	settings, err := meta.ResolveSettings(owner, name)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	sortBy, err := search.ParseSortField(rootSort)
+	if err != nil {
+		return err
+	}
+	if rootLimit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
 	var items []search.Item
-	var err error
+
+	// The selector toggles item types in place, so it gets all of them
+	issues, prs, discussions := resolveTypes(rootIssues, rootPRs, rootDiscussions, settings)
+	types := search.Filters{Issues: issues, PRs: prs, Discussions: discussions}
+	if !rootList {
+		types = search.Filters{Issues: true, PRs: true, Discussions: true}
+	}
 
 	// Determine which discovery method to use
 	useCEL := rootFilter != "" || rootAssigned
@@ -100,16 +139,32 @@ func runRoot(cmd *cobra.Command, args []string) error {
 	if useCEL {
 		items, err = discoverWithCEL(cmd, repo)
 	} else if useNew {
-		items, err = discoverNewItems(cmd, repo)
+		items, err = discoverNewItems(cmd, repo, types)
 	} else {
-		items, err = discoverWithFilters(repo)
+		types.Repo = repo
+		items, err = discoverWithFilters(types)
 	}
 
 	if err != nil {
 		return err
 	}
 
-	p := output.NewPrinter(cmd).WithFormat(output.ParseFormat(rootFormat))
+	if rootArchived {
+		archived, err := discoverArchived(cmd, repo)
+		if err != nil {
+			return err
+		}
+		for _, item := range archived {
+			if types.Includes(item.Type) {
+				items = append(items, item)
+			}
+		}
+	}
+
+	p, err := newPrinter(cmd, rootFormat, settings, true)
+	if err != nil {
+		return err
+	}
 
 	if len(items) == 0 {
 		p.Print("No items found. Run 'gh md pull' to download some first.")
@@ -118,7 +173,11 @@ func runRoot(cmd *cobra.Command, args []string) error {
 
 	// List mode - just print and exit
 	if rootList {
-		return outputItems(p, items)
+		search.SortItems(items, sortBy, rootReverse)
+		if rootLimit > 0 && len(items) > rootLimit {
+			items = items[:rootLimit]
+		}
+		return outputItems(p, items, rootColumns)
 	}
 
 	// Smart context detection - pre-filter based on git context
@@ -138,29 +197,57 @@ func runRoot(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	state := search.NewSelectorState(items, sortBy)
+	state.Issues, state.PRs, state.Discussions = issues, prs, discussions
+	state.Reverse, state.Limit = rootReverse, rootLimit
+	return browseItems(cmd, state, initialQuery)
+}
+
+// browseItems opens the FZF selector and runs the chosen action on the selected
+// item, or a bulk action if several items were marked. The built-in terminal UI
+// is used instead with --tui or when FZF isn't installed.
+func browseItems(cmd *cobra.Command, state *search.SelectorState, query string) error {
+	// Without FZF, fall back to the built-in terminal UI
+	if rootTUI || search.CheckFZFInstalled() != nil {
+		return browseTUI(cmd, state.Visible(), state.Sort, state.Reverse, query)
+	}
+
 	// Interactive FZF selection
-	selected, err := search.RunSelector(items, initialQuery, search.SortUpdated)
+	selected, err := search.RunMultiSelector(state, query)
 	if err != nil {
 		return err
 	}
 
-	if selected == nil {
+	switch len(selected) {
+	case 0:
 		// User cancelled
 		return nil
-	}
 
-	// Show action menu
-	action, err := search.RunActionMenu(selected)
-	if err != nil {
-		return err
-	}
+	case 1:
+		// Show action menu
+		action, err := search.RunActionMenu(&selected[0])
+		if err != nil {
+			return err
+		}
 
-	// Execute the action
-	return executeAction(cmd, selected, action)
+		// Execute the action
+		return executeAction(cmd, &selected[0], action)
+
+	default:
+		action, err := search.RunBulkActionMenu(selected)
+		if err != nil {
+			return err
+		}
+		return executeBulkAction(cmd, selected, action)
+	}
 }
 
 func discoverWithCEL(cmd *cobra.Command, repo string) ([]search.Item, error) {
-	// Build CEL filter expression
+	return discoverCEL(cmd, rootFilterExpr(), repo)
+}
+
+// rootFilterExpr builds the CEL filter expression from --filter and --assigned.
+func rootFilterExpr() string {
 	filterExpr := rootFilter
 	if rootAssigned {
 		if filterExpr != "" {
@@ -169,7 +256,40 @@ func discoverWithCEL(cmd *cobra.Command, repo string) ([]search.Item, error) {
 			filterExpr = "user in assigned"
 		}
 	}
+	return filterExpr
+}
+
+// discoverArchived returns the archived items in repo (all repos if empty)
+// matching --filter and --assigned.
+func discoverArchived(cmd *cobra.Command, repo string) ([]search.Item, error) {
+	filterExpr := rootFilterExpr()
+	username := ""
+	if filterExpr == "" {
+		filterExpr = "true"
+	} else {
+		var err error
+		if username, err = search.GetCurrentUser(); err != nil {
+			return nil, err
+		}
+	}
+
+	prg, err := search.CompileCELFilter(filterExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	s := newSpinner(cmd.ErrOrStderr(), "Reading archives...")
+	s.Start()
+	items, err := search.DiscoverArchived(prg, username, repo)
+	s.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archives: %w", err)
+	}
+	return items, nil
+}
 
+// discoverCEL returns local items in repo (all repos if empty) matching a CEL expression.
+func discoverCEL(cmd *cobra.Command, filterExpr, repo string) ([]search.Item, error) {
 	// Get current GitHub username
 	s := newSpinner(cmd.ErrOrStderr(), "Getting GitHub username...")
 	s.Start()
@@ -200,36 +320,35 @@ func discoverWithCEL(cmd *cobra.Command, repo string) ([]search.Item, error) {
 	return items, nil
 }
 
-func discoverNewItems(cmd *cobra.Command, repo string) ([]search.Item, error) {
+func discoverNewItems(cmd *cobra.Command, repo string, types search.Filters) ([]search.Item, error) {
 	// Collect sync timestamps per repo
 	syncTimes := make(map[string]*meta.SyncTimestamps)
+	issues, prs, discussions := types.Issues, types.PRs, types.Discussions
 
 	s := newSpinner(cmd.ErrOrStderr(), "Scanning local files...")
 	s.Start()
 
 	var items []search.Item
 
-	err := parser.WalkParsedFiles(parser.WalkFilters{Repo: repo}, func(parsed *parser.ParsedFile) error {
+	err := parser.WalkMetadata(parser.WalkFilters{Repo: repo}, func(parsed *parser.ParsedFile) error {
 		itemType := "unknown"
 		if label, ok := parsed.ItemType.ListLabel(); ok {
 			itemType = label
 		}
 
 		// Apply type filters
-		if rootIssues || rootPRs || rootDiscussions {
-			switch itemType {
-			case "issue":
-				if !rootIssues {
-					return nil
-				}
-			case "pr":
-				if !rootPRs {
-					return nil
-				}
-			case "discussion":
-				if !rootDiscussions {
-					return nil
-				}
+		switch itemType {
+		case "issue":
+			if !issues {
+				return nil
+			}
+		case "pr":
+			if !prs {
+				return nil
+			}
+		case "discussion":
+			if !discussions {
+				return nil
 			}
 		}
 
@@ -270,16 +389,20 @@ func discoverNewItems(cmd *cobra.Command, repo string) ([]search.Item, error) {
 		}
 
 		items = append(items, search.Item{
-			FilePath: parsed.FilePath,
-			Owner:    parsed.Owner,
-			Repo:     parsed.Repo,
-			Number:   parsed.Number,
-			Type:     itemType,
-			State:    strings.ToLower(parsed.State),
-			Title:    parsed.Title,
-			URL:      url,
-			Created:  parsed.Created,
-			Updated:  parsed.Updated,
+			FilePath:  parsed.FilePath,
+			Owner:     parsed.Owner,
+			Repo:      parsed.Repo,
+			Number:    parsed.Number,
+			Type:      itemType,
+			State:     strings.ToLower(parsed.State),
+			Title:     parsed.Title,
+			URL:       url,
+			Author:    parsed.Author,
+			Labels:    parsed.Labels,
+			Comments:  parsed.CommentCount,
+			Reactions: parsed.Reactions,
+			Created:   parsed.Created,
+			Updated:   parsed.Updated,
 		})
 
 		return nil
@@ -290,14 +413,7 @@ func discoverNewItems(cmd *cobra.Command, repo string) ([]search.Item, error) {
 	return items, err
 }
 
-func discoverWithFilters(repo string) ([]search.Item, error) {
-	filters := search.Filters{
-		Repo:        repo,
-		Issues:      rootIssues,
-		PRs:         rootPRs,
-		Discussions: rootDiscussions,
-	}
-
+func discoverWithFilters(filters search.Filters) ([]search.Item, error) {
 	items, err := search.DiscoverLocalFiles(filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover local files: %w", err)
@@ -309,9 +425,25 @@ func discoverWithFilters(repo string) ([]search.Item, error) {
 func executeAction(cmd *cobra.Command, item *search.Item, action search.Action) error {
 	p := output.NewPrinter(cmd)
 
+	// Archived items only exist once restored
+	if item.Archived && action != search.ActionCancel && action != search.ActionViewBrowser {
+		ok, err := confirm(cmd, fmt.Sprintf("%s/%s#%d is archived. Restore it?", item.Owner, item.Repo, item.Number))
+		if err != nil || !ok {
+			return err
+		}
+		if err := restoreFile(p, item.FilePath); err != nil {
+			return err
+		}
+		item.Archived = false
+	}
+
 	switch action {
 	case search.ActionOpenEditor:
-		return executil.OpenInEditor(item.FilePath)
+		settings, err := meta.ResolveSettings(item.Owner, item.Repo)
+		if err != nil {
+			return fmt.Errorf("failed to load settings: %w", err)
+		}
+		return executil.OpenInEditor(settings.Editor, item.FilePath)
 
 	case search.ActionPush:
 		return runPush(cmd, []string{item.FilePath})
@@ -329,6 +461,9 @@ func executeAction(cmd *cobra.Command, item *search.Item, action search.Action)
 	case search.ActionPullFresh:
 		return runPull(cmd, []string{item.FilePath})
 
+	case search.ActionComment:
+		return commentOnItem(cmd, item)
+
 	case search.ActionCancel:
 		return nil
 
@@ -339,44 +474,92 @@ func executeAction(cmd *cobra.Command, item *search.Item, action search.Action)
 
 // itemOutput is the output structure for list items.
 type itemOutput struct {
-	Owner    string `json:"owner" yaml:"owner"`
-	Repo     string `json:"repo" yaml:"repo"`
-	Number   int    `json:"number" yaml:"number"`
-	Type     string `json:"type" yaml:"type"`
-	State    string `json:"state" yaml:"state"`
-	Title    string `json:"title" yaml:"title"`
-	URL      string `json:"url" yaml:"url"`
-	FilePath string `json:"file_path" yaml:"file_path"`
+	Owner     string   `json:"owner" yaml:"owner"`
+	Repo      string   `json:"repo" yaml:"repo"`
+	Number    int      `json:"number" yaml:"number"`
+	Type      string   `json:"type" yaml:"type"`
+	State     string   `json:"state" yaml:"state"`
+	Title     string   `json:"title" yaml:"title"`
+	URL       string   `json:"url" yaml:"url"`
+	FilePath  string   `json:"file_path" yaml:"file_path"`
+	Author    string   `json:"author,omitempty" yaml:"author,omitempty"`
+	Labels    []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Comments  int      `json:"comments,omitempty" yaml:"comments,omitempty"`
+	Reactions int      `json:"reactions,omitempty" yaml:"reactions,omitempty"`
+	Created   string   `json:"created" yaml:"created"`
+	Updated   string   `json:"updated" yaml:"updated"`
+	Archived  bool     `json:"archived,omitempty" yaml:"archived,omitempty"`
+}
+
+func newItemOutput(item search.Item) itemOutput {
+	return itemOutput{
+		Owner:     item.Owner,
+		Repo:      item.Repo,
+		Number:    item.Number,
+		Type:      item.Type,
+		State:     item.State,
+		Title:     item.Title,
+		URL:       item.URL,
+		FilePath:  item.FilePath,
+		Author:    item.Author,
+		Labels:    item.Labels,
+		Comments:  item.Comments,
+		Reactions: item.Reactions,
+		Created:   output.FormatTime(&item.Created, output.TimestampISO),
+		Updated:   output.FormatTime(&item.Updated, output.TimestampISO),
+		Archived:  item.Archived,
+	}
 }
 
-func outputItems(p *output.Printer, items []search.Item) error {
-	// Convert to output type
+// stateDisplay returns the state shown in tables, marking archived items.
+func stateDisplay(i itemOutput) string {
+	if i.Archived {
+		return i.State + " (archived)"
+	}
+	return i.State
+}
+
+// itemColumns are the columns --columns can choose for list items.
+var itemColumns = []output.Column[itemOutput]{
+	{Name: "repo", Value: func(i itemOutput) string { return i.Owner + "/" + i.Repo }},
+	{Name: "owner", Value: func(i itemOutput) string { return i.Owner }},
+	{
+		Name:    "number",
+		Value:   func(i itemOutput) string { return strconv.Itoa(i.Number) },
+		Display: func(i itemOutput) string { return fmt.Sprintf("#%d", i.Number) },
+	},
+	{Name: "type", Value: func(i itemOutput) string { return i.Type }},
+	{
+		Name:    "state",
+		Value:   func(i itemOutput) string { return i.State },
+		Display: stateDisplay,
+	},
+	{Name: "title", Value: func(i itemOutput) string { return i.Title }},
+	{Name: "author", Value: func(i itemOutput) string { return i.Author }},
+	{
+		Name:    "labels",
+		Value:   func(i itemOutput) string { return strings.Join(i.Labels, ",") },
+		Display: func(i itemOutput) string { return strings.Join(i.Labels, ", ") },
+	},
+	{Name: "comments", Value: func(i itemOutput) string { return strconv.Itoa(i.Comments) }},
+	{Name: "reactions", Value: func(i itemOutput) string { return strconv.Itoa(i.Reactions) }},
+	{Name: "created", Value: func(i itemOutput) string { return i.Created }},
+	{Name: "updated", Value: func(i itemOutput) string { return i.Updated }},
+	{Name: "url", Value: func(i itemOutput) string { return i.URL }},
+	{Name: "path", Value: func(i itemOutput) string { return i.FilePath }},
+}
+
+// outputItems prints items in the printer's format. columns is a comma-separated
+// list of itemColumns names; empty selects the default columns.
+func outputItems(p *output.Printer, items []search.Item, columns string) error {
+	cols, err := output.SelectColumns(itemColumns, columns, "repo", "number", "type", "state", "title", "path")
+	if err != nil {
+		return err
+	}
+
 	out := make([]itemOutput, len(items))
 	for i, item := range items {
-		out[i] = itemOutput{
-			Owner:    item.Owner,
-			Repo:     item.Repo,
-			Number:   item.Number,
-			Type:     item.Type,
-			State:    item.State,
-			Title:    item.Title,
-			URL:      item.URL,
-			FilePath: item.FilePath,
-		}
+		out[i] = newItemOutput(item)
 	}
-
-	return output.List(p,
-		[]string{"REPO", "NUMBER", "TYPE", "STATE", "TITLE", "PATH"},
-		out,
-		func(item itemOutput) []string {
-			return []string{
-				fmt.Sprintf("%s/%s", item.Owner, item.Repo),
-				fmt.Sprintf("#%d", item.Number),
-				item.Type,
-				item.State,
-				item.Title,
-				item.FilePath,
-			}
-		},
-	)
+	return output.ListColumns(p, cols, out)
 }
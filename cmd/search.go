@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/index"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchIssues      bool
+	searchPRs         bool
+	searchDiscussions bool
+	searchIn          string
+	searchLimit       int
+	searchList        bool
+	searchFormat      string
+	searchArchived    bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query> [repo]",
+	Short: "Full-text search over local files",
+	Long: `Search the title, body and comments of local files.
+
+Results contain every word of the query and are ranked by relevance, with
+title matches weighted highest. Matching is case-insensitive on whole words.
+
+Searches use an index stored in the gh-md root. It is built on the first search
+and then kept up to date by pull, push and prune; files edited by hand are
+re-indexed on the next search.
+
+Without --list, results open in the FZF selector with a snippet of the match,
+followed by the same action menu as 'gh md'.
+
+With --include-archived, items archived by 'gh md prune --archive' are searched
+too. Choosing one in the selector offers to restore it first.
+
+Examples:
+  gh md search "memory leak"                  # Search everything
+  gh md search timeout owner/repo             # Search a single repo
+  gh md search flaky --in body,comments       # Skip titles
+  gh md search crash --issues --list          # Print ranked issues
+  gh md search crash --list --format=json
+  gh md search regression --include-archived  # Also search archived items`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	registerItemTypeFlags(searchCmd, &searchIssues, &searchPRs, &searchDiscussions, "Search")
+	searchCmd.Flags().StringVar(&searchIn, "in", "", "Fields to search: title, body, comments (comma-separated, default all)")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "Show at most this many results (0 = all)")
+	searchCmd.Flags().BoolVar(&searchList, "list", false, "Print results without interactive FZF")
+	searchCmd.Flags().StringVar(&searchFormat, "format", "text", "Output format with --list: text, json, yaml, csv, tsv, markdown, ndjson, template=<go template> (default from config)")
+	searchCmd.Flags().BoolVar(&searchArchived, "include-archived", false, "Also search items archived by 'gh md prune --archive'")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	fields, err := index.ParseFields(searchIn)
+	if err != nil {
+		return err
+	}
+
+	var repo string
+	if len(args) > 1 {
+		input, err := github.ParseInput(args[1])
+		if err != nil {
+			return err
+		}
+		repo = input.FullName()
+	}
+
+	owner, name, _ := strings.Cut(repo, "/")
+	settings, err := meta.ResolveSettings(owner, name)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	issues, prs, discussions := resolveTypes(searchIssues, searchPRs, searchDiscussions, settings)
+
+	p, err := newPrinter(cmd, searchFormat, settings, true)
+	if err != nil {
+		return err
+	}
+
+	s := newSpinner(cmd.ErrOrStderr(), "Updating search index...")
+	s.Start()
+	ix, err := index.Load(repo)
+	if err == nil {
+		_, err = ix.Refresh(repo)
+	}
+	if err == nil {
+		err = ix.Save()
+	}
+	s.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+
+	if searchArchived {
+		// Archived items are added in memory only, after the index was saved
+		s.Suffix = " Reading archives..."
+		s.Start()
+		err = ix.AddArchived(repo)
+		s.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to read archives: %w", err)
+		}
+	}
+
+	results := ix.Search(index.Query{Text: args[0], Fields: fields, Repo: repo})
+
+	var items []search.Item
+	for _, r := range results {
+		switch r.Doc.ItemType {
+		case github.ItemTypeIssue:
+			if !issues {
+				continue
+			}
+		case github.ItemTypePullRequest:
+			if !prs {
+				continue
+			}
+		case github.ItemTypeDiscussion:
+			if !discussions {
+				continue
+			}
+		}
+
+		item, err := searchResultItem(r)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+		if searchLimit > 0 && len(items) == searchLimit {
+			break
+		}
+	}
+
+	if len(items) == 0 {
+		p.Print("No matches found.")
+		return nil
+	}
+
+	if searchList {
+		return outputSearchResults(p, items)
+	}
+
+	return browseItems(cmd, search.NewSelectorState(items, search.SortRelevance), "")
+}
+
+// searchResultItem converts an index hit to a selectable item.
+func searchResultItem(r index.Result) (search.Item, error) {
+	path, err := r.Doc.FilePath()
+	if err != nil {
+		return search.Item{}, err
+	}
+
+	itemType := "unknown"
+	if label, ok := r.Doc.ItemType.ListLabel(); ok {
+		itemType = label
+	}
+
+	url := ""
+	if seg, ok := r.Doc.ItemType.URLSegment(); ok {
+		url = fmt.Sprintf("https://github.com/%s/%s/%s/%d", r.Doc.Owner, r.Doc.Repo, seg, r.Doc.Number)
+	}
+
+	return search.Item{
+		FilePath:  path,
+		Owner:     r.Doc.Owner,
+		Repo:      r.Doc.Repo,
+		Number:    r.Doc.Number,
+		Type:      itemType,
+		State:     r.Doc.State,
+		Title:     r.Doc.Title,
+		URL:       url,
+		Created:   r.Doc.Created,
+		Updated:   r.Doc.Updated,
+		Comments:  r.Doc.Comments,
+		Reactions: r.Doc.Reactions,
+		Snippet:   r.Snippet,
+		Archived:  r.Doc.Archived,
+	}, nil
+}
+
+// searchResultOutput is the output structure for search results.
+type searchResultOutput struct {
+	itemOutput `yaml:",inline"`
+	Snippet    string `json:"snippet" yaml:"snippet"`
+}
+
+func outputSearchResults(p *output.Printer, items []search.Item) error {
+	out := make([]searchResultOutput, len(items))
+	for i, item := range items {
+		out[i] = searchResultOutput{
+			itemOutput: newItemOutput(item),
+			Snippet:    item.Snippet,
+		}
+	}
+
+	return output.List(p,
+		[]string{"REPO", "NUMBER", "TYPE", "STATE", "TITLE", "SNIPPET"},
+		out,
+		func(item searchResultOutput) []string {
+			return []string{
+				fmt.Sprintf("%s/%s", item.Owner, item.Repo),
+				fmt.Sprintf("#%d", item.Number),
+				item.Type,
+				stateDisplay(item.itemOutput),
+				item.Title,
+				item.Snippet,
+			}
+		},
+	)
+}
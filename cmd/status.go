@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/status"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusFormat string
+	statusLocal  bool
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [owner/repo]",
+	Short: "Show local files with unpushed changes",
+	Long: `Show local files that differ from what was last pulled.
+
+Each file is reported with one or more states:
+  modified   Edited locally since the last pull
+  pending    Has new-comment drafts waiting to be pushed
+  conflict   Has local changes and the remote was updated since the last pull
+  draft      Not yet created on GitHub (no id in frontmatter)
+
+Files pulled before modification tracking was added are only reported
+when they have pending comments.
+
+Examples:
+  gh md status                   # All repos
+  gh md status owner/repo        # A single repo
+  gh md status --local           # Skip the remote conflict check
+  gh md status --format=json     # Output as JSON`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVar(&statusFormat, "format", "text", "Output format (text, json, yaml)")
+	statusCmd.Flags().BoolVar(&statusLocal, "local", false, "Skip checking GitHub for conflicts")
+}
+
+type statusOutput struct {
+	Repo   string   `json:"repo" yaml:"repo"`
+	Type   string   `json:"type" yaml:"type"`
+	Number int      `json:"number" yaml:"number"`
+	Title  string   `json:"title" yaml:"title"`
+	Status []string `json:"status" yaml:"status"`
+	Path   string   `json:"path" yaml:"path"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, statusFormat, settings, false)
+	if err != nil {
+		return err
+	}
+
+	var repoFilter string
+	if len(args) > 0 {
+		input, err := github.ParseInput(args[0])
+		if err != nil {
+			return err
+		}
+		repoFilter = input.FullName()
+	}
+
+	entries, err := status.Scan(repoFilter)
+	if err != nil {
+		return fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	if len(entries) > 0 && !statusLocal {
+		client, err := github.NewClient()
+		if err != nil {
+			return err
+		}
+
+		s := newSpinner(cmd.ErrOrStderr(), "Checking for conflicts...")
+		s.Start()
+		err = status.CheckConflicts(client, entries, func(e *status.Entry) {
+			s.Suffix = fmt.Sprintf(" Checking %s/%s#%d...", e.File.Owner, e.File.Repo, e.File.Number)
+		})
+		s.Stop()
+		if err != nil {
+			return fmt.Errorf("failed to check remote state: %w", err)
+		}
+	}
+
+	if len(entries) == 0 {
+		p.Print("No local changes.")
+		return nil
+	}
+
+	items := make([]statusOutput, len(entries))
+	for i, e := range entries {
+		itemType, _ := e.File.ItemType.ListLabel()
+		states := make([]string, len(e.States))
+		for j, s := range e.States {
+			states[j] = string(s)
+		}
+		items[i] = statusOutput{
+			Repo:   e.File.Owner + "/" + e.File.Repo,
+			Type:   itemType,
+			Number: e.File.Number,
+			Title:  e.File.Title,
+			Status: states,
+			Path:   e.RelativePath(),
+		}
+	}
+
+	return output.List(p,
+		[]string{"STATUS", "TYPE", "ITEM", "TITLE"},
+		items,
+		func(s statusOutput) []string {
+			return []string{strings.Join(s.Status, ","), s.Type, fmt.Sprintf("%s#%d", s.Repo, s.Number), s.Title}
+		},
+	)
+}
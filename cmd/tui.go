@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/jackchuka/gh-md/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// browseTUI browses items in the built-in terminal UI, running actions until
+// the user quits. The UI returns to the same item after each action.
+func browseTUI(cmd *cobra.Command, items []search.Item, sortBy search.SortField, reverse bool, query string) error {
+	p := output.NewPrinter(cmd)
+
+	m := tui.NewModel(items, sortBy, reverse, tuiFilter())
+	if query != "" {
+		m.Select(func(item search.Item) bool {
+			return strings.HasPrefix(fmt.Sprintf("%s/%s #%d", item.Owner, item.Repo, item.Number), query)
+		})
+	}
+
+	for {
+		action, item, err := tui.Run(m)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return nil
+		}
+
+		err = executeAction(cmd, item, action)
+		if err != nil {
+			p.Errorf("Error: %v\n", err)
+		}
+		// Let the user read the output before the UI takes over the screen again
+		if err != nil || action == search.ActionPush || action == search.ActionPullFresh || action == search.ActionComment {
+			p.Print("\nPress Enter to return to the list...")
+			_, _ = bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		}
+
+		refreshed, err := search.RefreshItems(items)
+		if err != nil {
+			return fmt.Errorf("failed to scan files: %w", err)
+		}
+		items = refreshed
+		m.SetItems(items)
+	}
+}
+
+// tuiFilter returns a filter for the terminal UI that keeps the items matching
+// a CEL expression. The GitHub username is looked up on first use.
+func tuiFilter() tui.FilterFunc {
+	var username string
+
+	return func(expr string, items []search.Item) ([]search.Item, error) {
+		prg, err := search.CompileCELFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		if username == "" {
+			if username, err = search.GetCurrentUser(); err != nil {
+				return nil, err
+			}
+		}
+
+		matches, err := search.DiscoverItems(prg, username, search.SingleRepo(items))
+		if err != nil {
+			return nil, err
+		}
+		matched := make(map[string]bool, len(matches))
+		for _, item := range matches {
+			matched[item.FilePath] = true
+		}
+
+		var out []search.Item
+		for _, item := range items {
+			if matched[item.FilePath] {
+				out = append(out, item)
+			}
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/spf13/cobra"
+)
+
+var (
+	viewList   bool
+	viewFormat string
+
+	viewSaveSort        string
+	viewSaveTypes       []string
+	viewSaveRepo        string
+	viewSaveDescription string
+
+	viewListFormat string
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view <name> [repo]",
+	Short: "Browse local files through a saved filter",
+	Long: `Browse local files through a named view: a saved CEL filter with an
+optional sort order, item types and repository.
+
+Views are saved in the user config (~/.config/gh-md/config.yaml). Built-in views
+are always available; saving a view with the same name replaces it:
+
+  waiting-on-me     Open items you're involved in where someone else spoke last
+  review-requested  Open pull requests awaiting your review
+  mentioned         Open items mentioning you in the body or a comment
+  stale             Open items without activity for 30 days
+
+Filters use the same variables as 'gh md --filter'.
+
+Examples:
+  gh md view waiting-on-me                     # Open in FZF
+  gh md view stale owner/repo --list           # Print matches in one repo
+  gh md view save needs-review 'state == "open" && size(reviewers) == 0' --type prs
+  gh md view save mine 'author == user' --sort created
+  gh md view list
+  gh md view delete needs-review`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runView,
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name> <filter>",
+	Short: "Save a named view",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runViewSave,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved and built-in views",
+	Args:  cobra.NoArgs,
+	RunE:  runViewList,
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a saved view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runViewDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewSaveCmd, viewListCmd, viewDeleteCmd)
+
+	viewCmd.Flags().BoolVar(&viewList, "list", false, "Print matches without interactive FZF")
+	viewCmd.Flags().StringVar(&viewFormat, "format", "text", "Output format with --list: text, json, yaml, csv, tsv, markdown, ndjson, template=<go template> (default from config)")
+
+	viewSaveCmd.Flags().StringVar(&viewSaveSort, "sort", "", "Sort order: updated, created, number, comments, reactions, title, repo (default updated)")
+	viewSaveCmd.Flags().StringSliceVar(&viewSaveTypes, "type", nil, "Item types to include: issues, prs, discussions (default all)")
+	viewSaveCmd.Flags().StringVar(&viewSaveRepo, "repo", "", "Limit the view to a repository (owner/repo)")
+	viewSaveCmd.Flags().StringVar(&viewSaveDescription, "description", "", "Short description shown by 'view list'")
+
+	viewListCmd.Flags().StringVar(&viewListFormat, "format", "text", "Output format (text, json, yaml, csv, tsv, markdown, ndjson, template=<go template>)")
+}
+
+// typeListLabels maps config item type names to the labels of search items.
+var typeListLabels = map[string]string{
+	config.TypeIssues:      "issue",
+	config.TypePRs:         "pr",
+	config.TypeDiscussions: "discussion",
+}
+
+func runView(cmd *cobra.Command, args []string) error {
+	user, err := config.LoadUserSettings()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	name := args[0]
+	view, ok := config.LookupView(user, name)
+	if !ok {
+		return fmt.Errorf("unknown view %q (see 'gh md view list')", name)
+	}
+
+	repo := view.Repo
+	if len(args) > 1 {
+		input, err := github.ParseInput(args[1])
+		if err != nil {
+			return err
+		}
+		repo = input.FullName()
+	}
+
+	owner, repoName, _ := strings.Cut(repo, "/")
+	settings, err := meta.ResolveSettings(owner, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, viewFormat, settings, true)
+	if err != nil {
+		return err
+	}
+
+	items, err := discoverCEL(cmd, view.Filter, repo)
+	if err != nil {
+		return fmt.Errorf("view %s: %w", name, err)
+	}
+
+	if len(view.Types) > 0 {
+		var labels []string
+		for _, t := range view.Types {
+			labels = append(labels, typeListLabels[t])
+		}
+		items = slices.DeleteFunc(items, func(item search.Item) bool {
+			return !slices.Contains(labels, item.Type)
+		})
+	}
+
+	if len(items) == 0 {
+		p.Printf("No items match view %q.\n", name)
+		return nil
+	}
+
+	sortBy, err := search.ParseSortField(view.Sort)
+	if err != nil {
+		return fmt.Errorf("view %q: %w", name, err)
+	}
+
+	if viewList {
+		search.SortItems(items, sortBy, false)
+		return outputItems(p, items, "")
+	}
+
+	return browseItems(cmd, search.NewSelectorState(items, sortBy), "")
+}
+
+func runViewSave(cmd *cobra.Command, args []string) error {
+	p := output.NewPrinter(cmd)
+	name, filter := args[0], args[1]
+
+	if err := config.ValidateViewName(name, reservedViewNames()); err != nil {
+		return err
+	}
+
+	view := &config.View{
+		Description: viewSaveDescription,
+		Filter:      filter,
+		Sort:        viewSaveSort,
+		Types:       viewSaveTypes,
+	}
+	if viewSaveRepo != "" {
+		input, err := github.ParseInput(viewSaveRepo)
+		if err != nil {
+			return err
+		}
+		view.Repo = input.FullName()
+	}
+	if err := view.Validate(); err != nil {
+		return err
+	}
+	if _, err := search.ParseSortField(view.Sort); err != nil {
+		return err
+	}
+	if _, err := search.CompileCELFilter(filter); err != nil {
+		return fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	user, err := config.LoadUserSettings()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if user.Views == nil {
+		user.Views = make(map[string]*config.View)
+	}
+	_, existed := user.Views[name]
+	user.Views[name] = view
+
+	if err := config.SaveUserSettings(user); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	switch {
+	case existed:
+		p.Printf("Updated view %s\n", name)
+	case config.IsBuiltinView(name):
+		p.Printf("Saved view %s (replaces the built-in view)\n", name)
+	default:
+		p.Printf("Saved view %s\n", name)
+	}
+	return nil
+}
+
+func runViewList(cmd *cobra.Command, args []string) error {
+	settings, err := meta.ResolveSettings("", "")
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	p, err := newPrinter(cmd, viewListFormat, settings, true)
+	if err != nil {
+		return err
+	}
+
+	user, err := config.LoadUserSettings()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return output.List(p,
+		[]string{"NAME", "SOURCE", "TYPES", "SORT", "FILTER"},
+		config.ListViews(user),
+		func(v config.NamedView) []string {
+			source := "saved"
+			if v.Builtin {
+				source = "built-in"
+			}
+			types := strings.Join(v.Types, ",")
+			if types == "" {
+				types = "all"
+			}
+			if v.Repo != "" {
+				types += " in " + v.Repo
+			}
+			sort := v.Sort
+			if sort == "" {
+				sort = string(search.SortUpdated)
+			}
+			return []string{v.Name, source, types, sort, v.Filter}
+		},
+	)
+}
+
+func runViewDelete(cmd *cobra.Command, args []string) error {
+	p := output.NewPrinter(cmd)
+	name := args[0]
+
+	user, err := config.LoadUserSettings()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if _, ok := user.Views[name]; !ok {
+		if config.IsBuiltinView(name) {
+			return fmt.Errorf("%s is a built-in view and cannot be deleted", name)
+		}
+		return fmt.Errorf("unknown view %q", name)
+	}
+
+	delete(user.Views, name)
+	if err := config.SaveUserSettings(user); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if config.IsBuiltinView(name) {
+		p.Printf("Deleted view %s (the built-in view is available again)\n", name)
+	} else {
+		p.Printf("Deleted view %s\n", name)
+	}
+	return nil
+}
+
+// reservedViewNames returns names that would be shadowed by view subcommands.
+func reservedViewNames() []string {
+	names := []string{"help"}
+	for _, c := range viewCmd.Commands() {
+		names = append(names, c.Name())
+	}
+	return names
+}
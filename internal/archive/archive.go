@@ -0,0 +1,390 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/assets"
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/writer"
+)
+
+// DirName is the hidden per-repo directory holding archived items, one
+// compressed tarball per month: <repo>/.archive/2026-10.tar.gz.
+const DirName = config.ArchiveDirName
+
+const fileExt = ".tar.gz"
+
+var (
+	// ErrNotArchived is returned by Restore when no archive holds the item.
+	ErrNotArchived = errors.New("item is not archived")
+	// ErrExists is returned by Restore when the item file already exists.
+	ErrExists = errors.New("item file already exists")
+)
+
+// Item is an item file stored in an archive.
+type Item struct {
+	Archive string // Path of the archive holding the item
+	// Parsed is the archived content. Its FilePath is where the file lived,
+	// and where Restore puts it back.
+	Parsed  *parser.ParsedFile
+	ModTime time.Time
+	Size    int64
+}
+
+// Path returns the archive that items archived at t are added to.
+func Path(repoDir string, t time.Time) string {
+	return filepath.Join(repoDir, DirName, t.Format("2006-01")+fileExt)
+}
+
+// entry is a file in an archive, named relative to the repository directory.
+type entry struct {
+	header *tar.Header
+	data   []byte
+}
+
+// Add stores item files of the repository at repoDir in the archive for the
+// month of t, together with their base copies and downloaded attachments.
+// An item already in that archive is replaced. The files are left in place;
+// the caller deletes them once Add succeeds.
+func Add(repoDir string, itemPaths []string, t time.Time) error {
+	var added []entry
+	for _, path := range itemPaths {
+		entries, err := itemEntries(repoDir, path)
+		if err != nil {
+			return err
+		}
+		added = append(added, entries...)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	archivePath := Path(repoDir, t)
+	entries, err := readArchive(archivePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	entries = slices.DeleteFunc(entries, func(e entry) bool {
+		return slices.ContainsFunc(added, func(a entry) bool { return a.header.Name == e.header.Name })
+	})
+
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	return writeArchive(archivePath, append(entries, added...))
+}
+
+// itemEntries reads the files belonging to an item: the item file, its base
+// copy and its attachments.
+func itemEntries(repoDir, itemPath string) ([]entry, error) {
+	paths := []string{itemPath}
+	if _, err := os.Stat(writer.BasePath(itemPath)); err == nil {
+		paths = append(paths, writer.BasePath(itemPath))
+	}
+	err := filepath.WalkDir(assets.Dir(itemPath), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachments: %w", err)
+	}
+
+	entries := make([]entry, 0, len(paths))
+	for _, path := range paths {
+		rel, err := filepath.Rel(repoDir, path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		entries = append(entries, entry{
+			header: &tar.Header{
+				Name:     filepath.ToSlash(rel),
+				Mode:     int64(info.Mode().Perm()),
+				Size:     int64(len(data)),
+				ModTime:  info.ModTime(),
+				Typeflag: tar.TypeReg,
+			},
+			data: data,
+		})
+	}
+	return entries, nil
+}
+
+// belongsTo reports whether an archive entry is part of the item stored as
+// name (e.g. issues/12.md).
+func belongsTo(entryName, name string) bool {
+	typeDir, file := filepath.Split(filepath.FromSlash(name))
+	number := strings.TrimSuffix(file, filepath.Ext(file))
+	assetDir := filepath.ToSlash(filepath.Join(typeDir, assets.DirName, number)) + "/"
+	return entryName == name ||
+		entryName == writer.BaseDirName+"/"+name ||
+		strings.HasPrefix(entryName, assetDir)
+}
+
+// Walk calls fn for every archived item. If repoFilter is non-empty (format:
+// "owner/repo"), only that repository's archives are read. An item archived more
+// than once is reported from the newest archive only, and items whose file
+// exists again (e.g. pulled after being archived) are not reported.
+func Walk(repoFilter string, fn func(*Item) error) error {
+	repoDirs, err := repoDirs(repoFilter)
+	if err != nil {
+		return err
+	}
+
+	for _, repoDir := range repoDirs {
+		seen := make(map[string]bool)
+		for _, archivePath := range archives(repoDir) {
+			entries, err := readArchive(archivePath)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				if seen[e.header.Name] || !isItemFile(e.header.Name) {
+					continue
+				}
+				seen[e.header.Name] = true
+
+				path := filepath.Join(repoDir, filepath.FromSlash(e.header.Name))
+				if _, err := os.Stat(path); err == nil {
+					continue
+				}
+				parsed, err := parser.ParseContent(string(e.data), path)
+				if err != nil {
+					continue // Skip files that can't be parsed
+				}
+				item := &Item{
+					Archive: archivePath,
+					Parsed:  parsed,
+					ModTime: e.header.ModTime,
+					Size:    e.header.Size,
+				}
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Restore puts the archived item file at itemPath back, with its base copy and
+// attachments, and removes it from every archive. The newest archived copy is
+// used. Returns ErrNotArchived if no archive holds the item, and ErrExists if
+// the file already exists.
+func Restore(itemPath string) error {
+	if _, err := os.Stat(itemPath); err == nil {
+		return ErrExists
+	}
+
+	repoDir := filepath.Dir(filepath.Dir(itemPath))
+	rel, err := filepath.Rel(repoDir, itemPath)
+	if err != nil {
+		return err
+	}
+	name := filepath.ToSlash(rel)
+
+	restored := false
+	for _, archivePath := range archives(repoDir) {
+		entries, err := readArchive(archivePath)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(entries, func(e entry) bool { return e.header.Name == name }) {
+			continue
+		}
+
+		// Extract the newest copy; older copies are dropped so they don't
+		// show up as archived next to the restored file
+		var kept []entry
+		for _, e := range entries {
+			if !belongsTo(e.header.Name, name) {
+				kept = append(kept, e)
+				continue
+			}
+			if !restored {
+				if err := extract(repoDir, e); err != nil {
+					return err
+				}
+			}
+		}
+		restored = true
+		if err := writeArchive(archivePath, kept); err != nil {
+			return err
+		}
+	}
+	if !restored {
+		return ErrNotArchived
+	}
+	return nil
+}
+
+func extract(repoDir string, e entry) error {
+	path := filepath.Join(repoDir, filepath.FromSlash(e.header.Name))
+	if rel, err := filepath.Rel(repoDir, path); err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("invalid archive entry %q", e.header.Name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, e.data, fs.FileMode(e.header.Mode).Perm()); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", e.header.Name, err)
+	}
+	return nil
+}
+
+// isItemFile reports whether an archive entry is an item file (<type>/<number>.md)
+// rather than a base copy or attachment.
+func isItemFile(name string) bool {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || !strings.HasSuffix(parts[1], ".md") {
+		return false
+	}
+	_, ok := github.ItemTypeFromDirName(parts[0])
+	return ok
+}
+
+// repoDirs returns the repository directories under the root, or only the one
+// for repoFilter.
+func repoDirs(repoFilter string) ([]string, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return nil, err
+	}
+	if repoFilter != "" {
+		return []string{filepath.Join(root, filepath.FromSlash(repoFilter))}, nil
+	}
+
+	owners, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var dirs []string
+	for _, owner := range owners {
+		if !owner.IsDir() || strings.HasPrefix(owner.Name(), ".") {
+			continue
+		}
+		repos, err := os.ReadDir(filepath.Join(root, owner.Name()))
+		if err != nil {
+			continue
+		}
+		for _, repo := range repos {
+			if repo.IsDir() && !strings.HasPrefix(repo.Name(), ".") {
+				dirs = append(dirs, filepath.Join(root, owner.Name(), repo.Name()))
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// archives returns the archives of a repository, newest first.
+func archives(repoDir string) []string {
+	paths, _ := filepath.Glob(filepath.Join(repoDir, DirName, "*"+fileExt))
+	slices.Sort(paths)
+	slices.Reverse(paths)
+	return paths
+}
+
+func readArchive(path string) ([]entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+	}
+	tr := tar.NewReader(gz)
+
+	var entries []entry
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+		}
+		entries = append(entries, entry{header: header, data: data})
+	}
+	return entries, nil
+}
+
+// writeArchive replaces the archive at path with entries, or removes it if
+// there are none.
+func writeArchive(path string, entries []entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove archive: %w", err)
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		if err := tw.WriteHeader(e.header); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+		if _, err := tw.Write(e.data); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	// Atomic write: write to temp file, then rename
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/writer"
+)
+
+func writeItem(t *testing.T, path, title string) {
+	t.Helper()
+	content := "---\nid: I_1\nnumber: 1\nowner: o\nrepo: r\nstate: closed\n---\n<!-- gh-md:content -->\n# " + title + "\n<!-- /gh-md:content -->\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func walkAll(t *testing.T, repoFilter string) []*Item {
+	t.Helper()
+	var items []*Item
+	if err := Walk(repoFilter, func(item *Item) error {
+		items = append(items, item)
+		return nil
+	}); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	return items
+}
+
+func TestAddWalkRestore(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	repoDir := filepath.Join(root, "o", "r")
+	itemPath := filepath.Join(repoDir, "issues", "1.md")
+	assetPath := filepath.Join(repoDir, "issues", "assets", "1", "shot.png")
+	otherAsset := filepath.Join(repoDir, "issues", "assets", "10", "other.png")
+	writeItem(t, itemPath, "Crash on start")
+	writeItem(t, writer.BasePath(itemPath), "Crash on start")
+	for _, path := range []string{assetPath, otherAsset} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("png"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if err := Add(repoDir, []string{itemPath}, now); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, ".archive", "2026-10.tar.gz")); err != nil {
+		t.Fatalf("archive not written: %v", err)
+	}
+	for _, path := range []string{itemPath, writer.BasePath(itemPath), assetPath} {
+		if err := os.Remove(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items := walkAll(t, "")
+	if len(items) != 1 {
+		t.Fatalf("Walk() found %d items, want 1", len(items))
+	}
+	if items[0].Parsed.Title != "Crash on start" || items[0].Parsed.FilePath != itemPath {
+		t.Errorf("Walk() item = %q at %s, want %q at %s", items[0].Parsed.Title, items[0].Parsed.FilePath, "Crash on start", itemPath)
+	}
+	if got := walkAll(t, "o/other"); len(got) != 0 {
+		t.Errorf("Walk(o/other) found %d items, want 0", len(got))
+	}
+
+	if err := Restore(itemPath); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	for _, path := range []string{itemPath, writer.BasePath(itemPath), assetPath, otherAsset} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s not restored: %v", path, err)
+		}
+	}
+	// The archive held only this item, so it is removed
+	if _, err := os.Stat(filepath.Join(repoDir, ".archive", "2026-10.tar.gz")); !os.IsNotExist(err) {
+		t.Errorf("archive still exists after restoring its only item: %v", err)
+	}
+
+	if err := Restore(itemPath); !errors.Is(err, ErrExists) {
+		t.Errorf("Restore() of an existing file error = %v, want ErrExists", err)
+	}
+	if err := os.Remove(itemPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(itemPath); !errors.Is(err, ErrNotArchived) {
+		t.Errorf("Restore() error = %v, want ErrNotArchived", err)
+	}
+}
+
+func TestWalk_NewestArchiveWins(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	repoDir := filepath.Join(root, "o", "r")
+	itemPath := filepath.Join(repoDir, "issues", "1.md")
+
+	writeItem(t, itemPath, "Old title")
+	if err := Add(repoDir, []string{itemPath}, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	writeItem(t, itemPath, "New title")
+	if err := Add(repoDir, []string{itemPath}, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	// The file was pulled again after being archived
+	if got := walkAll(t, "o/r"); len(got) != 0 {
+		t.Fatalf("Walk() = %d items, want none while the file exists", len(got))
+	}
+
+	if err := os.Remove(itemPath); err != nil {
+		t.Fatal(err)
+	}
+	items := walkAll(t, "o/r")
+	if len(items) != 1 || items[0].Parsed.Title != "New title" {
+		t.Fatalf("Walk() = %d items, want only the newest copy", len(items))
+	}
+
+	if err := Restore(itemPath); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	content, err := os.ReadFile(itemPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "# New title\n") {
+		t.Errorf("restored content = %q, want the newest copy", content)
+	}
+
+	// Older copies are removed too
+	if err := os.Remove(itemPath); err != nil {
+		t.Fatal(err)
+	}
+	if got := walkAll(t, "o/r"); len(got) != 0 {
+		t.Errorf("Walk() after Restore() = %d items, want none", len(got))
+	}
+}
+
+func TestBelongsTo(t *testing.T) {
+	tests := []struct {
+		entry string
+		want  bool
+	}{
+		{"issues/1.md", true},
+		{".base/issues/1.md", true},
+		{"issues/assets/1/shot.png", true},
+		{"issues/assets/10/shot.png", false},
+		{"issues/10.md", false},
+		{"pulls/1.md", false},
+	}
+	for _, tt := range tests {
+		if got := belongsTo(tt.entry, "issues/1.md"); got != tt.want {
+			t.Errorf("belongsTo(%q) = %v, want %v", tt.entry, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package assets
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+// DirName is the directory beside item files holding downloaded attachments,
+// one subdirectory per item number.
+const DirName = "assets"
+
+// ManifestName is the file in each item's asset directory mapping local file
+// names to the GitHub URLs they were downloaded from.
+const ManifestName = ".gh-md-assets.yaml"
+
+// maxSize caps a single download so a stray link can't fill the disk.
+const maxSize = 50 << 20
+
+// remotePattern matches attachment URLs hosted by GitHub.
+var remotePattern = regexp.MustCompile(
+	`https://(?:user-images\.githubusercontent\.com/|github\.com/user-attachments/(?:assets|files)/)[^\s)\]"'<>]+`,
+)
+
+// imagePattern matches markdown image targets and HTML img sources.
+var imagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?|<img\s[^>]*src="([^"]+)"`)
+
+// Manifest maps local asset file names to their GitHub URLs.
+type Manifest struct {
+	Files map[string]string `yaml:"files"`
+}
+
+// Downloader fetches attachment content.
+type Downloader interface {
+	Download(url string) (data []byte, contentType string, err error)
+}
+
+// HTTPDownloader downloads attachments using gh authentication, which private
+// repository attachments require.
+type HTTPDownloader struct {
+	client *http.Client
+}
+
+// NewDownloader creates a downloader authenticated with gh auth.
+func NewDownloader() (*HTTPDownloader, error) {
+	client, err := api.NewHTTPClient(api.ClientOptions{
+		Headers: map[string]string{"Accept": "*/*"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	return &HTTPDownloader{client: client}, nil
+}
+
+// Download fetches url and returns its content and content type.
+func (d *HTTPDownloader) Download(url string) ([]byte, string, error) {
+	resp, err := d.client.Get(url)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > maxSize {
+		return nil, "", fmt.Errorf("%s: larger than %d MB", url, maxSize>>20)
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// Dir returns the asset directory for an item file.
+// For <repo>/issues/1.md this is <repo>/issues/assets/1.
+func Dir(itemPath string) string {
+	return filepath.Join(filepath.Dir(itemPath), DirName, itemNumber(itemPath))
+}
+
+// relDir returns the asset directory as linked from the item file.
+func relDir(itemPath string) string {
+	return DirName + "/" + itemNumber(itemPath)
+}
+
+func itemNumber(itemPath string) string {
+	return strings.TrimSuffix(filepath.Base(itemPath), filepath.Ext(itemPath))
+}
+
+// Localize rewrites GitHub attachment URLs in content to relative links to local
+// copies for the item at itemPath. Missing copies are downloaded with d; attachments
+// that fail to download keep their URL. With a nil d, only attachments already
+// downloaded are rewritten.
+func Localize(content, itemPath string, d Downloader) (string, error) {
+	urls := remotePattern.FindAllString(content, -1)
+	if len(urls) == 0 {
+		return content, nil
+	}
+
+	dir := Dir(itemPath)
+	m, err := loadManifest(dir)
+	if err != nil {
+		return "", err
+	}
+
+	byURL := make(map[string]string, len(m.Files))
+	for name, url := range m.Files {
+		byURL[url] = name
+	}
+
+	changed := false
+	for _, url := range urls {
+		name, ok := byURL[url]
+		if ok {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				continue
+			}
+		}
+		if d == nil {
+			delete(byURL, url)
+			continue
+		}
+
+		data, contentType, err := d.Download(url)
+		if err != nil {
+			delete(byURL, url)
+			continue // Keep the remote link
+		}
+
+		if !ok {
+			name = uniqueName(m, fileName(url, contentType))
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create asset directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return "", fmt.Errorf("failed to write asset: %w", err)
+		}
+		if m.Files == nil {
+			m.Files = make(map[string]string)
+		}
+		m.Files[name] = url
+		byURL[url] = name
+		changed = true
+	}
+
+	if changed {
+		if err := saveManifest(dir, m); err != nil {
+			return "", err
+		}
+	}
+
+	rel := relDir(itemPath)
+	return remotePattern.ReplaceAllStringFunc(content, func(url string) string {
+		if name, ok := byURL[url]; ok {
+			return rel + "/" + name
+		}
+		return url
+	}), nil
+}
+
+// Restore reverses Localize in text, turning links to downloaded attachments back
+// into their GitHub URLs. GitHub's API cannot upload attachments, so an error is
+// returned for any local image that was never on GitHub.
+func Restore(text, itemPath string) (string, error) {
+	m, err := loadManifest(Dir(itemPath))
+	if err != nil {
+		return "", err
+	}
+
+	rel := relDir(itemPath)
+	localPattern := regexp.MustCompile(`(^|[\s("'<\[])(?:\./)?` + regexp.QuoteMeta(rel) + `/([^\s)\]"'<>]+)`)
+
+	var missing []string
+	text = localPattern.ReplaceAllStringFunc(text, func(match string) string {
+		sub := localPattern.FindStringSubmatch(match)
+		if url, ok := m.Files[sub[2]]; ok {
+			return sub[1] + url
+		}
+		missing = append(missing, rel+"/"+sub[2])
+		return match
+	})
+
+	// Other images pointing at local files can't be pushed either
+	for _, sub := range imagePattern.FindAllStringSubmatch(text, -1) {
+		target := sub[1] + sub[2]
+		if strings.Contains(target, "://") || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "data:") {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimPrefix(target, "./"), rel+"/") {
+			continue // Already reported above
+		}
+		if _, err := os.Stat(filepath.Join(filepath.Dir(itemPath), filepath.FromSlash(target))); err == nil {
+			missing = append(missing, target)
+		}
+	}
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("local images cannot be uploaded through the GitHub API: %s\n"+
+			"Attach them on github.com (drag into a comment box) and link the resulting URL instead",
+			strings.Join(missing, ", "))
+	}
+
+	return text, nil
+}
+
+// Remove deletes the asset directory of an item file, if any.
+func Remove(itemPath string) error {
+	return os.RemoveAll(Dir(itemPath))
+}
+
+func loadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Manifest{}, nil
+		}
+		return nil, fmt.Errorf("failed to read asset manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse asset manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func saveManifest(dir string, m *Manifest) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestName), data, 0644); err != nil {
+		return fmt.Errorf("failed to write asset manifest: %w", err)
+	}
+	return nil
+}
+
+// fileName derives a safe local file name from an attachment URL, adding an
+// extension from the content type when the URL has none.
+func fileName(url, contentType string) string {
+	base := path.Base(strings.SplitN(url, "?", 2)[0])
+
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, base)
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		name = "attachment"
+	}
+
+	if path.Ext(name) == "" {
+		name += extension(contentType)
+	}
+	return name
+}
+
+// extension returns the preferred file extension for a content type.
+func extension(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/svg+xml":
+		return ".svg"
+	case "image/webp":
+		return ".webp"
+	case "video/mp4":
+		return ".mp4"
+	case "video/quicktime":
+		return ".mov"
+	case "application/pdf":
+		return ".pdf"
+	}
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// uniqueName returns name, or a numbered variant if the manifest already uses it.
+func uniqueName(m *Manifest, name string) string {
+	if _, taken := m.Files[name]; !taken {
+		return name
+	}
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", stem, i, ext)
+		if _, taken := m.Files[candidate]; !taken {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package assets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeDownloader struct {
+	files map[string]string // URL -> content type
+	calls int
+}
+
+func (f *fakeDownloader) Download(url string) ([]byte, string, error) {
+	f.calls++
+	contentType, ok := f.files[url]
+	if !ok {
+		return nil, "", errors.New("not found")
+	}
+	return []byte("data:" + url), contentType, nil
+}
+
+const (
+	imageURL  = "https://github.com/user-attachments/assets/0a1b2c3d-4e5f"
+	legacyURL = "https://user-images.githubusercontent.com/123/shot.png"
+	brokenURL = "https://github.com/user-attachments/assets/missing"
+)
+
+func TestLocalizeAndRestore(t *testing.T) {
+	itemPath := filepath.Join(t.TempDir(), "issues", "7.md")
+	d := &fakeDownloader{files: map[string]string{
+		imageURL:  "image/png",
+		legacyURL: "image/png",
+	}}
+
+	content := "![a](" + imageURL + ")\n<img src=\"" + legacyURL + "\">\n![b](" + brokenURL + ")\n"
+
+	got, err := Localize(content, itemPath, d)
+	if err != nil {
+		t.Fatalf("Localize() error = %v", err)
+	}
+	want := "![a](assets/7/0a1b2c3d-4e5f.png)\n<img src=\"assets/7/shot.png\">\n![b](" + brokenURL + ")\n"
+	if got != want {
+		t.Errorf("Localize() =\n%s\nwant:\n%s", got, want)
+	}
+
+	data, err := os.ReadFile(filepath.Join(Dir(itemPath), "shot.png"))
+	if err != nil || string(data) != "data:"+legacyURL {
+		t.Errorf("downloaded file = %q, %v", data, err)
+	}
+
+	// Already downloaded files are reused
+	d.calls = 0
+	if again, err := Localize(content, itemPath, d); err != nil || again != want {
+		t.Errorf("second Localize() = %q, %v", again, err)
+	}
+	if d.calls != 1 {
+		t.Errorf("second Localize() made %d downloads, want 1 (the broken link retried)", d.calls)
+	}
+
+	// Without a downloader only existing copies are linked
+	if offline, err := Localize(content, itemPath, nil); err != nil || offline != want {
+		t.Errorf("Localize(nil) = %q, %v", offline, err)
+	}
+
+	restored, err := Restore(got, itemPath)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored != content {
+		t.Errorf("Restore() =\n%s\nwant:\n%s", restored, content)
+	}
+}
+
+func TestRestore_RefusesLocalImages(t *testing.T) {
+	itemPath := filepath.Join(t.TempDir(), "issues", "7.md")
+	if err := os.MkdirAll(filepath.Join(filepath.Dir(itemPath), "img"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(itemPath), "img", "new.png"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string // Substring of the error, empty for no error
+	}{
+		{"unknown asset", "![x](assets/7/new.png)", "assets/7/new.png"},
+		{"local file", "see ![x](img/new.png)", "img/new.png"},
+		{"missing relative file is left alone", "![x](img/other.png)", ""},
+		{"remote image", "![x](https://example.com/x.png)", ""},
+		{"other item's path in a URL", "https://example.com/assets/7/x.png", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Restore(tt.text, itemPath)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Restore() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Restore() error = %v, want mention of %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		url, contentType, want string
+	}{
+		{"https://github.com/user-attachments/assets/abc", "image/jpeg", "abc.jpg"},
+		{"https://github.com/user-attachments/files/1/my report.pdf", "application/pdf", "my-report.pdf"},
+		{"https://user-images.githubusercontent.com/1/x.gif?raw=true", "", "x.gif"},
+	}
+	for _, tt := range tests {
+		if got := fileName(tt.url, tt.contentType); got != tt.want {
+			t.Errorf("fileName(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	m := &Manifest{Files: map[string]string{"a.png": "u1", "a-2.png": "u2"}}
+	if got := uniqueName(m, "a.png"); got != "a-3.png" {
+		t.Errorf("uniqueName() = %q, want a-3.png", got)
+	}
+}
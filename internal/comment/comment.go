@@ -0,0 +1,145 @@
+// Package comment prepares the temporary buffer used to write a single comment
+// on an item, outside the item's file.
+package comment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+const (
+	helpStart = "<!-- gh-md:comment-help"
+	helpEnd   = "-->"
+)
+
+// Target is something a comment can respond to: an existing comment, or a
+// review thread of a pull request.
+type Target struct {
+	ReplyTo string // Comment or review thread ID to reply to; empty for a top-level comment
+	Label   string // One-line description for pickers
+	Author  string
+	Body    string // Text to quote
+}
+
+// Targets returns the comments and review threads of an item a new comment can
+// respond to, in file order. content is the raw file, used for review threads.
+//
+// Only discussions and review threads support threaded replies; responding to an
+// issue or pull request comment posts a top-level comment quoting it.
+func Targets(parsed *parser.ParsedFile, content string) []Target {
+	var targets []Target
+
+	for _, c := range parsed.Comments {
+		if c.ID == "" {
+			continue // Unposted draft
+		}
+		t := Target{
+			Label:  fmt.Sprintf("@%s: %s", c.Author, summary(c.Body)),
+			Author: c.Author,
+			Body:   c.Body,
+		}
+		if parsed.ItemType == github.ItemTypeDiscussion {
+			// Discussions nest one level deep, so replies join the top-level thread
+			t.ReplyTo = c.ID
+			if c.ParentID != "" {
+				t.ReplyTo = c.ParentID
+			}
+		}
+		targets = append(targets, t)
+	}
+
+	if parsed.ItemType == github.ItemTypePullRequest {
+		for _, th := range parser.ParseReviewThreads(content) {
+			if len(th.Comments) == 0 {
+				continue
+			}
+			last := th.Comments[len(th.Comments)-1]
+			targets = append(targets, Target{
+				ReplyTo: th.ID,
+				Label:   fmt.Sprintf("%s:%d @%s: %s", th.Path, th.Line, last.Author, summary(last.Body)),
+				Author:  last.Author,
+				Body:    last.Body,
+			})
+		}
+	}
+
+	return targets
+}
+
+// summary returns the first non-empty line of body, shortened for a picker.
+func summary(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if r := []rune(line); len(r) > 60 {
+			return string(r[:57]) + "..."
+		}
+		return line
+	}
+	return "(empty)"
+}
+
+// Buffer returns the initial content of the comment buffer: the quoted target
+// if quote is set, followed by a help block describing where the comment goes.
+// target may be nil for a new top-level comment.
+func Buffer(parsed *parser.ParsedFile, target *Target, quote bool) string {
+	var sb strings.Builder
+
+	if target != nil && quote && target.Body != "" {
+		fmt.Fprintf(&sb, "> @%s wrote:\n>\n", target.Author)
+		for _, line := range strings.Split(strings.TrimSpace(target.Body), "\n") {
+			if line == "" {
+				sb.WriteString(">\n")
+				continue
+			}
+			sb.WriteString("> " + line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n\n")
+
+	sb.WriteString(helpStart + "\n")
+	fmt.Fprintf(&sb, "Commenting on %s/%s #%d: %s\n", parsed.Owner, parsed.Repo, parsed.Number, parsed.Title)
+	switch {
+	case target == nil:
+		sb.WriteString("New top-level comment.\n")
+	case target.ReplyTo != "":
+		fmt.Fprintf(&sb, "Replying to %s.\n", target.Label)
+	default:
+		fmt.Fprintf(&sb, "New top-level comment, responding to %s.\n", target.Label)
+	}
+	sb.WriteString("Write the comment above this block, then save and quit.\n")
+	sb.WriteString("This block is removed. Leave the buffer unchanged or empty to cancel.\n")
+	sb.WriteString(helpEnd + "\n")
+
+	return sb.String()
+}
+
+// ParseBuffer returns the comment written in buf, with the help block removed.
+// It returns "" if the buffer is empty or was left as initial, the content
+// Buffer returned.
+func ParseBuffer(buf, initial string) string {
+	body := stripHelp(buf)
+	if body == "" || body == stripHelp(initial) {
+		return ""
+	}
+	return body
+}
+
+// stripHelp removes the help block and surrounding whitespace.
+func stripHelp(buf string) string {
+	if start := strings.Index(buf, helpStart); start != -1 {
+		end := strings.Index(buf[start:], helpEnd)
+		if end == -1 {
+			buf = buf[:start]
+		} else {
+			buf = buf[:start] + buf[start+end+len(helpEnd):]
+		}
+	}
+	return strings.TrimSpace(buf)
+}
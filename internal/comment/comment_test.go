@@ -0,0 +1,86 @@
+package comment
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+func TestTargets_Discussion(t *testing.T) {
+	parsed := &parser.ParsedFile{
+		ItemType: github.ItemTypeDiscussion,
+		Comments: []parser.ParsedComment{
+			{ID: "DC_1", Author: "alice", Body: "Top-level\n\nmore", Created: time.Now()},
+			{ID: "DC_2", Author: "bob", Body: "A reply", ParentID: "DC_1"},
+			{Body: "Unposted draft"},
+		},
+	}
+
+	targets := Targets(parsed, "")
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(targets))
+	}
+	if targets[0].ReplyTo != "DC_1" || targets[0].Label != "@alice: Top-level" {
+		t.Errorf("unexpected first target: %+v", targets[0])
+	}
+	// Replies to a reply join the top-level thread
+	if targets[1].ReplyTo != "DC_1" {
+		t.Errorf("reply target = %q, want DC_1", targets[1].ReplyTo)
+	}
+}
+
+func TestTargets_PullRequest(t *testing.T) {
+	content := "<!-- gh-md:review-thread\nid: PRRT_1\npath: main.go\nline: 7\n-->\n" +
+		"<!-- gh-md:review-comment\nid: PRRC_1\nauthor: carol\ncreated: 2026-01-01T00:00:00Z\n-->\n" +
+		"#### @carol (2026-01-01)\n\nNit: rename this\n<!-- /gh-md:review-comment -->\n\n" +
+		"<!-- /gh-md:review-thread -->\n"
+	parsed := &parser.ParsedFile{
+		ItemType: github.ItemTypePullRequest,
+		Comments: []parser.ParsedComment{{ID: "IC_1", Author: "alice", Body: "LGTM"}},
+	}
+
+	targets := Targets(parsed, content)
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(targets))
+	}
+	// PR conversation comments are quoted, not threaded
+	if targets[0].ReplyTo != "" {
+		t.Errorf("PR comment reply target = %q, want empty", targets[0].ReplyTo)
+	}
+	if targets[1].ReplyTo != "PRRT_1" || targets[1].Label != "main.go:7 @carol: Nit: rename this" {
+		t.Errorf("unexpected thread target: %+v", targets[1])
+	}
+}
+
+func TestBufferAndParseBuffer(t *testing.T) {
+	parsed := &parser.ParsedFile{Owner: "o", Repo: "r", Number: 3, Title: "Crash"}
+	target := &Target{ReplyTo: "DC_1", Label: "@alice: hi", Author: "alice", Body: "First\n\nSecond"}
+
+	initial := Buffer(parsed, target, true)
+	if !strings.HasPrefix(initial, "> @alice wrote:\n>\n> First\n>\n> Second\n") {
+		t.Errorf("quote not at start of buffer:\n%s", initial)
+	}
+	if !strings.Contains(initial, "Commenting on o/r #3: Crash") || !strings.Contains(initial, "Replying to @alice: hi.") {
+		t.Errorf("help block missing context:\n%s", initial)
+	}
+
+	if got := ParseBuffer(initial, initial); got != "" {
+		t.Errorf("unchanged buffer = %q, want empty", got)
+	}
+	if got := ParseBuffer("", initial); got != "" {
+		t.Errorf("empty buffer = %q, want empty", got)
+	}
+
+	edited := strings.Replace(initial, "> Second\n\n", "> Second\n\nThanks!\n", 1)
+	want := "> @alice wrote:\n>\n> First\n>\n> Second\n\nThanks!"
+	if got := ParseBuffer(edited, initial); got != want {
+		t.Errorf("ParseBuffer() = %q, want %q", got, want)
+	}
+
+	if got := ParseBuffer(Buffer(parsed, nil, true), ""); got != "" {
+		t.Errorf("help-only buffer = %q, want empty", got)
+	}
+}
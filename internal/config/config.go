@@ -10,6 +10,33 @@ const (
 	EnvRootDir     = "GH_MD_ROOT"
 )
 
+// Internal files and directories under the root, owned by the packages that
+// use them. They are named here so history can keep them out of git.
+const (
+	BackupDirName  = ".gh-md-backup"     // Root: originals copied by migrate
+	JournalDirName = ".gh-md-journal"    // Root: journals of interrupted pushes
+	QueueFileName  = ".gh-md-queue.yaml" // Root: queued pushes
+	MetaFileName   = ".gh-md-meta.yaml"  // Per repo: sync metadata
+	CacheFileName  = ".gh-md-items.json" // Per repo: metadata cache
+	IndexFileName  = ".gh-md-index"      // Per repo: search index
+	BaseDirName    = ".base"             // Per repo: item content as last pulled
+	ArchiveDirName = ".archive"          // Per repo: archived items
+)
+
+// IgnorePatterns are .gitignore patterns matching the internal files, so only
+// item files are tracked in history.
+var IgnorePatterns = []string{
+	BackupDirName + "/",
+	JournalDirName + "/",
+	IndexFileName,
+	QueueFileName,
+	MetaFileName,
+	CacheFileName,
+	BaseDirName + "/",
+	ArchiveDirName + "/",
+	"*.tmp",
+}
+
 // GetRootDir returns the root directory for gh-md storage.
 // It checks GH_MD_ROOT env var first, then defaults to ~/.gh-md/
 func GetRootDir() (string, error) {
@@ -0,0 +1,614 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvConfigFile  = "GH_MD_CONFIG"
+	configDirName  = "gh-md"
+	configFileName = "config.yaml"
+)
+
+// Item type names used in settings. They match the --issues, --prs and
+// --discussions flag names.
+const (
+	TypeIssues      = "issues"
+	TypePRs         = "prs"
+	TypeDiscussions = "discussions"
+)
+
+// AllTypes lists every item type name in display order.
+var AllTypes = []string{TypeIssues, TypePRs, TypeDiscussions}
+
+// Settings holds user-configurable defaults.
+// Unset fields fall through to the next level of precedence:
+// flag > repo (.gh-md-meta.yaml) > user (config.yaml) > built-in default.
+type Settings struct {
+	Types    []string     `yaml:"types,omitempty"`     // Item types to pull and browse
+	OpenOnly *bool        `yaml:"open_only,omitempty"` // Fetch only open items
+	Limit    *int         `yaml:"limit,omitempty"`     // Max items to pull per type (0 = no limit)
+	Labels   []string     `yaml:"labels,omitempty"`    // Only pull issues and PRs carrying one of these labels
+	Repos    []string     `yaml:"repos,omitempty"`     // Repositories included by pull --all
+	Format   string       `yaml:"format,omitempty"`    // Default output format
+	Editor   string       `yaml:"editor,omitempty"`    // Editor command (overrides $EDITOR)
+	History  *bool        `yaml:"history,omitempty"`   // Record pull/push/prune in a git repository at the root
+	Assets   *bool        `yaml:"assets,omitempty"`    // Download attachments and link local copies when pulling
+	Prune    *PrunePolicy `yaml:"prune,omitempty"`
+
+	// Views are saved filters. They are only read from the user config and
+	// are not merged like the keys above.
+	Views map[string]*View `yaml:"views,omitempty"`
+}
+
+// PrunePolicy controls which local files prune removes. Closed and merged
+// items are pruned, stale open items optionally too, unless a keep rule applies.
+type PrunePolicy struct {
+	Types        []string `yaml:"types,omitempty"`         // Item types eligible for pruning
+	ClosedDays   *int     `yaml:"closed_days,omitempty"`   // Only prune items closed more than this many days ago
+	StaleMonths  *int     `yaml:"stale_months,omitempty"`  // Also prune open items not updated in this many months (0 = never)
+	KeepLabels   []string `yaml:"keep_labels,omitempty"`   // Never prune items carrying one of these labels
+	KeepAssigned *bool    `yaml:"keep_assigned,omitempty"` // Never prune items assigned to the current user
+	KeepPinned   *bool    `yaml:"keep_pinned,omitempty"`   // Never prune pinned issues
+}
+
+// DefaultSettings returns the built-in defaults.
+func DefaultSettings() *Settings {
+	openOnly := false
+	limit := 0
+	history := false
+	downloadAssets := false
+	return &Settings{
+		Types:    slices.Clone(AllTypes),
+		OpenOnly: &openOnly,
+		Limit:    &limit,
+		Format:   "text",
+		History:  &history,
+		Assets:   &downloadAssets,
+		Prune: &PrunePolicy{
+			Types: slices.Clone(AllTypes),
+		},
+	}
+}
+
+// Merge returns a copy of s with every field set in over taking precedence.
+// A nil over returns a copy of s unchanged.
+func (s *Settings) Merge(over *Settings) *Settings {
+	merged := *s
+	if s.Prune != nil {
+		prune := *s.Prune
+		merged.Prune = &prune
+	}
+	if over == nil {
+		return &merged
+	}
+
+	if over.Types != nil {
+		merged.Types = over.Types
+	}
+	if over.OpenOnly != nil {
+		merged.OpenOnly = over.OpenOnly
+	}
+	if over.Limit != nil {
+		merged.Limit = over.Limit
+	}
+	if over.Labels != nil {
+		merged.Labels = over.Labels
+	}
+	if over.Repos != nil {
+		merged.Repos = over.Repos
+	}
+	if over.Format != "" {
+		merged.Format = over.Format
+	}
+	if over.Editor != "" {
+		merged.Editor = over.Editor
+	}
+	if over.History != nil {
+		merged.History = over.History
+	}
+	if over.Assets != nil {
+		merged.Assets = over.Assets
+	}
+	if over.Prune != nil {
+		if merged.Prune == nil {
+			merged.Prune = &PrunePolicy{}
+		}
+		if over.Prune.Types != nil {
+			merged.Prune.Types = over.Prune.Types
+		}
+		if over.Prune.ClosedDays != nil {
+			merged.Prune.ClosedDays = over.Prune.ClosedDays
+		}
+		if over.Prune.StaleMonths != nil {
+			merged.Prune.StaleMonths = over.Prune.StaleMonths
+		}
+		if over.Prune.KeepLabels != nil {
+			merged.Prune.KeepLabels = over.Prune.KeepLabels
+		}
+		if over.Prune.KeepAssigned != nil {
+			merged.Prune.KeepAssigned = over.Prune.KeepAssigned
+		}
+		if over.Prune.KeepPinned != nil {
+			merged.Prune.KeepPinned = over.Prune.KeepPinned
+		}
+	}
+
+	return &merged
+}
+
+// HistoryEnabled reports whether changes should be recorded in git history.
+func (s *Settings) HistoryEnabled() bool {
+	return s.History != nil && *s.History
+}
+
+// AssetsEnabled reports whether attachments should be downloaded when pulling.
+func (s *Settings) AssetsEnabled() bool {
+	return s.Assets != nil && *s.Assets
+}
+
+// IncludesType reports whether the given item type name is enabled.
+// An empty Types list enables all types.
+func (s *Settings) IncludesType(name string) bool {
+	return len(s.Types) == 0 || slices.Contains(s.Types, name)
+}
+
+// PruneIncludesType reports whether the given item type name may be pruned.
+func (s *Settings) PruneIncludesType(name string) bool {
+	if s.Prune == nil || len(s.Prune.Types) == 0 {
+		return true
+	}
+	return slices.Contains(s.Prune.Types, name)
+}
+
+// IsEmpty reports whether no field is set.
+func (s *Settings) IsEmpty() bool {
+	for _, key := range settingKeys {
+		if _, ok := key.get(s); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// GetUserConfigPath returns the path of the user configuration file.
+// It checks GH_MD_CONFIG first, then $XDG_CONFIG_HOME/gh-md/config.yaml,
+// then ~/.config/gh-md/config.yaml.
+func GetUserConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, configDirName, configFileName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", configDirName, configFileName), nil
+}
+
+// LoadUserSettings loads the user configuration file.
+// Returns empty Settings if the file doesn't exist.
+func LoadUserSettings() (*Settings, error) {
+	path, err := GetUserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Settings{}, nil
+		}
+		return nil, err
+	}
+
+	var s Settings
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &s, nil
+}
+
+// SaveUserSettings saves the user configuration file with atomic write.
+func SaveUserSettings(s *Settings) error {
+	path, err := GetUserConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// settingKey describes a single configurable key.
+type settingKey struct {
+	name  string
+	get   func(*Settings) (string, bool)
+	set   func(*Settings, string) error
+	unset func(*Settings)
+}
+
+var settingKeys = []settingKey{
+	{
+		name: "types",
+		get:  func(s *Settings) (string, bool) { return joinList(s.Types) },
+		set: func(s *Settings, v string) error {
+			types, err := parseTypes(v)
+			if err != nil {
+				return err
+			}
+			s.Types = types
+			return nil
+		},
+		unset: func(s *Settings) { s.Types = nil },
+	},
+	{
+		name: "open_only",
+		get: func(s *Settings) (string, bool) {
+			if s.OpenOnly == nil {
+				return "", false
+			}
+			return strconv.FormatBool(*s.OpenOnly), true
+		},
+		set: func(s *Settings, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q", v)
+			}
+			s.OpenOnly = &b
+			return nil
+		},
+		unset: func(s *Settings) { s.OpenOnly = nil },
+	},
+	{
+		name: "limit",
+		get: func(s *Settings) (string, bool) {
+			if s.Limit == nil {
+				return "", false
+			}
+			return strconv.Itoa(*s.Limit), true
+		},
+		set: func(s *Settings, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid limit %q (expected a non-negative integer)", v)
+			}
+			s.Limit = &n
+			return nil
+		},
+		unset: func(s *Settings) { s.Limit = nil },
+	},
+	{
+		name: "labels",
+		get:  func(s *Settings) (string, bool) { return joinList(s.Labels) },
+		set: func(s *Settings, v string) error {
+			s.Labels = splitList(v)
+			return nil
+		},
+		unset: func(s *Settings) { s.Labels = nil },
+	},
+	{
+		name: "repos",
+		get:  func(s *Settings) (string, bool) { return joinList(s.Repos) },
+		set: func(s *Settings, v string) error {
+			repos := splitList(v)
+			for _, r := range repos {
+				if parts := strings.Split(r, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return fmt.Errorf("invalid repository %q (expected owner/repo)", r)
+				}
+			}
+			s.Repos = repos
+			return nil
+		},
+		unset: func(s *Settings) { s.Repos = nil },
+	},
+	{
+		name: "format",
+		get:  func(s *Settings) (string, bool) { return s.Format, s.Format != "" },
+		set: func(s *Settings, v string) error {
+			if _, err := output.ParseFormat(v); err != nil {
+				return err
+			}
+			s.Format = v
+			return nil
+		},
+		unset: func(s *Settings) { s.Format = "" },
+	},
+	{
+		name: "editor",
+		get:  func(s *Settings) (string, bool) { return s.Editor, s.Editor != "" },
+		set: func(s *Settings, v string) error {
+			s.Editor = v
+			return nil
+		},
+		unset: func(s *Settings) { s.Editor = "" },
+	},
+	{
+		name: "history",
+		get: func(s *Settings) (string, bool) {
+			if s.History == nil {
+				return "", false
+			}
+			return strconv.FormatBool(*s.History), true
+		},
+		set: func(s *Settings, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q", v)
+			}
+			s.History = &b
+			return nil
+		},
+		unset: func(s *Settings) { s.History = nil },
+	},
+	{
+		name: "assets",
+		get: func(s *Settings) (string, bool) {
+			if s.Assets == nil {
+				return "", false
+			}
+			return strconv.FormatBool(*s.Assets), true
+		},
+		set: func(s *Settings, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q", v)
+			}
+			s.Assets = &b
+			return nil
+		},
+		unset: func(s *Settings) { s.Assets = nil },
+	},
+	{
+		name: "prune.types",
+		get: func(s *Settings) (string, bool) {
+			if s.Prune == nil {
+				return "", false
+			}
+			return joinList(s.Prune.Types)
+		},
+		set: func(s *Settings, v string) error {
+			types, err := parseTypes(v)
+			if err != nil {
+				return err
+			}
+			s.prunePolicy().Types = types
+			return nil
+		},
+		unset: func(s *Settings) {
+			if s.Prune != nil {
+				s.Prune.Types = nil
+			}
+		},
+	},
+	{
+		name: "prune.closed_days",
+		get: func(s *Settings) (string, bool) {
+			if s.Prune == nil || s.Prune.ClosedDays == nil {
+				return "", false
+			}
+			return strconv.Itoa(*s.Prune.ClosedDays), true
+		},
+		set: func(s *Settings, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid number of days %q (expected a non-negative integer)", v)
+			}
+			s.prunePolicy().ClosedDays = &n
+			return nil
+		},
+		unset: func(s *Settings) {
+			if s.Prune != nil {
+				s.Prune.ClosedDays = nil
+			}
+		},
+	},
+	{
+		name: "prune.stale_months",
+		get: func(s *Settings) (string, bool) {
+			if s.Prune == nil || s.Prune.StaleMonths == nil {
+				return "", false
+			}
+			return strconv.Itoa(*s.Prune.StaleMonths), true
+		},
+		set: func(s *Settings, v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid number of months %q (expected a non-negative integer)", v)
+			}
+			s.prunePolicy().StaleMonths = &n
+			return nil
+		},
+		unset: func(s *Settings) {
+			if s.Prune != nil {
+				s.Prune.StaleMonths = nil
+			}
+		},
+	},
+	{
+		name: "prune.keep_labels",
+		get: func(s *Settings) (string, bool) {
+			if s.Prune == nil {
+				return "", false
+			}
+			return joinList(s.Prune.KeepLabels)
+		},
+		set: func(s *Settings, v string) error {
+			s.prunePolicy().KeepLabels = splitList(v)
+			return nil
+		},
+		unset: func(s *Settings) {
+			if s.Prune != nil {
+				s.Prune.KeepLabels = nil
+			}
+		},
+	},
+	{
+		name: "prune.keep_assigned",
+		get: func(s *Settings) (string, bool) {
+			if s.Prune == nil || s.Prune.KeepAssigned == nil {
+				return "", false
+			}
+			return strconv.FormatBool(*s.Prune.KeepAssigned), true
+		},
+		set: func(s *Settings, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q", v)
+			}
+			s.prunePolicy().KeepAssigned = &b
+			return nil
+		},
+		unset: func(s *Settings) {
+			if s.Prune != nil {
+				s.Prune.KeepAssigned = nil
+			}
+		},
+	},
+	{
+		name: "prune.keep_pinned",
+		get: func(s *Settings) (string, bool) {
+			if s.Prune == nil || s.Prune.KeepPinned == nil {
+				return "", false
+			}
+			return strconv.FormatBool(*s.Prune.KeepPinned), true
+		},
+		set: func(s *Settings, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q", v)
+			}
+			s.prunePolicy().KeepPinned = &b
+			return nil
+		},
+		unset: func(s *Settings) {
+			if s.Prune != nil {
+				s.Prune.KeepPinned = nil
+			}
+		},
+	},
+}
+
+// SettingKeys returns all configurable key names in display order.
+func SettingKeys() []string {
+	names := make([]string, len(settingKeys))
+	for i, k := range settingKeys {
+		names[i] = k.name
+	}
+	return names
+}
+
+// Get returns the string value for a key and whether it is set.
+func (s *Settings) Get(key string) (string, bool, error) {
+	k, ok := lookupKey(key)
+	if !ok {
+		return "", false, unknownKeyError(key)
+	}
+	v, set := k.get(s)
+	return v, set, nil
+}
+
+// Set parses and stores the value for a key. An empty value unsets the key.
+func (s *Settings) Set(key, value string) error {
+	k, ok := lookupKey(key)
+	if !ok {
+		return unknownKeyError(key)
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		k.unset(s)
+		s.compact()
+		return nil
+	}
+	if err := k.set(s, value); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// compact drops empty nested sections so they are omitted when saved.
+func (s *Settings) compact() {
+	if s.Prune != nil && s.Prune.isEmpty() {
+		s.Prune = nil
+	}
+}
+
+func (p *PrunePolicy) isEmpty() bool {
+	return p.Types == nil && p.ClosedDays == nil && p.StaleMonths == nil &&
+		p.KeepLabels == nil && p.KeepAssigned == nil && p.KeepPinned == nil
+}
+
+// prunePolicy returns s.Prune, creating it if needed.
+func (s *Settings) prunePolicy() *PrunePolicy {
+	if s.Prune == nil {
+		s.Prune = &PrunePolicy{}
+	}
+	return s.Prune
+}
+
+func lookupKey(key string) (settingKey, bool) {
+	for _, k := range settingKeys {
+		if k.name == key {
+			return k, true
+		}
+	}
+	return settingKey{}, false
+}
+
+func unknownKeyError(key string) error {
+	return fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(SettingKeys(), ", "))
+}
+
+func parseTypes(v string) ([]string, error) {
+	types := splitList(v)
+	for _, t := range types {
+		if !slices.Contains(AllTypes, t) {
+			return nil, fmt.Errorf("invalid item type %q (expected %s)", t, strings.Join(AllTypes, ", "))
+		}
+	}
+	return types, nil
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func joinList(values []string) (string, bool) {
+	if values == nil {
+		return "", false
+	}
+	return strings.Join(values, ","), true
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGetUserConfigPath(t *testing.T) {
+	t.Run("env var set", func(t *testing.T) {
+		t.Setenv(EnvConfigFile, "/custom/config.yaml")
+
+		got, err := GetUserConfigPath()
+		if err != nil {
+			t.Fatalf("GetUserConfigPath() error = %v", err)
+		}
+		if got != "/custom/config.yaml" {
+			t.Errorf("GetUserConfigPath() = %q, want %q", got, "/custom/config.yaml")
+		}
+	})
+
+	t.Run("xdg config home", func(t *testing.T) {
+		t.Setenv(EnvConfigFile, "")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+
+		got, err := GetUserConfigPath()
+		if err != nil {
+			t.Fatalf("GetUserConfigPath() error = %v", err)
+		}
+		want := filepath.Join("/xdg", "gh-md", "config.yaml")
+		if got != want {
+			t.Errorf("GetUserConfigPath() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestLoadAndSaveUserSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gh-md", "config.yaml")
+	t.Setenv(EnvConfigFile, path)
+
+	t.Run("file not exists", func(t *testing.T) {
+		s, err := LoadUserSettings()
+		if err != nil {
+			t.Fatalf("LoadUserSettings() error = %v", err)
+		}
+		if !s.IsEmpty() {
+			t.Errorf("LoadUserSettings() = %+v, want empty settings", s)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		s := &Settings{}
+		if err := s.Set("open_only", "true"); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if err := s.Set("types", "issues,prs"); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+
+		if err := SaveUserSettings(s); err != nil {
+			t.Fatalf("SaveUserSettings() error = %v", err)
+		}
+
+		loaded, err := LoadUserSettings()
+		if err != nil {
+			t.Fatalf("LoadUserSettings() error = %v", err)
+		}
+		if loaded.OpenOnly == nil || !*loaded.OpenOnly {
+			t.Errorf("OpenOnly = %v, want true", loaded.OpenOnly)
+		}
+		if !slices.Equal(loaded.Types, []string{"issues", "prs"}) {
+			t.Errorf("Types = %v, want [issues prs]", loaded.Types)
+		}
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		if err := os.WriteFile(path, []byte("not: valid: yaml"), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if _, err := LoadUserSettings(); err == nil {
+			t.Error("LoadUserSettings() error = nil, want error for invalid yaml")
+		}
+	})
+}
+
+func TestSettings_Merge(t *testing.T) {
+	yes := true
+	limit := 10
+
+	user := &Settings{OpenOnly: &yes, Format: "json"}
+	repo := &Settings{Limit: &limit, Types: []string{TypeIssues}}
+
+	got := DefaultSettings().Merge(user).Merge(repo)
+
+	if got.OpenOnly == nil || !*got.OpenOnly {
+		t.Errorf("OpenOnly = %v, want true (from user)", got.OpenOnly)
+	}
+	if got.Limit == nil || *got.Limit != 10 {
+		t.Errorf("Limit = %v, want 10 (from repo)", got.Limit)
+	}
+	if got.Format != "json" {
+		t.Errorf("Format = %q, want json (from user)", got.Format)
+	}
+	if !slices.Equal(got.Types, []string{TypeIssues}) {
+		t.Errorf("Types = %v, want [issues] (from repo)", got.Types)
+	}
+	if !got.PruneIncludesType(TypeDiscussions) {
+		t.Error("PruneIncludesType(discussions) = false, want true (from default)")
+	}
+	if got.HistoryEnabled() {
+		t.Error("HistoryEnabled() = true, want false (from default)")
+	}
+	if !DefaultSettings().Merge(&Settings{History: &yes}).HistoryEnabled() {
+		t.Error("HistoryEnabled() = false, want true when set")
+	}
+
+	// Merging must not mutate the receiver
+	if user.Limit != nil {
+		t.Error("Merge() mutated the receiver")
+	}
+}
+
+func TestSettings_IncludesType(t *testing.T) {
+	s := &Settings{Types: []string{TypeIssues}}
+	if !s.IncludesType(TypeIssues) {
+		t.Error("IncludesType(issues) = false, want true")
+	}
+	if s.IncludesType(TypePRs) {
+		t.Error("IncludesType(prs) = true, want false")
+	}
+
+	empty := &Settings{}
+	if !empty.IncludesType(TypeDiscussions) {
+		t.Error("IncludesType(discussions) on empty settings = false, want true")
+	}
+}
+
+func TestSettings_SetGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    string
+		wantSet bool
+		wantErr bool
+	}{
+		{name: "bool", key: "open_only", value: "true", want: "true", wantSet: true},
+		{name: "int", key: "limit", value: "25", want: "25", wantSet: true},
+		{name: "list", key: "labels", value: "bug, help wanted", want: "bug,help wanted", wantSet: true},
+		{name: "nested", key: "prune.types", value: "issues", want: "issues", wantSet: true},
+		{name: "nested int", key: "prune.closed_days", value: "30", want: "30", wantSet: true},
+		{name: "nested list", key: "prune.keep_labels", value: "pinned, keep", want: "pinned,keep", wantSet: true},
+		{name: "nested bool", key: "prune.keep_assigned", value: "true", want: "true", wantSet: true},
+		{name: "negative months", key: "prune.stale_months", value: "-2", wantErr: true},
+		{name: "string", key: "editor", value: "code --wait", want: "code --wait", wantSet: true},
+		{name: "empty unsets", key: "editor", value: "", want: "", wantSet: false},
+		{name: "invalid bool", key: "open_only", value: "maybe", wantErr: true},
+		{name: "negative limit", key: "limit", value: "-1", wantErr: true},
+		{name: "invalid type", key: "types", value: "issues,wikis", wantErr: true},
+		{name: "invalid repo", key: "repos", value: "just-a-name", wantErr: true},
+		{name: "unknown key", key: "nope", value: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Settings{}
+			err := s.Set(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, ok, err := s.Get(tt.key)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if ok != tt.wantSet {
+				t.Errorf("Get() set = %v, want %v", ok, tt.wantSet)
+			}
+			if got != tt.want {
+				t.Errorf("Get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettings_SetInvalidKeepsPreviousValue(t *testing.T) {
+	s := &Settings{}
+	if err := s.Set("limit", "5"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := s.Set("limit", "abc"); err == nil {
+		t.Fatal("Set() error = nil, want error")
+	}
+	if s.Limit == nil || *s.Limit != 5 {
+		t.Errorf("Limit = %v, want 5", s.Limit)
+	}
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// View is a named filter for browsing local files.
+type View struct {
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Filter      string   `yaml:"filter" json:"filter"`                 // CEL expression
+	Sort        string   `yaml:"sort,omitempty" json:"sort,omitempty"` // One of search.SortFields
+	Types       []string `yaml:"types,omitempty" json:"types,omitempty"`
+	Repo        string   `yaml:"repo,omitempty" json:"repo,omitempty"` // owner/repo, empty = all repos
+}
+
+// builtinViews are available without being saved. A saved view with the same
+// name takes precedence.
+var builtinViews = map[string]*View{
+	"waiting-on-me": {
+		Description: "Open items you're involved in where someone else spoke last",
+		Filter:      `state == "open" && (author == user || user in assigned || user in reviewers) && last_commenter != "" && last_commenter != user`,
+	},
+	"review-requested": {
+		Description: "Open pull requests awaiting your review",
+		Filter:      `state == "open" && user in reviewers`,
+		Types:       []string{TypePRs},
+	},
+	"mentioned": {
+		Description: "Open items mentioning you in the body or a comment",
+		Filter:      `state == "open" && (body.contains("@" + user) || comments.exists(c, c.body.contains("@" + user)))`,
+	},
+	"stale": {
+		Description: "Open items without activity for 30 days",
+		Filter:      `state == "open" && updated < now - duration("720h")`,
+	},
+}
+
+// viewNamePattern restricts view names to something safe to type and store.
+var viewNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateViewName checks that a view name is well-formed and not reserved.
+func ValidateViewName(name string, reserved []string) error {
+	if !viewNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid view name %q (use lowercase letters, digits, '-' and '_')", name)
+	}
+	if slices.Contains(reserved, name) {
+		return fmt.Errorf("view name %q is reserved", name)
+	}
+	return nil
+}
+
+// Validate checks the item types and repository of a view.
+// The filter expression is compiled, and the sort order parsed, by the caller.
+func (v *View) Validate() error {
+	if strings.TrimSpace(v.Filter) == "" {
+		return fmt.Errorf("view filter is empty")
+	}
+	for _, t := range v.Types {
+		if !slices.Contains(AllTypes, t) {
+			return fmt.Errorf("invalid item type %q (expected %s)", t, strings.Join(AllTypes, ", "))
+		}
+	}
+	if v.Repo != "" {
+		if parts := strings.Split(v.Repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid repository %q (expected owner/repo)", v.Repo)
+		}
+	}
+	return nil
+}
+
+// NamedView is a view with its name and where it is defined.
+type NamedView struct {
+	Name    string `yaml:"name" json:"name"`
+	Builtin bool   `yaml:"builtin" json:"builtin"`
+	View    `yaml:",inline"`
+}
+
+// LookupView returns a saved view from the user settings, or a built-in view.
+func LookupView(user *Settings, name string) (*View, bool) {
+	if v, ok := user.Views[name]; ok {
+		return v, true
+	}
+	v, ok := builtinViews[name]
+	return v, ok
+}
+
+// IsBuiltinView reports whether name is a built-in view.
+func IsBuiltinView(name string) bool {
+	_, ok := builtinViews[name]
+	return ok
+}
+
+// ListViews returns saved and built-in views sorted by name. Built-in views
+// shadowed by a saved view of the same name are omitted.
+func ListViews(user *Settings) []NamedView {
+	var views []NamedView
+	for name, v := range user.Views {
+		views = append(views, NamedView{Name: name, View: *v})
+	}
+	for name, v := range builtinViews {
+		if _, ok := user.Views[name]; !ok {
+			views = append(views, NamedView{Name: name, Builtin: true, View: *v})
+		}
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestValidateViewName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"needs-review", false},
+		{"my_view2", false},
+		{"", true},
+		{"Has Space", true},
+		{"-leading", true},
+		{"list", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateViewName(tt.name, []string{"list"}); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateViewName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestView_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    View
+		wantErr bool
+	}{
+		{"minimal", View{Filter: "true"}, false},
+		{"full", View{Filter: "true", Sort: "comments", Types: []string{TypePRs}, Repo: "o/r"}, false},
+		{"empty filter", View{Filter: " "}, true},
+		{"bad type", View{Filter: "true", Types: []string{"pulls"}}, true},
+		{"bad repo", View{Filter: "true", Repo: "o"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.view.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLookupAndListViews(t *testing.T) {
+	user := &Settings{Views: map[string]*View{
+		"stale": {Filter: `state == "closed"`},
+		"mine":  {Filter: "author == user"},
+	}}
+
+	if v, ok := LookupView(user, "stale"); !ok || v.Filter != `state == "closed"` {
+		t.Errorf("saved view should shadow the built-in, got %+v", v)
+	}
+	if _, ok := LookupView(user, "waiting-on-me"); !ok {
+		t.Error("built-in view not found")
+	}
+	if _, ok := LookupView(&Settings{}, "missing"); ok {
+		t.Error("unknown view found")
+	}
+
+	views := ListViews(user)
+	if len(views) != len(builtinViews)+1 {
+		t.Fatalf("ListViews() returned %d views, want %d", len(views), len(builtinViews)+1)
+	}
+	for i, v := range views {
+		if i > 0 && views[i-1].Name >= v.Name {
+			t.Errorf("ListViews() not sorted: %s before %s", views[i-1].Name, v.Name)
+		}
+		if v.Name == "stale" && v.Builtin {
+			t.Error("shadowed built-in view listed")
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+// OpKind is the kind of a line in an edit script.
+type OpKind int
+
+const (
+	OpEqual OpKind = iota
+	OpDelete
+	OpInsert
+)
+
+// Op is a single line in an edit script.
+type Op struct {
+	Kind OpKind
+	Line string
+}
+
+// Lines computes a minimal line edit script turning a into b (Myers' algorithm).
+func Lines(a, b []string) []Op {
+	// Common prefix and suffix are trimmed first; they are usually most of a file.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []Op
+	for _, line := range a[:prefix] {
+		ops = append(ops, Op{Kind: OpEqual, Line: line})
+	}
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, Op{Kind: OpEqual, Line: line})
+	}
+	return ops
+}
+
+// myers returns the shortest edit script between a and b, using the linear
+// space variant of Myers' algorithm.
+func myers(a, b []string) []Op {
+	var ops []Op
+	diffInto(&ops, a, b)
+	return ops
+}
+
+// diffInto appends the shortest edit script between a and b to ops. The middle
+// snake of the edit path splits it into two halves that are diffed recursively,
+// so only two V arrays are kept instead of one per edit.
+func diffInto(ops *[]Op, a, b []string) {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		*ops = append(*ops, Op{Kind: OpEqual, Line: a[0]})
+		a, b = a[1:], b[1:]
+	}
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	tail := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			*ops = append(*ops, Op{Kind: OpInsert, Line: line})
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			*ops = append(*ops, Op{Kind: OpDelete, Line: line})
+		}
+	default:
+		// With the common prefix and suffix gone, at least two edits remain,
+		// so both halves are smaller problems.
+		x, y, u, v := middleSnake(a, b)
+		diffInto(ops, a[:x], b[:y])
+		for _, line := range a[x:u] {
+			*ops = append(*ops, Op{Kind: OpEqual, Line: line})
+		}
+		diffInto(ops, a[u:], b[v:])
+	}
+
+	for _, line := range tail {
+		*ops = append(*ops, Op{Kind: OpEqual, Line: line})
+	}
+}
+
+// middleSnake searches for the shortest edit path from both ends at once and
+// returns the snake (diagonal run of equal lines) where the searches meet: it
+// goes from (x, y) to (u, v). vf holds the furthest x reached forward on each
+// diagonal k = x-y; vb the furthest distance reached backward from the end, on
+// diagonals of the reversed inputs.
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	vf := make([]int, 2*offset+1)
+	vb := make([]int, 2*offset+1)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x0 int
+			if k == -d || (k != d && vf[offset+k-1] < vf[offset+k+1]) {
+				x0 = vf[offset+k+1] // Move down (insert)
+			} else {
+				x0 = vf[offset+k-1] + 1 // Move right (delete)
+			}
+			y0 := x0 - k
+			x1, y1 := x0, y0
+			for x1 < n && y1 < m && a[x1] == b[y1] {
+				x1++
+				y1++
+			}
+			vf[offset+k] = x1
+			// Backward paths of d-1 edits are on diagonals delta-(d-1)..delta+(d-1)
+			if odd && k >= delta-(d-1) && k <= delta+(d-1) && x1+vb[offset+delta-k] >= n {
+				return x0, y0, x1, y1
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			var x0 int
+			if k == -d || (k != d && vb[offset+k-1] < vb[offset+k+1]) {
+				x0 = vb[offset+k+1]
+			} else {
+				x0 = vb[offset+k-1] + 1
+			}
+			y0 := x0 - k
+			x1, y1 := x0, y0
+			for x1 < n && y1 < m && a[n-1-x1] == b[m-1-y1] {
+				x1++
+				y1++
+			}
+			vb[offset+k] = x1
+			// Forward paths of d edits are on diagonals -d..d
+			if !odd && delta-k >= -d && delta-k <= d && vf[offset+delta-k]+x1 >= n {
+				return n - x1, m - y1, n - x0, m - y0
+			}
+		}
+	}
+
+	return 0, 0, 0, 0 // Unreachable: the searches always meet
+}
+
+// Unified returns a unified diff between a and b with the given number of context
+// lines, or "" if they are equal.
+func Unified(aName, bName, a, b string, context int) string {
+	ops := Lines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	hunks := buildHunks(ops, context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	fmt.Fprintf(&sb, "--- %s\n", aName)
+	fmt.Fprintf(&sb, "+++ %s\n", bName)
+	for _, h := range hunks {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(h.aStart, h.aLen), hunkRange(h.bStart, h.bLen))
+		for _, op := range h.ops {
+			switch op.Kind {
+			case OpEqual:
+				sb.WriteString(" ")
+			case OpDelete:
+				sb.WriteString("-")
+			case OpInsert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(op.Line)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+type hunk struct {
+	aStart, aLen int
+	bStart, bLen int
+	ops          []Op
+}
+
+// buildHunks groups changes that are within 2*context lines of each other.
+func buildHunks(ops []Op, context int) []hunk {
+	var hunks []hunk
+
+	// Line numbers (0-based) in a and b at each op
+	aLine, bLine := make([]int, len(ops)), make([]int, len(ops))
+	ai, bi := 0, 0
+	for i, op := range ops {
+		aLine[i], bLine[i] = ai, bi
+		if op.Kind != OpInsert {
+			ai++
+		}
+		if op.Kind != OpDelete {
+			bi++
+		}
+	}
+
+	i := 0
+	for i < len(ops) {
+		if ops[i].Kind == OpEqual {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].Kind != OpEqual {
+				end++
+				continue
+			}
+			// Count the run of equal lines
+			run := end
+			for run < len(ops) && ops[run].Kind == OpEqual {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = run
+		}
+
+		h := hunk{aStart: aLine[start], bStart: bLine[start], ops: ops[start:end]}
+		for _, op := range h.ops {
+			if op.Kind != OpInsert {
+				h.aLen++
+			}
+			if op.Kind != OpDelete {
+				h.bLen++
+			}
+		}
+		hunks = append(hunks, h)
+		i = end
+	}
+
+	return hunks
+}
+
+// hunkRange formats a hunk range header ("start,len", 1-based).
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,118 @@
+package diff
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{
+			name: "equal",
+			a:    "one\ntwo\n",
+			b:    "one\ntwo\n",
+			want: "",
+		},
+		{
+			name: "single change",
+			a:    "one\ntwo\nthree\n",
+			b:    "one\n2\nthree\n",
+			want: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			name: "insert into empty",
+			a:    "",
+			b:    "new\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1 @@\n+new\n",
+		},
+		{
+			name: "separate hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			b:    "1\nX\n3\n4\n5\n6\n7\n8\n9\n10\nY\n12\n",
+			want: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+X\n 3\n 4\n 5\n" +
+				"@@ -8,5 +8,5 @@\n 8\n 9\n 10\n-11\n+Y\n 12\n",
+		},
+		{
+			name: "nearby changes merge",
+			a:    "1\n2\n3\n4\n5\n",
+			b:    "1\nX\n3\nY\n5\n",
+			want: "--- a\n+++ b\n@@ -1,5 +1,5 @@\n 1\n-2\n+X\n 3\n-4\n+Y\n 5\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("a", "b", tt.a, tt.b, DefaultContext)
+			if got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLines_Reconstructs checks that applying the edit script to a yields b.
+func TestLines_Reconstructs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rng.Intn(20))
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randomLines(), randomLines()
+		var gotA, gotB []string
+		for _, op := range Lines(a, b) {
+			if op.Kind != OpInsert {
+				gotA = append(gotA, op.Line)
+			}
+			if op.Kind != OpDelete {
+				gotB = append(gotB, op.Line)
+			}
+		}
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("edit script does not reconstruct inputs: a=%v b=%v", a, b)
+		}
+		if equal := len(a) - countOps(Lines(a, b), OpDelete); equal != lcs(a, b) {
+			t.Fatalf("edit script keeps %d lines, want %d: a=%v b=%v", equal, lcs(a, b), a, b)
+		}
+	}
+}
+
+func countOps(ops []Op, kind OpKind) int {
+	n := 0
+	for _, op := range ops {
+		if op.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// lcs returns the length of the longest common subsequence of a and b, which a
+// shortest edit script keeps unchanged.
+func lcs(a, b []string) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+	return dp[0][0]
+}
@@ -64,6 +64,11 @@ func DiscoverManagedRepos() ([]ManagedRepo, error) {
 			return nil // Skip inaccessible paths
 		}
 
+		// Skip hidden directories (history, backups, internal state)
+		if d.IsDir() && path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
 		// Only interested in .gh-md-meta.yaml files
 		if d.IsDir() || d.Name() != metaFile {
 			return nil
@@ -9,8 +9,12 @@ import (
 	"strings"
 )
 
-func OpenInEditor(filePath string) error {
-	editor := os.Getenv("EDITOR")
+// OpenInEditor opens one or more files with the given editor command.
+// An empty editor falls back to $EDITOR, then to vim (notepad on Windows).
+func OpenInEditor(editor string, filePaths ...string) error {
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
 	if editor == "" {
 		if runtime.GOOS == "windows" {
 			editor = "notepad"
@@ -28,7 +32,7 @@ func OpenInEditor(filePath string) error {
 	}
 
 	editorCmd := editorArgs[0]
-	editorArgs = append(editorArgs[1:], filePath)
+	editorArgs = append(editorArgs[1:], filePaths...)
 
 	cmd := exec.Command(editorCmd, editorArgs...)
 	cmd.Stdin = os.Stdin
@@ -24,6 +24,10 @@ query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [D
         locked
         createdAt
         updatedAt
+        closedAt
+        reactions {
+          totalCount
+        }
         category {
           name
         }
@@ -74,6 +78,10 @@ query($owner: String!, $repo: String!, $number: Int!) {
       locked
       createdAt
       updatedAt
+      closedAt
+      reactions {
+        totalCount
+      }
       category {
         name
       }
@@ -141,6 +149,8 @@ type DiscussionNode struct {
 	Locked    bool      `json:"locked"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	ClosedAt  time.Time `json:"closedAt"`
+	Reactions CountNode `json:"reactions"`
 	Category  struct {
 		Name string `json:"name"`
 	} `json:"category"`
@@ -300,6 +310,8 @@ func nodeToDiscussion(node DiscussionNode, owner, repo string) *Discussion {
 		Locked:    node.Locked,
 		CreatedAt: node.CreatedAt,
 		UpdatedAt: node.UpdatedAt,
+		ClosedAt:  node.ClosedAt,
+		Reactions: node.Reactions.TotalCount,
 		Comments:  comments,
 	}
 }
@@ -5,6 +5,11 @@ type LabelNode struct {
 	Name string `json:"name"`
 }
 
+// CountNode represents a connection of which only the total count is queried.
+type CountNode struct {
+	TotalCount int `json:"totalCount"`
+}
+
 // AssigneeNode represents an assignee in the GraphQL response.
 type AssigneeNode struct {
 	Login string `json:"login"`
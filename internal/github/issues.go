@@ -6,9 +6,9 @@ import (
 )
 
 const issuesQuery = `
-query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [IssueState!]) {
+query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [IssueState!], $labels: [String!]) {
   repository(owner: $owner, name: $repo) {
-    issues(first: $first, after: $after, states: $states, orderBy: {field: UPDATED_AT, direction: DESC}) {
+    issues(first: $first, after: $after, states: $states, labels: $labels, orderBy: {field: UPDATED_AT, direction: DESC}) {
       pageInfo {
         hasNextPage
         endCursor
@@ -25,6 +25,11 @@ query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [I
         }
         createdAt
         updatedAt
+        closedAt
+        isPinned
+        reactions {
+          totalCount
+        }
         labels(first: 100) {
           nodes {
             name
@@ -96,6 +101,11 @@ query($owner: String!, $repo: String!, $number: Int!) {
       }
       createdAt
       updatedAt
+      closedAt
+      isPinned
+      reactions {
+        totalCount
+      }
       labels(first: 100) {
         nodes {
           name
@@ -221,6 +231,9 @@ type IssueNode struct {
 	} `json:"author"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	ClosedAt  time.Time `json:"closedAt"`
+	IsPinned  bool      `json:"isPinned"`
+	Reactions CountNode `json:"reactions"`
 	Labels    struct {
 		Nodes []LabelNode `json:"nodes"`
 	} `json:"labels"`
@@ -263,9 +276,10 @@ func (c *Client) FetchIssue(owner, repo string, number int) (*Issue, error) {
 
 // FetchIssues fetches all issues from a repository with pagination.
 // If openOnly is true, only OPEN issues are fetched; otherwise all states are fetched.
+// If labels is non-empty, only items carrying at least one of them are fetched.
 // If since is provided, fetching stops when encountering items older than the timestamp.
 // If progress is non-nil, it's called after each page with the current count.
-func (c *Client) FetchIssues(owner, repo string, limit int, openOnly bool, since *time.Time, progress ProgressFunc) ([]Issue, error) {
+func (c *Client) FetchIssues(owner, repo string, limit int, openOnly bool, labels []string, since *time.Time, progress ProgressFunc) ([]Issue, error) {
 	var issues []Issue
 	var cursor *string
 	pageSize := 100
@@ -287,6 +301,9 @@ func (c *Client) FetchIssues(owner, repo string, limit int, openOnly bool, since
 			"first":  pageSize,
 			"states": states,
 		}
+		if len(labels) > 0 {
+			vars["labels"] = labels
+		}
 		if cursor != nil {
 			vars["after"] = *cursor
 		}
@@ -352,6 +369,9 @@ func nodeToIssue(node IssueNode, owner, repo string) *Issue {
 		Assignees: assignees,
 		CreatedAt: node.CreatedAt,
 		UpdatedAt: node.UpdatedAt,
+		ClosedAt:  node.ClosedAt,
+		Pinned:    node.IsPinned,
+		Reactions: node.Reactions.TotalCount,
 		Comments:  comments,
 	}
 
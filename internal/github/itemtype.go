@@ -58,3 +58,18 @@ func (t ItemType) ListLabel() (string, bool) {
 		return "", false
 	}
 }
+
+// FlagName returns the name used for an item type in command flags and settings
+// (--issues, --prs, --discussions).
+func (t ItemType) FlagName() (string, bool) {
+	switch t {
+	case ItemTypeIssue:
+		return "issues", true
+	case ItemTypePullRequest:
+		return "prs", true
+	case ItemTypeDiscussion:
+		return "discussions", true
+	default:
+		return "", false
+	}
+}
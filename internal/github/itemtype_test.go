@@ -156,3 +156,29 @@ func TestItemType_DisplayPlural(t *testing.T) {
 		})
 	}
 }
+
+func TestItemType_FlagName(t *testing.T) {
+	tests := []struct {
+		name   string
+		t      ItemType
+		want   string
+		wantOK bool
+	}{
+		{name: "issue", t: ItemTypeIssue, want: "issues", wantOK: true},
+		{name: "pull request", t: ItemTypePullRequest, want: "prs", wantOK: true},
+		{name: "discussion", t: ItemTypeDiscussion, want: "discussions", wantOK: true},
+		{name: "invalid", t: ItemType("invalid"), want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.t.FlagName()
+			if ok != tt.wantOK {
+				t.Errorf("ItemType(%q).FlagName() ok = %v, want %v", tt.t, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ItemType(%q).FlagName() = %q, want %q", tt.t, got, tt.want)
+			}
+		})
+	}
+}
@@ -61,6 +61,52 @@ mutation($id: ID!) {
     pullRequest { id state }
   }
 }
+`
+
+	closeDiscussionMutation = `
+mutation($id: ID!) {
+  closeDiscussion(input: {discussionId: $id}) {
+    discussion { id closed }
+  }
+}
+`
+
+	addLabelsMutation = `
+mutation($id: ID!, $labelIds: [ID!]!) {
+  addLabelsToLabelable(input: {labelableId: $id, labelIds: $labelIds}) {
+    clientMutationId
+  }
+}
+`
+
+	removeLabelsMutation = `
+mutation($id: ID!, $labelIds: [ID!]!) {
+  removeLabelsFromLabelable(input: {labelableId: $id, labelIds: $labelIds}) {
+    clientMutationId
+  }
+}
+`
+
+	addAssigneesMutation = `
+mutation($id: ID!, $assigneeIds: [ID!]!) {
+  addAssigneesToAssignable(input: {assignableId: $id, assigneeIds: $assigneeIds}) {
+    clientMutationId
+  }
+}
+`
+
+	fetchLabelQuery = `
+query($owner: String!, $repo: String!, $name: String!) {
+  repository(owner: $owner, name: $repo) {
+    label(name: $name) { id }
+  }
+}
+`
+
+	fetchViewerQuery = `
+query {
+  viewer { id login }
+}
 `
 
 	addCommentMutation = `
@@ -359,6 +405,115 @@ func (c *Client) ReopenPullRequest(id string) error {
 	return nil
 }
 
+// CloseDiscussion closes a discussion.
+func (c *Client) CloseDiscussion(id string) error {
+	vars := map[string]any{
+		"id": id,
+	}
+
+	var resp struct {
+		CloseDiscussion struct {
+			Discussion struct {
+				ID     string `json:"id"`
+				Closed bool   `json:"closed"`
+			} `json:"discussion"`
+		} `json:"closeDiscussion"`
+	}
+
+	if err := c.Query(closeDiscussionMutation, vars, &resp); err != nil {
+		return fmt.Errorf("failed to close discussion: %w", err)
+	}
+
+	return nil
+}
+
+// LabelID returns the node ID of the label with the given name in a repository.
+func (c *Client) LabelID(owner, repo, name string) (string, error) {
+	vars := map[string]any{
+		"owner": owner,
+		"repo":  repo,
+		"name":  name,
+	}
+
+	var resp struct {
+		Repository struct {
+			Label *struct {
+				ID string `json:"id"`
+			} `json:"label"`
+		} `json:"repository"`
+	}
+
+	if err := c.Query(fetchLabelQuery, vars, &resp); err != nil {
+		return "", fmt.Errorf("failed to fetch label: %w", err)
+	}
+	if resp.Repository.Label == nil {
+		return "", fmt.Errorf("label %q not found in %s/%s", name, owner, repo)
+	}
+
+	return resp.Repository.Label.ID, nil
+}
+
+// AddLabels adds labels to an issue, pull request or discussion.
+func (c *Client) AddLabels(id string, labelIDs []string) error {
+	vars := map[string]any{
+		"id":       id,
+		"labelIds": labelIDs,
+	}
+
+	var resp struct{}
+	if err := c.Query(addLabelsMutation, vars, &resp); err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveLabels removes labels from an issue, pull request or discussion.
+func (c *Client) RemoveLabels(id string, labelIDs []string) error {
+	vars := map[string]any{
+		"id":       id,
+		"labelIds": labelIDs,
+	}
+
+	var resp struct{}
+	if err := c.Query(removeLabelsMutation, vars, &resp); err != nil {
+		return fmt.Errorf("failed to remove labels: %w", err)
+	}
+
+	return nil
+}
+
+// AddAssignees assigns users to an issue or pull request.
+func (c *Client) AddAssignees(id string, userIDs []string) error {
+	vars := map[string]any{
+		"id":          id,
+		"assigneeIds": userIDs,
+	}
+
+	var resp struct{}
+	if err := c.Query(addAssigneesMutation, vars, &resp); err != nil {
+		return fmt.Errorf("failed to add assignees: %w", err)
+	}
+
+	return nil
+}
+
+// ViewerID returns the node ID of the authenticated user.
+func (c *Client) ViewerID() (string, error) {
+	var resp struct {
+		Viewer struct {
+			ID    string `json:"id"`
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+
+	if err := c.Query(fetchViewerQuery, nil, &resp); err != nil {
+		return "", fmt.Errorf("failed to fetch viewer: %w", err)
+	}
+
+	return resp.Viewer.ID, nil
+}
+
 // RemoteState holds the remote item's current state info.
 type RemoteState struct {
 	UpdatedAt time.Time
@@ -429,8 +584,8 @@ func (c *Client) FetchRemoteState(itemType ItemType, owner, repo string, number
 	}
 }
 
-// AddComment adds a new comment to an issue or PR.
-func (c *Client) AddComment(subjectID, body string) error {
+// AddComment adds a new comment to an issue or PR and returns its ID.
+func (c *Client) AddComment(subjectID, body string) (string, error) {
 	vars := map[string]any{
 		"subjectId": subjectID,
 		"body":      body,
@@ -447,10 +602,10 @@ func (c *Client) AddComment(subjectID, body string) error {
 	}
 
 	if err := c.Query(addCommentMutation, vars, &resp); err != nil {
-		return fmt.Errorf("failed to add comment: %w", err)
+		return "", fmt.Errorf("failed to add comment: %w", err)
 	}
 
-	return nil
+	return resp.AddComment.CommentEdge.Node.ID, nil
 }
 
 // UpdateIssueComment updates an existing comment on an issue or PR.
@@ -475,8 +630,8 @@ func (c *Client) UpdateIssueComment(commentID, body string) error {
 	return nil
 }
 
-// AddDiscussionComment adds a new comment to a discussion.
-func (c *Client) AddDiscussionComment(discussionID, body string) error {
+// AddDiscussionComment adds a new comment to a discussion and returns its ID.
+func (c *Client) AddDiscussionComment(discussionID, body string) (string, error) {
 	vars := map[string]any{
 		"discussionId": discussionID,
 		"body":         body,
@@ -491,10 +646,10 @@ func (c *Client) AddDiscussionComment(discussionID, body string) error {
 	}
 
 	if err := c.Query(addDiscussionCommentMutation, vars, &resp); err != nil {
-		return fmt.Errorf("failed to add discussion comment: %w", err)
+		return "", fmt.Errorf("failed to add discussion comment: %w", err)
 	}
 
-	return nil
+	return resp.AddDiscussionComment.Comment.ID, nil
 }
 
 // UpdateDiscussionComment updates an existing discussion comment.
@@ -519,8 +674,8 @@ func (c *Client) UpdateDiscussionComment(commentID, body string) error {
 	return nil
 }
 
-// AddDiscussionCommentReply adds a reply to a discussion comment.
-func (c *Client) AddDiscussionCommentReply(replyToID, body string) error {
+// AddDiscussionCommentReply adds a reply to a discussion comment and returns its ID.
+func (c *Client) AddDiscussionCommentReply(replyToID, body string) (string, error) {
 	vars := map[string]any{
 		"replyToId": replyToID,
 		"body":      body,
@@ -535,14 +690,14 @@ func (c *Client) AddDiscussionCommentReply(replyToID, body string) error {
 	}
 
 	if err := c.Query(addDiscussionCommentReplyMutation, vars, &resp); err != nil {
-		return fmt.Errorf("failed to add discussion reply: %w", err)
+		return "", fmt.Errorf("failed to add discussion reply: %w", err)
 	}
 
-	return nil
+	return resp.AddDiscussionComment.Comment.ID, nil
 }
 
-// AddReviewThreadReply adds a reply to a PR review thread.
-func (c *Client) AddReviewThreadReply(threadID, body string) error {
+// AddReviewThreadReply adds a reply to a PR review thread and returns its ID.
+func (c *Client) AddReviewThreadReply(threadID, body string) (string, error) {
 	vars := map[string]any{
 		"threadId": threadID,
 		"body":     body,
@@ -557,10 +712,10 @@ func (c *Client) AddReviewThreadReply(threadID, body string) error {
 	}
 
 	if err := c.Query(addReviewThreadReplyMutation, vars, &resp); err != nil {
-		return fmt.Errorf("failed to add review thread reply: %w", err)
+		return "", fmt.Errorf("failed to add review thread reply: %w", err)
 	}
 
-	return nil
+	return resp.AddPullRequestReviewThreadReply.Comment.ID, nil
 }
 
 // RemoteComment represents a comment fetched from GitHub for comparison.
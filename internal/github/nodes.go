@@ -0,0 +1,71 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// nodesBatchSize is the most IDs GitHub resolves in one nodes(ids:) query.
+const nodesBatchSize = 100
+
+const fetchNodeStatesQuery = `
+query($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    ... on Issue { id state closedAt }
+    ... on PullRequest { id state closedAt }
+    ... on Discussion { id closed closedAt }
+  }
+}
+`
+
+// NodeState is the current state of an issue, pull request or discussion.
+type NodeState struct {
+	State    string // "open", "closed" or "merged" (PRs only), as in frontmatter
+	ClosedAt time.Time
+}
+
+// FetchNodeStates fetches the current state of items by node ID, in batches.
+// Items that no longer exist, or can't be seen, are missing from the result.
+func (c *Client) FetchNodeStates(ids []string) (map[string]NodeState, error) {
+	states := make(map[string]NodeState, len(ids))
+	for start := 0; start < len(ids); start += nodesBatchSize {
+		batch := ids[start:min(start+nodesBatchSize, len(ids))]
+
+		var resp struct {
+			Nodes []*struct {
+				ID       string    `json:"id"`
+				State    string    `json:"state"`
+				Closed   bool      `json:"closed"`
+				ClosedAt time.Time `json:"closedAt"`
+			} `json:"nodes"`
+		}
+		if err := c.Query(fetchNodeStatesQuery, map[string]any{"ids": batch}, &resp); err != nil {
+			// Deleted items resolve to null alongside a NOT_FOUND error;
+			// the rest of the batch is still returned.
+			var gqlErr *api.GraphQLError
+			if !errors.As(err, &gqlErr) || !gqlErr.Match("NOT_FOUND", "nodes.") {
+				return nil, fmt.Errorf("failed to fetch item states: %w", err)
+			}
+		}
+
+		for _, node := range resp.Nodes {
+			if node == nil || node.ID == "" {
+				continue
+			}
+			state := strings.ToLower(node.State)
+			if state == "" {
+				// Discussions report closed instead of a state
+				state = "open"
+				if node.Closed {
+					state = "closed"
+				}
+			}
+			states[node.ID] = NodeState{State: state, ClosedAt: node.ClosedAt}
+		}
+	}
+	return states, nil
+}
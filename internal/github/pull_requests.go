@@ -9,9 +9,9 @@ import (
 // GitHub GraphQL API has a 500,000 node limit per query.
 // With nested connections, we must limit: 25 PRs × (50 comments + 50 threads × 20 nested) = 26,250 nodes
 const pullRequestsQuery = `
-query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [PullRequestState!]) {
+query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [PullRequestState!], $labels: [String!]) {
   repository(owner: $owner, name: $repo) {
-    pullRequests(first: $first, after: $after, states: $states, orderBy: {field: UPDATED_AT, direction: DESC}) {
+    pullRequests(first: $first, after: $after, states: $states, labels: $labels, orderBy: {field: UPDATED_AT, direction: DESC}) {
       pageInfo {
         hasNextPage
         endCursor
@@ -29,6 +29,10 @@ query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [P
         isDraft
         createdAt
         updatedAt
+        closedAt
+        reactions {
+          totalCount
+        }
         mergedAt
         headRefName
         baseRefName
@@ -110,6 +114,10 @@ query($owner: String!, $repo: String!, $number: Int!) {
       isDraft
       createdAt
       updatedAt
+      closedAt
+      reactions {
+        totalCount
+      }
       mergedAt
       headRefName
       baseRefName
@@ -208,6 +216,8 @@ type PullRequestNode struct {
 	IsDraft     bool      `json:"isDraft"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	ClosedAt    time.Time `json:"closedAt"`
+	Reactions   CountNode `json:"reactions"`
 	MergedAt    time.Time `json:"mergedAt"`
 	HeadRefName string    `json:"headRefName"`
 	BaseRefName string    `json:"baseRefName"`
@@ -279,9 +289,10 @@ func (c *Client) FetchPullRequest(owner, repo string, number int) (*PullRequest,
 
 // FetchPullRequests fetches all PRs from a repository with pagination.
 // If openOnly is true, only OPEN PRs are fetched; otherwise all states are fetched.
+// If labels is non-empty, only items carrying at least one of them are fetched.
 // If since is provided, fetching stops when encountering items older than the timestamp.
 // If progress is non-nil, it's called after each page with the current count.
-func (c *Client) FetchPullRequests(owner, repo string, limit int, openOnly bool, since *time.Time, progress ProgressFunc) ([]PullRequest, error) {
+func (c *Client) FetchPullRequests(owner, repo string, limit int, openOnly bool, labels []string, since *time.Time, progress ProgressFunc) ([]PullRequest, error) {
 	var prs []PullRequest
 	var cursor *string
 	pageSize := 25
@@ -303,6 +314,9 @@ func (c *Client) FetchPullRequests(owner, repo string, limit int, openOnly bool,
 			"first":  pageSize,
 			"states": states,
 		}
+		if len(labels) > 0 {
+			vars["labels"] = labels
+		}
 		if cursor != nil {
 			vars["after"] = *cursor
 		}
@@ -406,6 +420,8 @@ func nodeToPullRequest(node PullRequestNode, owner, repo string) *PullRequest {
 		MergeCommit:   node.MergeCommit.Oid,
 		CreatedAt:     node.CreatedAt,
 		UpdatedAt:     node.UpdatedAt,
+		ClosedAt:      node.ClosedAt,
+		Reactions:     node.Reactions.TotalCount,
 		MergedAt:      node.MergedAt,
 		Comments:      comments,
 		ReviewThreads: reviewThreads,
@@ -66,6 +66,9 @@ type Issue struct {
 	Assignees        []string          `json:"assignees"`
 	CreatedAt        time.Time         `json:"createdAt"`
 	UpdatedAt        time.Time         `json:"updatedAt"`
+	ClosedAt         time.Time         `json:"closedAt,omitempty"`
+	Pinned           bool              `json:"pinned,omitempty"`
+	Reactions        int               `json:"reactions"` // Reactions on the issue itself
 	Comments         []Comment         `json:"comments"`
 	Parent           *IssueReference   `json:"parent,omitempty"`
 	Children         []IssueReference  `json:"children,omitempty"`
@@ -92,6 +95,8 @@ type PullRequest struct {
 	MergeCommit   string         `json:"mergeCommit,omitempty"`
 	CreatedAt     time.Time      `json:"createdAt"`
 	UpdatedAt     time.Time      `json:"updatedAt"`
+	ClosedAt      time.Time      `json:"closedAt,omitempty"`
+	Reactions     int            `json:"reactions"` // Reactions on the pull request itself
 	MergedAt      time.Time      `json:"mergedAt,omitempty"`
 	Comments      []Comment      `json:"comments"`
 	ReviewThreads []ReviewThread `json:"reviewThreads"`
@@ -123,6 +128,8 @@ type Discussion struct {
 	Locked    bool                `json:"locked"`
 	CreatedAt time.Time           `json:"createdAt"`
 	UpdatedAt time.Time           `json:"updatedAt"`
+	ClosedAt  time.Time           `json:"closedAt,omitempty"`
+	Reactions int                 `json:"reactions"` // Reactions on the discussion itself
 	Comments  []DiscussionComment `json:"comments"`
 }
 
@@ -0,0 +1,360 @@
+package history
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
+)
+
+// Actions recorded in commit subjects.
+const (
+	ActionPull    = "pull"
+	ActionPush    = "push"
+	ActionPrune   = "prune"
+	ActionEdit    = "edit" // Local edits snapshotted before they are pushed or pulled over
+	ActionRestore = "restore"
+)
+
+// ErrNotEnabled is returned when reading history from a root that is not a git repository.
+var ErrNotEnabled = errors.New("history is not enabled (run 'gh md config set history true')")
+
+// Author identity of history commits, so they work without a git user configured.
+const (
+	authorName  = "gh-md"
+	authorEmail = "gh-md@localhost"
+)
+
+// ignoreHeader starts the block of .gitignore that gh-md manages.
+const ignoreHeader = "# Managed by gh-md"
+
+// gitignore keeps internal state out of history; only item files are tracked.
+var gitignore = strings.Join(append([]string{ignoreHeader}, config.IgnorePatterns...), "\n") + "\n"
+
+// Entry is a single commit touching an item or repository.
+type Entry struct {
+	Commit  string    `json:"commit" yaml:"commit"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Subject string    `json:"subject" yaml:"subject"`
+}
+
+// ShortCommit returns the abbreviated commit hash.
+func (e *Entry) ShortCommit() string {
+	if len(e.Commit) > 7 {
+		return e.Commit[:7]
+	}
+	return e.Commit
+}
+
+// Enabled reports whether history is enabled for a repository.
+func Enabled(owner, repo string) (bool, error) {
+	settings, err := meta.ResolveSettings(owner, repo)
+	if err != nil {
+		return false, err
+	}
+	return settings.HistoryEnabled(), nil
+}
+
+// Record commits all changes under a repository directory with a structured
+// message such as "pull acme/api: 12 issues updated". It does nothing if history
+// is disabled or nothing changed. Returns the short hash of the new commit, if any.
+func Record(action, owner, repo string) (string, error) {
+	enabled, err := Enabled(owner, repo)
+	if err != nil || !enabled {
+		return "", err
+	}
+
+	root, err := config.GetRootDir()
+	if err != nil {
+		return "", err
+	}
+
+	repoDir := owner + "/" + repo
+	if _, err := os.Stat(filepath.Join(root, repoDir)); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	if err := ensureRepo(root); err != nil {
+		return "", err
+	}
+
+	if _, err := git(root, "add", "-A", "--", repoDir); err != nil {
+		return "", err
+	}
+
+	status, err := git(root, "diff", "--cached", "--name-status", "--no-renames", "--", repoDir)
+	if err != nil {
+		return "", err
+	}
+	changes := parseNameStatus(status, repoDir)
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	subject, body := commitMessage(action, repoDir, changes)
+	if err := commit(root, subject, body, repoDir); err != nil {
+		return "", err
+	}
+
+	return git(root, "rev-parse", "--short", "HEAD")
+}
+
+// Log returns the commits touching path (a file or directory under the gh-md
+// root; empty for the whole root), newest first. A limit of 0 returns all commits.
+func Log(path string, limit int) ([]Entry, error) {
+	root, rel, err := resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"log", "--format=%H%x1f%aI%x1f%s"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, "--", rel)
+
+	out, err := git(root, args...)
+	if err != nil {
+		// A fresh repository has no commits yet
+		if strings.Contains(err.Error(), "does not have any commits") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{Commit: fields[0], Date: date, Subject: fields[2]})
+	}
+
+	return entries, nil
+}
+
+// Patch returns the unified diff a commit made to path.
+func Patch(commit, path string) (string, error) {
+	root, rel, err := resolve(path)
+	if err != nil {
+		return "", err
+	}
+	return git(root, "show", "--format=", "--no-color", commit, "--", rel)
+}
+
+// resolve returns the gh-md root and path relative to it, checking that history exists.
+func resolve(path string) (string, string, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git")); err != nil {
+		return "", "", ErrNotEnabled
+	}
+
+	if path == "" {
+		return root, ".", nil
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", err
+	}
+	rel, err := filepath.Rel(root, absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", "", fmt.Errorf("%s is outside the gh-md root", path)
+	}
+	return root, filepath.ToSlash(rel), nil
+}
+
+// ensureRepo initializes a git repository at the root if there isn't one, and
+// keeps the managed block of its .gitignore current.
+func ensureRepo(root string) error {
+	subject := "init: start gh-md history"
+	if _, err := os.Stat(filepath.Join(root, ".git")); err == nil {
+		subject = "chore: update ignored files"
+	} else if _, err := git(root, "init", "-q"); err != nil {
+		return err
+	}
+
+	// History started by an older version may lack newer entries
+	path := filepath.Join(root, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	content := updateIgnore(string(data))
+	if content == string(data) {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+	if _, err := git(root, "add", ".gitignore"); err != nil {
+		return err
+	}
+
+	// Stop tracking files committed before they were ignored
+	tracked, err := git(root, "ls-files", "-z", "--cached", "--ignored", "--exclude-standard")
+	if err != nil {
+		return err
+	}
+	if tracked = strings.Trim(tracked, "\x00"); tracked != "" {
+		args := append([]string{"rm", "-q", "--cached", "--"}, strings.Split(tracked, "\x00")...)
+		if _, err := git(root, args...); err != nil {
+			return err
+		}
+	}
+	return commit(root, subject, "")
+}
+
+// updateIgnore replaces the managed block of a .gitignore with the current one,
+// keeping any other lines. The block is appended if missing.
+func updateIgnore(content string) string {
+	block := strings.TrimSuffix(gitignore, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	start := -1
+	for i, line := range lines {
+		if line == ignoreHeader {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		if strings.TrimSpace(content) == "" {
+			return gitignore
+		}
+		return strings.TrimSuffix(content, "\n") + "\n\n" + gitignore
+	}
+
+	// The block runs up to the first blank line
+	end := start + 1
+	for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+		end++
+	}
+	out := append(append(lines[:start:start], block), lines[end:]...)
+	return strings.Join(out, "\n") + "\n"
+}
+
+// commit records the given paths with the gh-md identity, or everything staged
+// if no paths are given.
+func commit(root, subject, body string, paths ...string) error {
+	args := []string{
+		"-c", "user.name=" + authorName,
+		"-c", "user.email=" + authorEmail,
+		"-c", "commit.gpgsign=false",
+		"commit", "-q", "-m", subject,
+	}
+	if body != "" {
+		args = append(args, "-m", body)
+	}
+	args = append(args, "--")
+	args = append(args, paths...)
+	_, err := git(root, args...)
+	return err
+}
+
+// change is a single file change within a repository directory.
+type change struct {
+	status   byte   // 'A', 'M' or 'D'
+	path     string // Relative to the repository directory
+	itemType github.ItemType
+}
+
+// parseNameStatus parses `git diff --name-status` output for item files.
+func parseNameStatus(out, repoDir string) []change {
+	var changes []change
+	for _, line := range strings.Split(out, "\n") {
+		status, path, ok := strings.Cut(line, "\t")
+		if !ok || status == "" {
+			continue
+		}
+		rel := strings.TrimPrefix(path, repoDir+"/")
+		dir, _, _ := strings.Cut(rel, "/")
+		itemType, _ := github.ItemTypeFromDirName(dir)
+		changes = append(changes, change{status: status[0], path: rel, itemType: itemType})
+	}
+	return changes
+}
+
+// commitMessage builds the subject and body for a history commit. The subject
+// summarizes counts per item type; the body lists every changed file.
+func commitMessage(action, repoDir string, changes []change) (string, string) {
+	type countKey struct {
+		itemType github.ItemType
+		status   byte
+	}
+	counts := make(map[countKey]int)
+	var order []countKey
+	other := 0
+
+	var body strings.Builder
+	for _, c := range changes {
+		fmt.Fprintf(&body, "%c %s\n", c.status, c.path)
+		if c.itemType == "" {
+			other++
+			continue
+		}
+		k := countKey{c.itemType, c.status}
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+
+	var parts []string
+	for _, k := range order {
+		n := counts[k]
+		noun := k.itemType.Display()
+		if n != 1 {
+			noun = k.itemType.DisplayPlural()
+		}
+		parts = append(parts, fmt.Sprintf("%d %s %s", n, noun, statusVerb(k.status)))
+	}
+	if other > 0 {
+		parts = append(parts, fmt.Sprintf("%d other file(s) changed", other))
+	}
+
+	return fmt.Sprintf("%s %s: %s", action, repoDir, strings.Join(parts, ", ")), strings.TrimRight(body.String(), "\n")
+}
+
+func statusVerb(status byte) string {
+	switch status {
+	case 'A':
+		return "added"
+	case 'D':
+		return "removed"
+	default:
+		return "updated"
+	}
+}
+
+// git runs a git command in dir and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git: %s", msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
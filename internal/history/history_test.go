@@ -0,0 +1,201 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+// setupHistory points the root and user config at temp dirs with history enabled.
+func setupHistory(t *testing.T, enabled bool) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv(config.EnvConfigFile, configPath)
+	if enabled {
+		writeTestFile(t, configPath, "history: true\n")
+	}
+
+	return root
+}
+
+func TestRecord_Disabled(t *testing.T) {
+	root := setupHistory(t, false)
+	writeTestFile(t, filepath.Join(root, "o", "r", "issues", "1.md"), "one")
+
+	commit, err := Record(ActionPull, "o", "r")
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if commit != "" {
+		t.Errorf("Record() = %q, want no commit", commit)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git")); !os.IsNotExist(err) {
+		t.Error("Record() initialized a repository with history disabled")
+	}
+	if _, err := Log("", 0); !errors.Is(err, ErrNotEnabled) {
+		t.Errorf("Log() error = %v, want ErrNotEnabled", err)
+	}
+}
+
+func TestRecordAndLog(t *testing.T) {
+	root := setupHistory(t, true)
+	issue := filepath.Join(root, "o", "r", "issues", "1.md")
+
+	writeTestFile(t, issue, "one\n")
+	writeTestFile(t, filepath.Join(root, "o", "r", "issues", "2.md"), "two\n")
+	writeTestFile(t, filepath.Join(root, "o", "r", "pulls", "3.md"), "three\n")
+	writeTestFile(t, filepath.Join(root, "o", "r", ".gh-md-meta.yaml"), "schema_version: 1\n")
+	writeTestFile(t, filepath.Join(root, "o", "other", "issues", "9.md"), "other\n")
+
+	commit, err := Record(ActionPull, "o", "r")
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if commit == "" {
+		t.Fatal("Record() made no commit")
+	}
+
+	// Nothing changed: no new commit
+	if commit, err := Record(ActionPull, "o", "r"); err != nil || commit != "" {
+		t.Errorf("Record() without changes = %q, %v; want no commit", commit, err)
+	}
+
+	writeTestFile(t, issue, "one edited\n")
+	if _, err := Record(ActionEdit, "o", "r"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	entries, err := Log(issue, 0)
+	if err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Log() = %d entries, want 2", len(entries))
+	}
+	if want := "edit o/r: 1 issue updated"; entries[0].Subject != want {
+		t.Errorf("entries[0].Subject = %q, want %q", entries[0].Subject, want)
+	}
+	if want := "pull o/r: 2 issues added, 1 PR added"; entries[1].Subject != want {
+		t.Errorf("entries[1].Subject = %q, want %q", entries[1].Subject, want)
+	}
+
+	patch, err := Patch(entries[0].Commit, issue)
+	if err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	if !strings.Contains(patch, "-one\n+one edited") {
+		t.Errorf("Patch() =\n%s\nwant the edit", patch)
+	}
+
+	// Other repos and internal state stay out of the commits
+	all, err := Log("", 1)
+	if err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("Log(limit 1) = %d entries, want 1", len(all))
+	}
+	if other, err := Log(filepath.Join(root, "o", "other"), 0); err != nil || len(other) != 0 {
+		t.Errorf("Log(o/other) = %v, %v; want no commits", other, err)
+	}
+	if meta, err := Log(filepath.Join(root, "o", "r", ".gh-md-meta.yaml"), 0); err != nil || len(meta) != 0 {
+		t.Errorf("Log(meta) = %v, %v; want the meta file ignored", meta, err)
+	}
+}
+
+func TestRecord_UpdatesOldIgnore(t *testing.T) {
+	root := setupHistory(t, true)
+	writeTestFile(t, filepath.Join(root, "o", "r", "issues", "1.md"), "one\n")
+	if _, err := Record(ActionPull, "o", "r"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	// Simulate history started by a version that didn't ignore archives
+	gitignorePath := filepath.Join(root, ".gitignore")
+	old := strings.Replace(gitignore, ".archive/\n", "", 1) + "\n# mine\nnotes/\n"
+	writeTestFile(t, gitignorePath, old)
+	writeTestFile(t, filepath.Join(root, "o", "r", ".archive", "2026-10.tar.gz"), "tarball")
+	for _, args := range [][]string{{"add", "-A"}, {"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "-m", "old"}} {
+		if _, err := git(root, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	writeTestFile(t, filepath.Join(root, "o", "r", "issues", "2.md"), "two\n")
+	if _, err := Record(ActionPull, "o", "r"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	data, err := os.ReadFile(gitignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := gitignore + "\n# mine\nnotes/\n"; string(data) != want {
+		t.Errorf(".gitignore =\n%s\nwant\n%s", data, want)
+	}
+	tracked, err := git(root, "ls-files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(tracked, ".archive") {
+		t.Errorf("tracked files =\n%s\nwant the archive untracked", tracked)
+	}
+	if !strings.Contains(tracked, "o/r/issues/2.md") {
+		t.Errorf("tracked files =\n%s\nwant the new item", tracked)
+	}
+}
+
+func TestUpdateIgnore(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty", "", gitignore},
+		{"current", gitignore, gitignore},
+		{"outdated", ignoreHeader + "\n*.tmp\n", gitignore},
+		{"user lines kept", "build/\n\n" + ignoreHeader + "\n*.tmp\n\nnotes/\n", "build/\n\n" + gitignore + "\nnotes/\n"},
+		{"no managed block", "notes/\n", "notes/\n\n" + gitignore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateIgnore(tt.content); got != tt.want {
+				t.Errorf("updateIgnore() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	changes := parseNameStatus("M\to/r/issues/1.md\nM\to/r/issues/2.md\nD\to/r/discussions/3.md\nA\to/r/notes.txt", "o/r")
+
+	subject, body := commitMessage(ActionPrune, "o/r", changes)
+	if want := "prune o/r: 2 issues updated, 1 discussion removed, 1 other file(s) changed"; subject != want {
+		t.Errorf("subject = %q, want %q", subject, want)
+	}
+	if want := "M issues/1.md\nM issues/2.md\nD discussions/3.md\nA notes.txt"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
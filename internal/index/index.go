@@ -0,0 +1,741 @@
+package index
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackchuka/gh-md/internal/archive"
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+// FileName is the name of the per-repo index file.
+const FileName = config.IndexFileName
+
+// version is bumped whenever the on-disk layout or tokenization changes;
+// an index with another version is rebuilt from scratch.
+const version = 3
+
+// Field is a searchable part of an item.
+type Field string
+
+const (
+	FieldTitle    Field = "title"
+	FieldBody     Field = "body"
+	FieldComments Field = "comments"
+)
+
+// AllFields lists every searchable field.
+var AllFields = []Field{FieldTitle, FieldBody, FieldComments}
+
+// fieldWeights rank a match in the title above one in the body or comments.
+var fieldWeights = map[Field]float64{
+	FieldTitle:    3,
+	FieldBody:     1.5,
+	FieldComments: 1,
+}
+
+// ParseFields parses a comma-separated field list. An empty list selects all fields.
+func ParseFields(s string) ([]Field, error) {
+	if strings.TrimSpace(s) == "" {
+		return AllFields, nil
+	}
+
+	var fields []Field
+	for _, part := range strings.Split(s, ",") {
+		f := Field(strings.TrimSpace(part))
+		if _, ok := fieldWeights[f]; !ok {
+			return nil, fmt.Errorf("invalid field %q (expected title, body, comments)", part)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// Doc is an indexed item file.
+type Doc struct {
+	Path      string // Relative to the gh-md root
+	ModTime   int64  // Unix nanoseconds, for change detection
+	Size      int64
+	Owner     string
+	Repo      string
+	Number    int
+	ItemType  github.ItemType
+	State     string
+	Title     string
+	Created   time.Time
+	Updated   time.Time
+	Comments  int      // Posted comments
+	Reactions int      // Reactions on the item
+	Terms     []string // Distinct terms, to remove postings on update
+	Archived  bool     // Held in a repository archive; Path is where it is restored to
+
+	text map[Field]string // Searchable text of archived docs, which have no file to re-read
+}
+
+// Posting records how often a term occurs in a field of a document.
+type Posting struct {
+	Doc   int
+	Field Field
+	Freq  int
+}
+
+// shard is the index of a single repository, stored in its directory so a
+// change to one repository only rewrites that repository's index.
+type shard struct {
+	Version  int
+	NextID   int
+	Docs     map[int]*Doc
+	Postings map[string][]Posting
+
+	path   string // Empty for shards kept in memory only
+	byPath map[string]int
+	dirty  bool
+}
+
+// Index is a persistent inverted index over the local item files, made of one
+// shard per repository.
+type Index struct {
+	root     string
+	shards   map[string]*shard // Keyed by "owner/repo" directory
+	archived *shard            // Archived items, never saved
+}
+
+// Result is a ranked search hit.
+type Result struct {
+	Doc     *Doc
+	Score   float64
+	Snippet string
+}
+
+// Query describes a search.
+type Query struct {
+	Text   string
+	Fields []Field // Empty = all fields
+	Repo   string  // "owner/repo", empty = all repos
+	Limit  int     // 0 = no limit
+}
+
+func newShard(path string) *shard {
+	return &shard{
+		Version:  version,
+		Docs:     make(map[int]*Doc),
+		Postings: make(map[string][]Posting),
+		path:     path,
+		byPath:   make(map[string]int),
+	}
+}
+
+func newIndex(root string) *Index {
+	return &Index{
+		root:     root,
+		shards:   make(map[string]*shard),
+		archived: newShard(""),
+	}
+}
+
+// Exists reports whether an index has been built.
+func Exists() bool {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return false
+	}
+	matches, _ := filepath.Glob(filepath.Join(root, "*", "*", FileName))
+	return len(matches) > 0
+}
+
+// Load reads the index of every repository, or only of repoFilter if it is
+// non-empty (format: "owner/repo"). Shards that don't exist yet or were
+// written by an incompatible version start out empty.
+func Load(repoFilter string) (*Index, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Older versions kept a single index at the root
+	_ = os.Remove(filepath.Join(root, FileName))
+
+	ix := newIndex(root)
+	owners, err := os.ReadDir(root)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read gh-md root: %w", err)
+	}
+	for _, owner := range owners {
+		if !owner.IsDir() || strings.HasPrefix(owner.Name(), ".") {
+			continue
+		}
+		repos, err := os.ReadDir(filepath.Join(root, owner.Name()))
+		if err != nil {
+			continue
+		}
+		for _, repo := range repos {
+			key := owner.Name() + "/" + repo.Name()
+			if !repo.IsDir() || strings.HasPrefix(repo.Name(), ".") || (repoFilter != "" && key != repoFilter) {
+				continue
+			}
+			if _, err := ix.shard(key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ix, nil
+}
+
+// shard returns the shard of a repository ("owner/repo"), reading it on first use.
+func (ix *Index) shard(repo string) (*shard, error) {
+	if sh, ok := ix.shards[repo]; ok {
+		return sh, nil
+	}
+
+	path := filepath.Join(ix.root, filepath.FromSlash(repo), FileName)
+	sh := newShard(path)
+	ix.shards[repo] = sh
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			sh.dirty = true // Build it
+			return sh, nil
+		}
+		return nil, fmt.Errorf("failed to open search index: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := gob.NewDecoder(f).Decode(sh); err != nil || sh.Version != version {
+		// Corrupt or outdated: rebuild
+		sh = newShard(path)
+		sh.dirty = true
+		ix.shards[repo] = sh
+		return sh, nil
+	}
+
+	for id, doc := range sh.Docs {
+		sh.byPath[doc.Path] = id
+	}
+	return sh, nil
+}
+
+// Save writes the shards that changed since they were loaded.
+func (ix *Index) Save() error {
+	for _, sh := range ix.shards {
+		if err := sh.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sh *shard) save() error {
+	if !sh.dirty || sh.path == "" {
+		return nil
+	}
+
+	// Atomic write: write to temp file, then rename
+	tmpPath := sh.path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := gob.NewEncoder(f).Encode(sh); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to encode search index: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, sh.path); err != nil {
+		return err
+	}
+
+	sh.dirty = false
+	return nil
+}
+
+// Refresh brings the index up to date with the files on disk. Only files whose
+// size or modification time changed are parsed again; deleted files are dropped.
+// If repoFilter is non-empty (format: "owner/repo"), only that repo is refreshed.
+// Returns the number of documents added, updated or removed.
+func (ix *Index) Refresh(repoFilter string) (int, error) {
+	walked := make(map[string]bool)
+	seen := make(map[string]bool)
+	changed := 0
+
+	var walkErr error
+	err := walkItemFiles(ix.root, repoFilter, func(repo string) {
+		walked[repo] = true
+		if _, err := ix.shard(repo); err != nil && walkErr == nil {
+			walkErr = err
+		}
+	}, func(path, rel string, info fs.FileInfo) {
+		seen[rel] = true
+
+		sh := ix.shards[repoOf(rel)]
+		if sh == nil {
+			return // Its shard couldn't be read
+		}
+		if id, ok := sh.byPath[rel]; ok {
+			doc := sh.Docs[id]
+			if doc.ModTime == info.ModTime().UnixNano() && doc.Size == info.Size() {
+				return
+			}
+			sh.remove(id)
+		}
+
+		parsed, err := parser.ParseFile(path)
+		if err != nil {
+			return // Skip files that can't be parsed
+		}
+		sh.add(rel, info, parsed)
+		changed++
+	})
+	if err == nil {
+		err = walkErr
+	}
+	if err != nil {
+		return changed, err
+	}
+
+	for repo, sh := range ix.shards {
+		if repoFilter != "" && repo != repoFilter {
+			continue
+		}
+		if !walked[repo] {
+			// The repository directory is gone, and its index with it
+			changed += len(sh.Docs)
+			delete(ix.shards, repo)
+			continue
+		}
+		for rel, id := range sh.byPath {
+			if !seen[rel] {
+				sh.remove(id)
+				changed++
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// Update refreshes the index of a repository after files were written or
+// deleted. It does nothing until the index has been built by a search.
+func Update(repoFilter string) error {
+	if !Exists() {
+		return nil
+	}
+
+	ix, err := Load(repoFilter)
+	if err != nil {
+		return err
+	}
+	if _, err := ix.Refresh(repoFilter); err != nil {
+		return err
+	}
+	return ix.Save()
+}
+
+// Search returns documents containing every term of the query in the selected
+// fields, ranked by tf-idf with field weights, best first.
+func (ix *Index) Search(q Query) []Result {
+	terms := uniqueTerms(q.Text)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	fields := q.Fields
+	if len(fields) == 0 {
+		fields = AllFields
+	}
+	inFields := make(map[Field]bool, len(fields))
+	for _, f := range fields {
+		inFields[f] = true
+	}
+
+	shards := []*shard{ix.archived}
+	n := float64(len(ix.archived.Docs))
+	for _, sh := range ix.shards {
+		shards = append(shards, sh)
+		n += float64(len(sh.Docs))
+	}
+
+	scores := make(map[*Doc]float64)
+	matched := make(map[*Doc]int) // Number of query terms found in each doc
+
+	for _, term := range terms {
+		docs := make(map[*Doc]bool)
+		for _, sh := range shards {
+			for _, p := range sh.Postings[term] {
+				if inFields[p.Field] {
+					docs[sh.Docs[p.Doc]] = true
+				}
+			}
+		}
+		if len(docs) == 0 {
+			return nil // Every term must match
+		}
+
+		idf := math.Log(1 + n/float64(len(docs)))
+		for _, sh := range shards {
+			for _, p := range sh.Postings[term] {
+				if !inFields[p.Field] {
+					continue
+				}
+				scores[sh.Docs[p.Doc]] += fieldWeights[p.Field] * (1 + math.Log(float64(p.Freq))) * idf
+			}
+		}
+		for doc := range docs {
+			matched[doc]++
+		}
+	}
+
+	var results []Result
+	for doc, score := range scores {
+		if matched[doc] < len(terms) {
+			continue
+		}
+		if q.Repo != "" && doc.Owner+"/"+doc.Repo != q.Repo {
+			continue
+		}
+		results = append(results, Result{Doc: doc, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Doc.Updated.After(results[j].Doc.Updated)
+	})
+
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	for i := range results {
+		results[i].Snippet = snippet(ix.docText(results[i].Doc), fields, terms)
+	}
+	return results
+}
+
+// FilePath returns the absolute path of a document.
+func (d *Doc) FilePath() (string, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(d.Path)), nil
+}
+
+// docText returns the searchable text of a document, re-reading its file
+// since only archived documents keep their text. Returns nil if the file
+// can't be read.
+func (ix *Index) docText(doc *Doc) map[Field]string {
+	if doc.text != nil {
+		return doc.text
+	}
+	parsed, err := parser.ParseFile(filepath.Join(ix.root, filepath.FromSlash(doc.Path)))
+	if err != nil {
+		return nil
+	}
+	return parsedText(parsed)
+}
+
+// AddArchived adds the items in repository archives to the index, for
+// searches that include them. If repoFilter is non-empty (format: "owner/repo"),
+// only that repo's archives are read. Archived documents are kept in memory
+// only and are never saved.
+func (ix *Index) AddArchived(repoFilter string) error {
+	return archive.Walk(repoFilter, func(item *archive.Item) error {
+		rel, err := filepath.Rel(ix.root, item.Parsed.FilePath)
+		if err != nil {
+			return err
+		}
+		doc := newDoc(filepath.ToSlash(rel), item.Parsed)
+		doc.ModTime = item.ModTime.UnixNano()
+		doc.Size = item.Size
+		doc.Archived = true
+		doc.text = parsedText(item.Parsed)
+		ix.archived.insert(doc, doc.text)
+		return nil
+	})
+}
+
+func (sh *shard) add(rel string, info fs.FileInfo, parsed *parser.ParsedFile) {
+	doc := newDoc(rel, parsed)
+	doc.ModTime = info.ModTime().UnixNano()
+	doc.Size = info.Size()
+	sh.byPath[rel] = sh.insert(doc, parsedText(parsed))
+}
+
+func newDoc(rel string, parsed *parser.ParsedFile) *Doc {
+	return &Doc{
+		Path:      rel,
+		Owner:     parsed.Owner,
+		Repo:      parsed.Repo,
+		Number:    parsed.Number,
+		ItemType:  parsed.ItemType,
+		State:     strings.ToLower(parsed.State),
+		Title:     parsed.Title,
+		Created:   parsed.Created,
+		Updated:   parsed.Updated,
+		Comments:  parsed.CommentCount,
+		Reactions: parsed.Reactions,
+	}
+}
+
+// parsedText returns the searchable text of each field of an item.
+func parsedText(parsed *parser.ParsedFile) map[Field]string {
+	var comments []string
+	for _, c := range parsed.Comments {
+		comments = append(comments, c.Body)
+	}
+
+	return map[Field]string{
+		FieldTitle:    parsed.Title,
+		FieldBody:     parsed.Body,
+		FieldComments: strings.Join(comments, "\n\n"),
+	}
+}
+
+// insert adds a document's postings and returns its ID.
+func (sh *shard) insert(doc *Doc, text map[Field]string) int {
+	id := sh.NextID
+	sh.NextID++
+
+	distinct := make(map[string]bool)
+	for _, field := range AllFields {
+		freqs := make(map[string]int)
+		for _, term := range tokenize(text[field]) {
+			freqs[term]++
+		}
+		for term, freq := range freqs {
+			sh.Postings[term] = append(sh.Postings[term], Posting{Doc: id, Field: field, Freq: freq})
+			distinct[term] = true
+		}
+	}
+	for term := range distinct {
+		doc.Terms = append(doc.Terms, term)
+	}
+
+	sh.Docs[id] = doc
+	sh.dirty = true
+	return id
+}
+
+func (sh *shard) remove(id int) {
+	doc, ok := sh.Docs[id]
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.Terms {
+		postings := sh.Postings[term]
+		kept := postings[:0]
+		for _, p := range postings {
+			if p.Doc != id {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			delete(sh.Postings, term)
+		} else {
+			sh.Postings[term] = kept
+		}
+	}
+
+	delete(sh.Docs, id)
+	delete(sh.byPath, doc.Path)
+	sh.dirty = true
+}
+
+// repoOf returns the "owner/repo" directory of a path relative to the root.
+func repoOf(rel string) string {
+	parts := strings.SplitN(rel, "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// walkItemFiles calls fn for every item file (owner/repo/<type>/<number>.md)
+// under root without parsing it. onRepo is called with "owner/repo" for every
+// repository directory before its files.
+func walkItemFiles(root, repoFilter string, onRepo func(repo string), fn func(path, rel string, info fs.FileInfo)) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip directories we can't read
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		parts := strings.Split(rel, "/")
+
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			// Skip hidden directories and anything outside the item layout
+			if strings.HasPrefix(d.Name(), ".") || len(parts) > 3 {
+				return filepath.SkipDir
+			}
+			if repoFilter != "" && len(parts) >= 2 && parts[0]+"/"+parts[1] != repoFilter {
+				return filepath.SkipDir
+			}
+			if len(parts) == 2 {
+				onRepo(rel)
+			}
+			return nil
+		}
+
+		if len(parts) != 4 || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		if _, ok := github.ItemTypeFromDirName(parts[2]); !ok {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fn(path, rel, info)
+		return nil
+	})
+}
+
+// tokenize splits text into lowercase terms of at least two letters or digits.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, w := range words {
+		if len([]rune(w)) >= 2 {
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+func uniqueTerms(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, t := range tokenize(text) {
+		if !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// snippetRadius is the number of characters shown around a match.
+const snippetRadius = 40
+
+// snippet returns a one-line excerpt around the first match of any term,
+// preferring body and comments over the title, which is shown anyway.
+func snippet(texts map[Field]string, fields []Field, terms []string) string {
+	order := []Field{FieldBody, FieldComments, FieldTitle}
+	for _, field := range order {
+		if !containsField(fields, field) {
+			continue
+		}
+		text := texts[field]
+		lower, offsets := lowerWithOffsets(text)
+
+		pos := -1
+		for _, term := range terms {
+			if i := indexWord(lower, term); i != -1 && (pos == -1 || i < pos) {
+				pos = i
+			}
+		}
+		if pos == -1 {
+			continue
+		}
+		pos = offsets[pos]
+
+		start := max(0, pos-snippetRadius)
+		end := min(len(text), pos+snippetRadius*2)
+		// Don't cut multi-byte characters in half
+		for start > 0 && !utf8Start(text[start]) {
+			start--
+		}
+		for end < len(text) && !utf8Start(text[end]) {
+			end++
+		}
+
+		s := strings.Join(strings.Fields(text[start:end]), " ")
+		if start > 0 {
+			s = "…" + s
+		}
+		if end < len(text) {
+			s += "…"
+		}
+		return s
+	}
+	return ""
+}
+
+// lowerWithOffsets lowercases text like strings.ToLower, and maps each byte of
+// the result to the offset in text of the character it came from. Lowercasing
+// can change the byte length of a character (e.g. 'Ⱥ' grows, 'İ' shrinks), so
+// offsets into the lowercase text can't be used on text directly.
+func lowerWithOffsets(text string) (string, []int) {
+	var sb strings.Builder
+	sb.Grow(len(text))
+	offsets := make([]int, 0, len(text)+1)
+	for i, r := range text {
+		n := sb.Len()
+		sb.WriteRune(unicode.ToLower(r))
+		for range sb.Len() - n {
+			offsets = append(offsets, i)
+		}
+	}
+	offsets = append(offsets, len(text))
+	return sb.String(), offsets
+}
+
+// indexWord returns the byte offset of term in lowercase text where it starts a word.
+func indexWord(text, term string) int {
+	offset := 0
+	for {
+		i := strings.Index(text[offset:], term)
+		if i == -1 {
+			return -1
+		}
+		i += offset
+		if i == 0 || !isWordByte(text[i-1]) {
+			return i
+		}
+		offset = i + len(term)
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b >= 0x80
+}
+
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func containsField(fields []Field, f Field) bool {
+	for _, x := range fields {
+		if x == f {
+			return true
+		}
+	}
+	return false
+}
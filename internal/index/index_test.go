@@ -0,0 +1,316 @@
+package index
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/archive"
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+func writeItem(t *testing.T, root, rel, title, body, comment string) {
+	t.Helper()
+	parts := strings.Split(rel, "/")
+	content := fmt.Sprintf(`---
+id: I_1
+owner: %s
+repo: %s
+number: %s
+title: %q
+updated: 2026-01-01T00:00:00Z
+state: open
+---
+
+<!-- gh-md:content -->
+# %s
+%s
+<!-- /gh-md:content -->
+
+---
+
+<!-- gh-md:comment
+id: IC_1
+author: user1
+created: 2026-01-01T00:00:00Z
+-->
+### @user1 (2026-01-01)
+
+%s
+<!-- /gh-md:comment -->
+
+---
+`, parts[0], parts[1], strings.TrimSuffix(parts[3], ".md"), title, title, body, comment)
+
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func paths(results []Result) []string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.Doc.Path)
+	}
+	return out
+}
+
+func TestIndex_SearchRanksAndFilters(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	writeItem(t, root, "o/r/issues/1.md", "Memory leak in parser", "The parser grows without bound.", "Confirmed.")
+	writeItem(t, root, "o/r/issues/2.md", "Slow startup", "Possibly a memory leak somewhere.", "Unrelated.")
+	writeItem(t, root, "o/r/issues/3.md", "Docs typo", "Nothing to see.", "Saw a leak of memory in CI.")
+	writeItem(t, root, "x/y/issues/4.md", "Memory usage", "No leak here.", "")
+
+	ix, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	n, err := ix.Refresh("")
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if n != 4 {
+		t.Errorf("Refresh() indexed %d docs, want 4", n)
+	}
+
+	got := paths(ix.Search(Query{Text: "Memory LEAK"}))
+	want := []string{"o/r/issues/1.md", "x/y/issues/4.md", "o/r/issues/2.md", "o/r/issues/3.md"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Search() = %v, want %v", got, want)
+	}
+
+	if results := ix.Search(Query{Text: "Memory LEAK"}); results[0].Doc.Comments != 1 {
+		t.Errorf("Doc.Comments = %d, want 1 for sorting results", results[0].Doc.Comments)
+	}
+
+	got = paths(ix.Search(Query{Text: "memory leak", Fields: []Field{FieldComments}}))
+	if strings.Join(got, ",") != "o/r/issues/3.md" {
+		t.Errorf("Search(comments) = %v, want [o/r/issues/3.md]", got)
+	}
+
+	got = paths(ix.Search(Query{Text: "memory leak", Repo: "x/y"}))
+	if strings.Join(got, ",") != "x/y/issues/4.md" {
+		t.Errorf("Search(repo) = %v, want [x/y/issues/4.md]", got)
+	}
+
+	if got := ix.Search(Query{Text: "memory leak", Limit: 2}); len(got) != 2 {
+		t.Errorf("Search(limit) returned %d results, want 2", len(got))
+	}
+	if got := ix.Search(Query{Text: "memory nonexistent"}); len(got) != 0 {
+		t.Errorf("Search() with an unmatched term = %v, want none", paths(got))
+	}
+}
+
+func TestIndex_IncrementalRefresh(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	writeItem(t, root, "o/r/issues/1.md", "First", "alpha", "")
+	writeItem(t, root, "o/r/issues/2.md", "Second", "beta", "")
+
+	ix, _ := Load("")
+	if _, err := ix.Refresh(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if !Exists() {
+		t.Fatal("Exists() = false after Save()")
+	}
+
+	// Nothing changed: nothing is re-parsed
+	ix, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if n, _ := ix.Refresh(""); n != 0 {
+		t.Errorf("Refresh() without changes = %d, want 0", n)
+	}
+
+	// Edit one file, delete the other
+	path := filepath.Join(root, "o", "r", "issues", "1.md")
+	writeItem(t, root, "o/r/issues/1.md", "First", "gamma", "")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(root, "o", "r", "issues", "2.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Update("o/r"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	ix, _ = Load("")
+	if got := ix.Search(Query{Text: "alpha"}); len(got) != 0 {
+		t.Errorf("stale term still indexed: %v", paths(got))
+	}
+	if got := ix.Search(Query{Text: "beta"}); len(got) != 0 {
+		t.Errorf("deleted file still indexed: %v", paths(got))
+	}
+	if got := paths(ix.Search(Query{Text: "gamma"})); strings.Join(got, ",") != "o/r/issues/1.md" {
+		t.Errorf("Search(gamma) = %v, want [o/r/issues/1.md]", got)
+	}
+}
+
+func TestIndex_ShardsPerRepo(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	writeItem(t, root, "o/r/issues/1.md", "First", "alpha in the body", "")
+	writeItem(t, root, "x/y/issues/2.md", "Second", "beta", "")
+	legacy := filepath.Join(root, FileName)
+	if err := os.WriteFile(legacy, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ix, _ := Load("")
+	if _, err := ix.Refresh(""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("the index of older versions at the root was not removed")
+	}
+	other := filepath.Join(root, "x", "y", FileName)
+	before, err := os.ReadFile(other)
+	if err != nil {
+		t.Fatalf("shard of x/y not written: %v", err)
+	}
+
+	// Updating one repository leaves the other's shard alone
+	writeItem(t, root, "o/r/issues/3.md", "Third", "gamma", "")
+	writeItem(t, root, "x/y/issues/4.md", "Fourth", "gamma", "")
+	if err := Update("o/r"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	after, err := os.ReadFile(other)
+	if err != nil || string(after) != string(before) {
+		t.Error("Update(o/r) rewrote the shard of x/y")
+	}
+
+	ix, _ = Load("o/r")
+	got := paths(ix.Search(Query{Text: "gamma"}))
+	if strings.Join(got, ",") != "o/r/issues/3.md" {
+		t.Errorf("Search(gamma) = %v, want [o/r/issues/3.md]", got)
+	}
+
+	// Snippets are read from the item file
+	results := ix.Search(Query{Text: "alpha"})
+	if len(results) != 1 || results[0].Snippet != "alpha in the body" {
+		t.Errorf("Search(alpha) = %+v, want a snippet from the file", results)
+	}
+}
+
+func TestUpdate_NoIndex(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+	writeItem(t, root, "o/r/issues/1.md", "First", "alpha", "")
+
+	if err := Update("o/r"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if Exists() {
+		t.Error("Update() should not build an index that doesn't exist yet")
+	}
+}
+
+func TestIndex_AddArchived(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+	writeItem(t, root, "o/r/issues/1.md", "Live", "alpha", "")
+	writeItem(t, root, "o/r/issues/2.md", "Archived", "alpha", "")
+
+	archived := filepath.Join(root, "o", "r", "issues", "2.md")
+	if err := archive.Add(filepath.Join(root, "o", "r"), []string{archived}, time.Now()); err != nil {
+		t.Fatalf("archive.Add() error = %v", err)
+	}
+	if err := os.Remove(archived); err != nil {
+		t.Fatal(err)
+	}
+
+	ix := newIndex(root)
+	if _, err := ix.Refresh(""); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got := paths(ix.Search(Query{Text: "alpha"})); len(got) != 1 {
+		t.Fatalf("Search() before AddArchived = %v, want only the live item", got)
+	}
+
+	if err := ix.AddArchived("o/r"); err != nil {
+		t.Fatalf("AddArchived() error = %v", err)
+	}
+	results := ix.Search(Query{Text: "alpha"})
+	if len(results) != 2 {
+		t.Fatalf("Search() after AddArchived = %v, want both items", paths(results))
+	}
+	for _, r := range results {
+		if want := r.Doc.Path == "o/r/issues/2.md"; r.Doc.Archived != want {
+			t.Errorf("%s: Archived = %v, want %v", r.Doc.Path, r.Doc.Archived, want)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("body, comments")
+	if err != nil || len(fields) != 2 || fields[0] != FieldBody || fields[1] != FieldComments {
+		t.Errorf("ParseFields() = %v, %v", fields, err)
+	}
+	if fields, _ := ParseFields(""); len(fields) != len(AllFields) {
+		t.Errorf("ParseFields(\"\") = %v, want all fields", fields)
+	}
+	if _, err := ParseFields("labels"); err == nil {
+		t.Error("ParseFields(labels) should fail")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	text := map[Field]string{
+		FieldTitle: "Crash on start",
+		FieldBody:  strings.Repeat("filler ", 20) + "the app\ncrashes hard " + strings.Repeat("more ", 20),
+	}
+
+	got := snippet(text, AllFields, []string{"crashes"})
+	if !strings.Contains(got, "the app crashes hard") {
+		t.Errorf("snippet() = %q, want the match with newlines collapsed", got)
+	}
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") {
+		t.Errorf("snippet() = %q, want ellipses on both sides", got)
+	}
+
+	if got := snippet(text, []Field{FieldTitle}, []string{"crash"}); got != "Crash on start" {
+		t.Errorf("snippet(title) = %q", got)
+	}
+}
+
+func TestSnippet_CaseChangesByteLength(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"lowercase grows", strings.Repeat("Ⱥ", 300) + " crash here"},
+		{"lowercase shrinks", strings.Repeat("İ", 300) + " crash here"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := snippet(map[Field]string{FieldBody: tt.text}, []Field{FieldBody}, []string{"crash"})
+			if !strings.Contains(got, "crash here") {
+				t.Errorf("snippet() = %q, want the match", got)
+			}
+		})
+	}
+}
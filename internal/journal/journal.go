@@ -0,0 +1,241 @@
+package journal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// DirName is the directory under the gh-md root holding journals of pushes
+// that have not completed yet.
+const DirName = config.JournalDirName
+
+// Op is a single planned push operation.
+type Op struct {
+	Key         string     `yaml:"key"`
+	Description string     `yaml:"description"`
+	DoneAt      *time.Time `yaml:"done_at,omitempty"`
+}
+
+// Journal records the operations of a push to a single item so an interrupted
+// push can be resumed without applying anything twice.
+type Journal struct {
+	Item    string    `yaml:"item"` // Item file path relative to the gh-md root
+	Started time.Time `yaml:"started"`
+	Ops     []Op      `yaml:"ops"`
+
+	path string // Journal file path
+}
+
+// Key builds a content-addressed operation key, so an operation is only
+// considered applied if it would push exactly the same content.
+func Key(kind string, parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return kind + ":" + hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Open loads the journal for an item file, or starts a new one.
+func Open(itemPath string) (*Journal, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(itemPath)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(root, absPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("%s is outside the gh-md root", itemPath)
+	}
+
+	j := &Journal{
+		Item:    filepath.ToSlash(rel),
+		Started: time.Now().UTC(),
+		path:    filepath.Join(root, DirName, rel+".yaml"),
+	}
+
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %w", j.path, err)
+	}
+
+	return j, nil
+}
+
+// Exists reports whether the journal has been saved, i.e. a previous push did not complete.
+func (j *Journal) Exists() bool {
+	_, err := os.Stat(j.path)
+	return err == nil
+}
+
+// clockSkew is how far the remote time of an applied operation may trail the
+// local time it was marked done.
+const clockSkew = time.Minute
+
+// CoversUpdate reports whether a remote update at t can be explained by the
+// journaled push itself, i.e. t is no later than its last applied operation.
+// Remote updates made after that, or before anything was applied, come from
+// someone else and are conflicts even when resuming.
+func (j *Journal) CoversUpdate(t time.Time) bool {
+	if !j.Exists() {
+		return false
+	}
+	var last time.Time
+	for _, op := range j.Ops {
+		if op.DoneAt != nil && op.DoneAt.After(last) {
+			last = *op.DoneAt
+		}
+	}
+	return !last.IsZero() && !t.After(last.Add(clockSkew))
+}
+
+// Record adds planned operations that are not in the journal yet and saves it.
+func (j *Journal) Record(ops ...Op) error {
+	for _, op := range ops {
+		if j.find(op.Key) == nil {
+			op.DoneAt = nil
+			j.Ops = append(j.Ops, op)
+		}
+	}
+	return j.save()
+}
+
+// IsDone reports whether the operation was already applied.
+func (j *Journal) IsDone(key string) bool {
+	op := j.find(key)
+	return op != nil && op.DoneAt != nil
+}
+
+// MarkDone records that an operation was applied and saves the journal.
+func (j *Journal) MarkDone(key string) error {
+	op := j.find(key)
+	if op == nil {
+		return fmt.Errorf("operation %s not in journal", key)
+	}
+	now := time.Now().UTC()
+	op.DoneAt = &now
+	return j.save()
+}
+
+// Pending returns the number of recorded operations not yet applied.
+func (j *Journal) Pending() int {
+	n := 0
+	for _, op := range j.Ops {
+		if op.DoneAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Remove deletes the journal once the push has fully completed.
+func (j *Journal) Remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (j *Journal) find(key string) *Op {
+	for i := range j.Ops {
+		if j.Ops[i].Key == key {
+			return &j.Ops[i]
+		}
+	}
+	return nil
+}
+
+func (j *Journal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(j)
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: write to temp file, then rename
+	tmpPath := j.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	return os.Rename(tmpPath, j.path)
+}
+
+// List returns all unfinished journals.
+// If repoFilter is non-empty (format: "owner/repo"), only journals from that repo are included.
+func List(repoFilter string) ([]*Journal, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(root, DirName)
+	var journals []*Journal
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".yaml") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(strings.TrimSuffix(rel, ".yaml"))
+		if repoFilter != "" && !strings.HasPrefix(rel, repoFilter+"/") {
+			return nil
+		}
+
+		j, err := Open(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil // Skip unreadable journals
+		}
+		journals = append(journals, j)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return journals, nil
+}
+
+// ItemPath returns the absolute path of the journaled item file.
+func (j *Journal) ItemPath() (string, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(j.Item)), nil
+}
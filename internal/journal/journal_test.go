@@ -0,0 +1,164 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+func TestKey(t *testing.T) {
+	a := Key("new_comment", "", "hello", "0")
+	if a != Key("new_comment", "", "hello", "0") {
+		t.Error("Key() is not stable")
+	}
+	if a == Key("new_comment", "", "hello", "1") {
+		t.Error("Key() should differ by occurrence")
+	}
+	// Parts are separated, so shifting text between them changes the key
+	if Key("k", "ab", "c") == Key("k", "a", "bc") {
+		t.Error("Key() should not collide when parts are split differently")
+	}
+}
+
+func TestJournal_RecordAndResume(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+	item := filepath.Join(root, "o", "r", "issues", "1.md")
+
+	j, err := Open(item)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if j.Exists() {
+		t.Fatal("Exists() = true before anything was recorded")
+	}
+
+	if err := j.Record(Op{Key: "a"}, Op{Key: "b"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := j.MarkDone("a"); err != nil {
+		t.Fatalf("MarkDone() error = %v", err)
+	}
+	if err := j.MarkDone("missing"); err == nil {
+		t.Error("MarkDone() on unknown key should fail")
+	}
+
+	// Reopen as a retried push would
+	j, err = Open(item)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !j.Exists() {
+		t.Fatal("Exists() = false after Record")
+	}
+	if !j.IsDone("a") || j.IsDone("b") {
+		t.Errorf("IsDone(a, b) = %v, %v, want true, false", j.IsDone("a"), j.IsDone("b"))
+	}
+
+	// Recording the same plan again keeps progress
+	if err := j.Record(Op{Key: "a"}, Op{Key: "b"}, Op{Key: "c"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(j.Ops) != 3 || !j.IsDone("a") || j.Pending() != 2 {
+		t.Errorf("after re-Record: ops = %d, IsDone(a) = %v, Pending() = %d", len(j.Ops), j.IsDone("a"), j.Pending())
+	}
+
+	if got, _ := j.ItemPath(); got != item {
+		t.Errorf("ItemPath() = %q, want %q", got, item)
+	}
+
+	if err := j.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if j.Exists() {
+		t.Error("Exists() = true after Remove")
+	}
+}
+
+func TestJournal_CoversUpdate(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	j, err := Open(filepath.Join(root, "o", "r", "issues", "1.md"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if j.CoversUpdate(time.Now()) {
+		t.Error("CoversUpdate() = true without a journal")
+	}
+
+	if err := j.Record(Op{Key: "a"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if j.CoversUpdate(time.Now()) {
+		t.Error("CoversUpdate() = true before any operation was applied")
+	}
+
+	if err := j.MarkDone("a"); err != nil {
+		t.Fatalf("MarkDone() error = %v", err)
+	}
+	if !j.CoversUpdate(time.Now()) {
+		t.Error("CoversUpdate() = false for the push's own update")
+	}
+
+	// A journal left behind stays on disk, but later remote edits are conflicts
+	done := time.Now().Add(-48 * time.Hour)
+	j.Ops[0].DoneAt = &done
+	if j.CoversUpdate(time.Now()) {
+		t.Error("CoversUpdate() = true for a remote edit made after a stale journal")
+	}
+}
+
+func TestOpen_OutsideRoot(t *testing.T) {
+	t.Setenv(config.EnvRootDir, t.TempDir())
+
+	if _, err := Open(filepath.Join(t.TempDir(), "1.md")); err == nil {
+		t.Error("Open() outside the root should fail")
+	}
+}
+
+func TestList(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	journals, err := List("")
+	if err != nil {
+		t.Fatalf("List() on missing dir error = %v", err)
+	}
+	if len(journals) != 0 {
+		t.Fatalf("List() on missing dir = %d journals", len(journals))
+	}
+
+	for _, rel := range []string{"o/r/issues/1.md", "o/r/pulls/2.md", "o/other/issues/3.md"} {
+		j, err := Open(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", rel, err)
+		}
+		if err := j.Record(Op{Key: "a"}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	all, err := List("")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("List(\"\") = %d journals, want 3", len(all))
+	}
+
+	filtered, err := List("o/r")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(filtered) != 2 {
+		t.Errorf("List(\"o/r\") = %d journals, want 2", len(filtered))
+	}
+	for _, j := range filtered {
+		if len(j.Ops) != 1 {
+			t.Errorf("%s: ops = %d, want 1", j.Item, len(j.Ops))
+		}
+	}
+}
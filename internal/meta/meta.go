@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -9,12 +10,20 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-const metaFile = ".gh-md-meta.yaml"
+// FileName is the name of the per-repo metadata file.
+const FileName = config.MetaFileName
+
+// SchemaVersion is the current format version of the metadata file.
+// Bump it together with a new migration in internal/migrate whenever the
+// file format changes.
+const SchemaVersion = 1
 
 // Meta is the root structure for the metadata file.
 // It allows for future extensibility beyond sync timestamps.
 type Meta struct {
-	Sync *SyncTimestamps `yaml:"sync,omitempty"`
+	SchemaVersion int              `yaml:"schema_version,omitempty"`
+	Sync          *SyncTimestamps  `yaml:"sync,omitempty"`
+	Settings      *config.Settings `yaml:"settings,omitempty"` // Per-repo overrides of the user config
 }
 
 // SyncTimestamps stores the last sync timestamps for each item type.
@@ -49,6 +58,10 @@ func Load(owner, repo string) (*Meta, error) {
 		return nil, err
 	}
 
+	if meta.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("%s has schema version %d, newer than supported %d (upgrade gh-md)", path, meta.SchemaVersion, SchemaVersion)
+	}
+
 	return &meta, nil
 }
 
@@ -65,6 +78,8 @@ func Save(owner, repo string, meta *Meta) error {
 		return err
 	}
 
+	meta.SchemaVersion = SchemaVersion
+
 	data, err := yaml.Marshal(meta)
 	if err != nil {
 		return err
@@ -79,10 +94,32 @@ func Save(owner, repo string, meta *Meta) error {
 	return os.Rename(tmpPath, path)
 }
 
+// ResolveSettings returns the effective settings for a repository by layering
+// repo overrides over the user config over the built-in defaults.
+// If owner or repo is empty, only the user config and defaults are applied.
+func ResolveSettings(owner, repo string) (*config.Settings, error) {
+	user, err := config.LoadUserSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := config.DefaultSettings().Merge(user)
+	if owner == "" || repo == "" {
+		return settings, nil
+	}
+
+	m, err := Load(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings.Merge(m.Settings), nil
+}
+
 func metaPath(owner, repo string) (string, error) {
 	repoDir, err := config.GetRepoDir(owner, repo)
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(repoDir, metaFile), nil
+	return filepath.Join(repoDir, FileName), nil
 }
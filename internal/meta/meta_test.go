@@ -77,6 +77,24 @@ func TestLoad(t *testing.T) {
 			t.Error("Load() error = nil, want error for invalid yaml")
 		}
 	})
+
+	t.Run("newer schema version", func(t *testing.T) {
+		owner, repo := "test", "newer"
+		repoDir := filepath.Join(root, owner, repo)
+		if err := os.MkdirAll(repoDir, 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+
+		content := "schema_version: 99\n"
+		if err := os.WriteFile(filepath.Join(repoDir, FileName), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+
+		_, err := Load(owner, repo)
+		if err == nil {
+			t.Error("Load() error = nil, want error for unsupported schema version")
+		}
+	})
 }
 
 func TestSave(t *testing.T) {
@@ -158,3 +176,54 @@ func TestSave(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveSettings(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+	t.Setenv(config.EnvConfigFile, filepath.Join(root, "config.yaml"))
+
+	user := &config.Settings{}
+	if err := user.Set("open_only", "true"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := user.Set("limit", "50"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := config.SaveUserSettings(user); err != nil {
+		t.Fatalf("SaveUserSettings() error = %v", err)
+	}
+
+	repoSettings := &config.Settings{}
+	if err := repoSettings.Set("limit", "5"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := Save("owner", "repo", &Meta{Settings: repoSettings}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	t.Run("repo overrides user", func(t *testing.T) {
+		s, err := ResolveSettings("owner", "repo")
+		if err != nil {
+			t.Fatalf("ResolveSettings() error = %v", err)
+		}
+		if *s.Limit != 5 {
+			t.Errorf("Limit = %d, want 5", *s.Limit)
+		}
+		if !*s.OpenOnly {
+			t.Error("OpenOnly = false, want true")
+		}
+	})
+
+	t.Run("user only without repo", func(t *testing.T) {
+		s, err := ResolveSettings("", "")
+		if err != nil {
+			t.Fatalf("ResolveSettings() error = %v", err)
+		}
+		if *s.Limit != 50 {
+			t.Errorf("Limit = %d, want 50", *s.Limit)
+		}
+		if s.Format != "text" {
+			t.Errorf("Format = %q, want default text", s.Format)
+		}
+	})
+}
@@ -0,0 +1,265 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/writer"
+	"gopkg.in/yaml.v3"
+)
+
+// BackupDirName is the directory under the gh-md root where originals are
+// copied before migrating.
+const BackupDirName = config.BackupDirName
+
+// Kind identifies which kind of file a migration applies to.
+type Kind string
+
+const (
+	KindItem Kind = "item" // Item markdown files (issues, pulls, discussions)
+	KindMeta Kind = "meta" // Per-repo .gh-md-meta.yaml files
+)
+
+// Migration upgrades file content from version From to From+1.
+type Migration struct {
+	From        int
+	Description string
+	Apply       func(content string) (string, error)
+}
+
+// itemMigrations are applied in order to item markdown files.
+// Index i upgrades version i to i+1.
+var itemMigrations = []Migration{
+	{
+		From:        0,
+		Description: "add schema_version to frontmatter",
+		Apply: func(content string) (string, error) {
+			return writer.SetFrontmatterField(content, "schema_version", 1, true)
+		},
+	},
+}
+
+// metaMigrations are applied in order to .gh-md-meta.yaml files.
+// Index i upgrades version i to i+1.
+var metaMigrations = []Migration{
+	{
+		From:        0,
+		Description: "add schema_version",
+		Apply: func(content string) (string, error) {
+			return writer.SetYAMLField(content, "schema_version", 1, true)
+		},
+	},
+}
+
+// Change describes a file whose schema version differs from the current one.
+type Change struct {
+	Path    string // Absolute path
+	RelPath string // Path relative to the gh-md root
+	Kind    Kind
+	From    int
+	To      int
+	Steps   []string // Descriptions of the migrations to apply
+}
+
+// Unsupported reports whether the file was written by a newer gh-md and cannot be migrated.
+func (c *Change) Unsupported() bool {
+	return c.From > c.To
+}
+
+// Plan walks the gh-md root and returns files that need migrating.
+// If repoFilter is non-empty (format: "owner/repo"), only files from that repo are included.
+func Plan(repoFilter string) ([]Change, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip directories we can't read
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+
+		parts := strings.Split(filepath.ToSlash(relPath), "/")
+
+		// Skip hidden directories (backups, archives, internal state), except
+		// the base copies of items, which are compared with the item files
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") && !(len(parts) == 3 && d.Name() == writer.BaseDirName) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if len(parts) < 3 {
+			return nil // Not in expected owner/repo structure
+		}
+		if repoFilter != "" && parts[0]+"/"+parts[1] != repoFilter {
+			return nil
+		}
+
+		var kind Kind
+		switch {
+		case len(parts) == 3 && d.Name() == meta.FileName:
+			kind = KindMeta
+		case len(parts) == 4 && strings.HasSuffix(d.Name(), ".md"),
+			len(parts) == 5 && parts[2] == writer.BaseDirName && strings.HasSuffix(d.Name(), ".md"):
+			if _, ok := github.ItemTypeFromDirName(parts[len(parts)-2]); !ok {
+				return nil
+			}
+			kind = KindItem
+		default:
+			return nil
+		}
+
+		change, err := planFile(path, kind)
+		if err != nil {
+			return nil // Skip files that can't be parsed
+		}
+		if change != nil {
+			change.RelPath = relPath
+			changes = append(changes, *change)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// planFile returns the change needed for a single file, or nil if it is current.
+func planFile(path string, kind Kind) (*Change, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := readVersion(string(content), kind)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, to := migrationsFor(kind)
+	if from == to {
+		return nil, nil
+	}
+
+	change := &Change{Path: path, Kind: kind, From: from, To: to}
+	for _, m := range migrations {
+		if m.From >= from {
+			change.Steps = append(change.Steps, m.Description)
+		}
+	}
+
+	return change, nil
+}
+
+// Apply migrates each change in place after copying the original into backupDir.
+// Unsupported changes are skipped. Returns the number of files migrated.
+func Apply(changes []Change, backupDir string) (int, error) {
+	migrated := 0
+	for _, c := range changes {
+		if c.Unsupported() {
+			continue
+		}
+
+		content, err := os.ReadFile(c.Path)
+		if err != nil {
+			return migrated, err
+		}
+
+		upgraded, err := upgrade(string(content), c.Kind, c.From)
+		if err != nil {
+			return migrated, fmt.Errorf("%s: %w", c.RelPath, err)
+		}
+		// Keep unedited files unedited: a migration is not a local modification
+		if c.Kind == KindItem && writer.StoredContentHash(string(content)) != "" && !writer.IsModified(string(content)) {
+			upgraded = writer.StampContentHash(upgraded)
+		}
+
+		backupPath := filepath.Join(backupDir, c.RelPath)
+		if err := os.MkdirAll(filepath.Dir(backupPath), 0755); err != nil {
+			return migrated, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		if err := os.WriteFile(backupPath, content, 0644); err != nil {
+			return migrated, fmt.Errorf("failed to back up %s: %w", c.RelPath, err)
+		}
+
+		if err := writer.WriteFile(c.Path, upgraded); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// NewBackupDir returns a fresh timestamped backup directory path under the gh-md root.
+// The directory is created lazily by Apply.
+func NewBackupDir() (string, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, BackupDirName, time.Now().Format("20060102-150405")), nil
+}
+
+// upgrade runs every migration from version from up to the current version.
+func upgrade(content string, kind Kind, from int) (string, error) {
+	migrations, _ := migrationsFor(kind)
+	for _, m := range migrations {
+		if m.From < from {
+			continue
+		}
+		var err error
+		content, err = m.Apply(content)
+		if err != nil {
+			return "", fmt.Errorf("migration %d -> %d (%s): %w", m.From, m.From+1, m.Description, err)
+		}
+	}
+	return content, nil
+}
+
+func migrationsFor(kind Kind) ([]Migration, int) {
+	if kind == KindMeta {
+		return metaMigrations, meta.SchemaVersion
+	}
+	return itemMigrations, writer.SchemaVersion
+}
+
+// readVersion extracts schema_version from a file; files without one are version 0.
+func readVersion(content string, kind Kind) (int, error) {
+	data := content
+	if kind == KindItem {
+		fm, _, err := writer.SplitFrontmatter(content)
+		if err != nil {
+			return 0, err
+		}
+		data = fm
+	}
+
+	var v struct {
+		SchemaVersion int `yaml:"schema_version"`
+	}
+	if err := yaml.Unmarshal([]byte(data), &v); err != nil {
+		return 0, err
+	}
+	return v.SchemaVersion, nil
+}
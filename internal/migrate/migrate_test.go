@@ -0,0 +1,146 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/meta"
+	"github.com/jackchuka/gh-md/internal/writer"
+)
+
+const legacyIssue = `---
+id: I_1
+owner: owner
+repo: repo
+number: 1
+state: open
+---
+
+<!-- gh-md:content -->
+# Title
+Body
+<!-- /gh-md:content -->
+`
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	issuePath := filepath.Join(root, "owner", "repo", "issues", "1.md")
+	currentPath := filepath.Join(root, "owner", "repo", "issues", "2.md")
+	basePath := filepath.Join(root, "owner", "repo", writer.BaseDirName, "issues", "1.md")
+	metaPath := filepath.Join(root, "owner", "repo", meta.FileName)
+	otherPath := filepath.Join(root, "other", "repo", "issues", "1.md")
+
+	writeTestFile(t, issuePath, legacyIssue)
+	writeTestFile(t, basePath, legacyIssue)
+	writeTestFile(t, currentPath, "---\nschema_version: 1\nid: I_2\n---\n")
+	writeTestFile(t, metaPath, "sync:\n  issues: 2026-01-01T00:00:00Z\n")
+	writeTestFile(t, otherPath, legacyIssue)
+	// Files in hidden directories must be ignored
+	writeTestFile(t, filepath.Join(root, BackupDirName, "old", "owner", "repo", "issues", "1.md"), legacyIssue)
+
+	changes, err := Plan("owner/repo")
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("Plan() returned %d changes, want 3: %+v", len(changes), changes)
+	}
+
+	byPath := map[string]Change{}
+	for _, c := range changes {
+		byPath[c.Path] = c
+	}
+	if c, ok := byPath[issuePath]; !ok || c.Kind != KindItem || c.From != 0 || c.To != 1 {
+		t.Errorf("item change = %+v, want %s 0 -> 1", c, issuePath)
+	}
+	if c, ok := byPath[basePath]; !ok || c.Kind != KindItem {
+		t.Errorf("base copy change = %+v, want %s", c, basePath)
+	}
+	if c, ok := byPath[metaPath]; !ok || c.Kind != KindMeta {
+		t.Errorf("meta change = %+v, want %s", c, metaPath)
+	}
+
+	backupDir := filepath.Join(root, BackupDirName, "test")
+	migrated, err := Apply(changes, backupDir)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if migrated != 3 {
+		t.Errorf("Apply() migrated = %d, want 3", migrated)
+	}
+
+	got, err := os.ReadFile(issuePath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.HasPrefix(string(got), "---\nschema_version: 1\nid: I_1\n") {
+		t.Errorf("migrated issue frontmatter:\n%s", got)
+	}
+	if !strings.HasSuffix(string(got), "# Title\nBody\n<!-- /gh-md:content -->\n") {
+		t.Errorf("migrated issue body changed:\n%s", got)
+	}
+
+	backup, err := os.ReadFile(filepath.Join(backupDir, "owner", "repo", "issues", "1.md"))
+	if err != nil {
+		t.Fatalf("backup not written: %v", err)
+	}
+	if string(backup) != legacyIssue {
+		t.Errorf("backup content = %q, want original", backup)
+	}
+
+	m, err := meta.Load("owner", "repo")
+	if err != nil {
+		t.Fatalf("meta.Load() error = %v", err)
+	}
+	if m.SchemaVersion != meta.SchemaVersion {
+		t.Errorf("meta SchemaVersion = %d, want %d", m.SchemaVersion, meta.SchemaVersion)
+	}
+
+	// Second run finds nothing to do
+	changes, err = Plan("owner/repo")
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Plan() after Apply returned %d changes, want 0", len(changes))
+	}
+}
+
+func TestPlan_NewerVersionUnsupported(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	path := filepath.Join(root, "owner", "repo", "issues", "1.md")
+	writeTestFile(t, path, "---\nschema_version: 99\nid: I_1\n---\n")
+
+	changes, err := Plan("")
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 1 || !changes[0].Unsupported() {
+		t.Fatalf("Plan() = %+v, want one unsupported change", changes)
+	}
+
+	migrated, err := Apply(changes, filepath.Join(root, BackupDirName, "test"))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if migrated != 0 {
+		t.Errorf("Apply() migrated = %d, want 0", migrated)
+	}
+}
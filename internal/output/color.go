@@ -0,0 +1,61 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// ANSI color codes.
+const (
+	ColorReset     = "\x1b[0m"
+	ColorBold      = "\x1b[1m"
+	ColorDim       = "\x1b[2m"
+	ColorItalic    = "\x1b[3m"
+	ColorUnderline = "\x1b[4m"
+	ColorRed       = "\x1b[31m"
+	ColorGreen     = "\x1b[32m"
+	ColorYellow    = "\x1b[33m"
+	ColorMagenta   = "\x1b[35m"
+	ColorCyan      = "\x1b[36m"
+)
+
+// ColorEnabled reports whether stdout is a terminal that should receive color.
+// Respects NO_COLOR and CLICOLOR_FORCE.
+func ColorEnabled() bool {
+	return term.FromEnv().IsColorEnabled()
+}
+
+// Colorize wraps s in the given ANSI color.
+func Colorize(color, s string) string {
+	return color + s + ColorReset
+}
+
+// ColorizeDiff colors the lines of a unified diff: headers bold, hunk markers
+// cyan, removals red and additions green.
+func ColorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	var sb strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		text, nl := strings.CutSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(text, "--- "), strings.HasPrefix(text, "+++ "):
+			sb.WriteString(Colorize(ColorBold, text))
+		case strings.HasPrefix(text, "@@"):
+			sb.WriteString(Colorize(ColorCyan, text))
+		case strings.HasPrefix(text, "-"):
+			sb.WriteString(Colorize(ColorRed, text))
+		case strings.HasPrefix(text, "+"):
+			sb.WriteString(Colorize(ColorGreen, text))
+		default:
+			sb.WriteString(text)
+		}
+		if nl {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
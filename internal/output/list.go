@@ -0,0 +1,206 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// === High-level output helpers ===
+
+// Column is a named column of list output.
+type Column[T any] struct {
+	Name    string         // Lower-case name, as given to --columns
+	Value   func(T) string // Value for csv and tsv
+	Display func(T) string // Value for text and markdown tables; defaults to Value
+}
+
+// header returns the table header of the column, e.g. "LAST SYNC" for "last_sync".
+func (c Column[T]) header() string {
+	return strings.ToUpper(strings.ReplaceAll(c.Name, "_", " "))
+}
+
+// SelectColumns returns the columns named in names, a comma-separated list, in
+// that order. An empty list selects the columns named in defaults.
+func SelectColumns[T any](all []Column[T], names string, defaults ...string) ([]Column[T], error) {
+	wanted := defaults
+	if strings.TrimSpace(names) != "" {
+		wanted = strings.Split(names, ",")
+	}
+
+	var cols []Column[T]
+	for _, name := range wanted {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, c := range all {
+			if c.Name == name {
+				cols = append(cols, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (valid: %s)", name, ColumnNames(all))
+		}
+	}
+	return cols, nil
+}
+
+// ColumnNames returns the names of columns, comma-separated.
+func ColumnNames[T any](all []Column[T]) string {
+	names := make([]string, len(all))
+	for i, c := range all {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// List prints items as either a table or structured (JSON/YAML) based on the Printer's format.
+// Items should be the output type with json/yaml tags. rowFunc extracts table columns.
+func List[T any](p *Printer, headers []string, items []T, rowFunc func(T) []string) error {
+	if ok, err := writeItems(p, items); ok {
+		return err
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = rowFunc(item)
+	}
+	return p.writeRows(headers, rows)
+}
+
+// ListColumns prints items in the Printer's format. Table formats (text, csv,
+// tsv, markdown) show cols; JSON, YAML and NDJSON encode whole items, and
+// templates are executed with each item.
+func ListColumns[T any](p *Printer, cols []Column[T], items []T) error {
+	if ok, err := writeItems(p, items); ok {
+		return err
+	}
+
+	human := p.format != FormatCSV && p.format != FormatTSV
+	headers := make([]string, len(cols))
+	for i, c := range cols {
+		headers[i] = c.header()
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := make([]string, len(cols))
+		for j, c := range cols {
+			if human && c.Display != nil {
+				row[j] = c.Display(item)
+			} else {
+				row[j] = c.Value(item)
+			}
+		}
+		rows[i] = row
+	}
+	return p.writeRows(headers, rows)
+}
+
+// writeItems writes items in the formats that encode whole items rather than
+// rows. Reports whether the printer's format is one of them.
+func writeItems[T any](p *Printer, items []T) (bool, error) {
+	if p.IsStructured() {
+		return true, p.Structured(items)
+	}
+
+	if p.format == FormatNDJSON {
+		for _, item := range items {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return true, err
+			}
+			if _, err := fmt.Fprintf(p.out, "%s\n", data); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	}
+
+	if text, ok := p.format.template(); ok {
+		tmpl, err := parseTemplate(text)
+		if err != nil {
+			return true, err
+		}
+		for _, item := range items {
+			var sb strings.Builder
+			if err := tmpl.Execute(&sb, item); err != nil {
+				return true, fmt.Errorf("failed to execute template: %w", err)
+			}
+			line := strings.TrimSuffix(sb.String(), "\n")
+			if _, err := fmt.Fprintln(p.out, line); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// parseTemplate parses a --format template. Besides the built-in functions,
+// templates can use join (strings.Join), lower and upper.
+func parseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeRows writes a table in the printer's table format: csv, tsv, a markdown
+// table, or aligned text.
+func (p *Printer) writeRows(headers []string, rows [][]string) error {
+	switch p.format {
+	case FormatCSV, FormatTSV:
+		w := csv.NewWriter(p.out)
+		if p.format == FormatTSV {
+			w.Comma = '\t'
+		}
+		names := make([]string, len(headers))
+		for i, h := range headers {
+			names[i] = strings.ReplaceAll(strings.ToLower(h), " ", "_")
+		}
+		if err := w.Write(names); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+
+	case FormatMarkdown:
+		p.Printf("| %s |\n", strings.Join(headers, " | "))
+		p.Printf("|%s\n", strings.Repeat(" --- |", len(headers)))
+		for _, row := range rows {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				cells[i] = markdownCell(v)
+			}
+			p.Printf("| %s |\n", strings.Join(cells, " | "))
+		}
+		return nil
+
+	default:
+		t := p.NewTable(headers...)
+		for _, row := range rows {
+			t.Row(row...)
+		}
+		return t.Flush()
+	}
+}
+
+// markdownCell escapes a value for a markdown table cell.
+func markdownCell(v string) string {
+	v = strings.ReplaceAll(v, "\\", "\\\\")
+	v = strings.ReplaceAll(v, "|", "\\|")
+	return strings.Join(strings.Fields(strings.ReplaceAll(v, "\n", " ")), " ")
+}
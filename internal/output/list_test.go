@@ -0,0 +1,94 @@
+package output
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type listTestItem struct {
+	Number int      `json:"number" yaml:"number"`
+	Title  string   `json:"title" yaml:"title"`
+	Labels []string `json:"labels" yaml:"labels"`
+}
+
+var listTestColumns = []Column[listTestItem]{
+	{
+		Name:    "number",
+		Value:   func(i listTestItem) string { return strconv.Itoa(i.Number) },
+		Display: func(i listTestItem) string { return "#" + strconv.Itoa(i.Number) },
+	},
+	{Name: "title", Value: func(i listTestItem) string { return i.Title }},
+	{Name: "labels", Value: func(i listTestItem) string { return strings.Join(i.Labels, ",") }},
+}
+
+func TestListColumns(t *testing.T) {
+	items := []listTestItem{
+		{Number: 1, Title: "Crash, on start", Labels: []string{"bug", "p1"}},
+		{Number: 2, Title: "a | b"},
+	}
+	cols, err := SelectColumns(listTestColumns, "", "number", "title")
+	if err != nil {
+		t.Fatalf("SelectColumns() error = %v", err)
+	}
+
+	tests := []struct {
+		format Format
+		cols   []Column[listTestItem]
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			cols:   cols,
+			want:   "number,title\n1,\"Crash, on start\"\n2,a | b\n",
+		},
+		{
+			format: FormatTSV,
+			cols:   listTestColumns,
+			want:   "number\ttitle\tlabels\n1\tCrash, on start\tbug,p1\n2\ta | b\t\n",
+		},
+		{
+			format: FormatMarkdown,
+			cols:   cols,
+			want:   "| NUMBER | TITLE |\n| --- | --- |\n| #1 | Crash, on start |\n| #2 | a \\| b |\n",
+		},
+		{
+			format: FormatNDJSON,
+			cols:   cols,
+			want:   `{"number":1,"title":"Crash, on start","labels":["bug","p1"]}` + "\n" + `{"number":2,"title":"a | b","labels":null}` + "\n",
+		},
+		{
+			format: Format(`template=#{{.Number}} {{join .Labels "+"}}`),
+			cols:   cols,
+			want:   "#1 bug+p1\n#2 \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			p := NewPrinter(&mockCommandOutput{out: &buf, err: &buf}).WithFormat(tt.format)
+			if err := ListColumns(p, tt.cols, items); err != nil {
+				t.Fatalf("ListColumns() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("ListColumns() =\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	cols, err := SelectColumns(listTestColumns, "labels, Number")
+	if err != nil {
+		t.Fatalf("SelectColumns() error = %v", err)
+	}
+	if len(cols) != 2 || cols[0].Name != "labels" || cols[1].Name != "number" {
+		t.Errorf("SelectColumns() = %v", ColumnNames(cols))
+	}
+
+	if _, err := SelectColumns(listTestColumns, "number,bogus"); err == nil || !strings.Contains(err.Error(), "number, title, labels") {
+		t.Errorf("SelectColumns() with unknown column error = %v", err)
+	}
+}
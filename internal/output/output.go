@@ -33,23 +33,57 @@ func FormatTime(t *time.Time, format string) string {
 type Format string
 
 const (
-	FormatText Format = "text"
-	FormatJSON Format = "json"
-	FormatYAML Format = "yaml"
+	FormatText     Format = "text"
+	FormatJSON     Format = "json"
+	FormatYAML     Format = "yaml"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatMarkdown Format = "markdown" // Markdown table
+	FormatNDJSON   Format = "ndjson"   // One JSON object per line
+	FormatTemplate Format = "template" // Go template per item, given as "template=<text>"
 )
 
-// ParseFormat converts a string to Format, defaulting to FormatText.
-func ParseFormat(s string) Format {
-	switch s {
-	case "json":
-		return FormatJSON
-	case "yaml":
-		return FormatYAML
+// formatNames lists the accepted format names for error messages.
+const formatNames = "text, json, yaml, csv, tsv, markdown, ndjson, template=<go template>"
+
+// ParseFormat converts a string to Format. An empty string is FormatText.
+// Templates are given as "template=<text>" and are checked for syntax errors.
+func ParseFormat(s string) (Format, error) {
+	if text, ok := strings.CutPrefix(s, string(FormatTemplate)+"="); ok {
+		if _, err := parseTemplate(text); err != nil {
+			return "", err
+		}
+		return Format(s), nil
+	}
+
+	switch f := Format(s); f {
+	case "":
+		return FormatText, nil
+	case FormatText, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatMarkdown, FormatNDJSON:
+		return f, nil
+	case FormatTemplate:
+		return "", fmt.Errorf("template format needs a template, e.g. --format 'template={{.Title}}'")
 	default:
-		return FormatText
+		return "", fmt.Errorf("unknown format %q (valid: %s)", s, formatNames)
 	}
 }
 
+// IsListOnly reports whether f is one of the formats only list output supports:
+// csv, tsv, markdown, ndjson and templates.
+func (f Format) IsListOnly() bool {
+	switch f {
+	case FormatCSV, FormatTSV, FormatMarkdown, FormatNDJSON:
+		return true
+	}
+	_, ok := f.template()
+	return ok
+}
+
+// template returns the template text of a template format.
+func (f Format) template() (string, bool) {
+	return strings.CutPrefix(string(f), string(FormatTemplate)+"=")
+}
+
 // CommandOutput is the interface for cobra.Command output methods.
 type CommandOutput interface {
 	OutOrStdout() io.Writer
@@ -82,6 +116,11 @@ func (p *Printer) IsStructured() bool {
 	return p.format == FormatJSON || p.format == FormatYAML
 }
 
+// IsListOnly returns true if the printer is in one of the list-only formats.
+func (p *Printer) IsListOnly() bool {
+	return p.format.IsListOnly()
+}
+
 // Print prints a message to stdout.
 func (p *Printer) Print(msg string) {
 	_, _ = fmt.Fprintln(p.out, msg)
@@ -146,18 +185,3 @@ func YAMLOutput(w io.Writer, data any) error {
 	enc.SetIndent(2)
 	return enc.Encode(data)
 }
-
-// === High-level output helpers ===
-
-// List prints items as either a table or structured (JSON/YAML) based on the Printer's format.
-// Items should be the output type with json/yaml tags. rowFunc extracts table columns.
-func List[T any](p *Printer, headers []string, items []T, rowFunc func(T) []string) error {
-	if p.IsStructured() {
-		return p.Structured(items)
-	}
-	t := p.NewTable(headers...)
-	for _, item := range items {
-		t.Row(rowFunc(item)...)
-	}
-	return t.Flush()
-}
@@ -59,9 +59,10 @@ func TestFormatTime(t *testing.T) {
 
 func TestParseFormat(t *testing.T) {
 	tests := []struct {
-		name  string
-		input string
-		want  Format
+		name    string
+		input   string
+		want    Format
+		wantErr bool
 	}{
 		{
 			name:  "json",
@@ -78,21 +79,49 @@ func TestParseFormat(t *testing.T) {
 			input: "text",
 			want:  FormatText,
 		},
+		{
+			name:  "csv",
+			input: "csv",
+			want:  FormatCSV,
+		},
+		{
+			name:  "ndjson",
+			input: "ndjson",
+			want:  FormatNDJSON,
+		},
+		{
+			name:  "template",
+			input: "template={{.Number}} {{.Title}}",
+			want:  Format("template={{.Number}} {{.Title}}"),
+		},
 		{
 			name:  "empty string defaults to text",
 			input: "",
 			want:  FormatText,
 		},
 		{
-			name:  "unknown defaults to text",
-			input: "xml",
-			want:  FormatText,
+			name:    "unknown is an error",
+			input:   "xml",
+			wantErr: true,
+		},
+		{
+			name:    "template without text",
+			input:   "template",
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			input:   "template={{.Title",
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ParseFormat(tt.input)
+			got, err := ParseFormat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
 			if got != tt.want {
 				t.Errorf("ParseFormat(%q) = %v, want %v", tt.input, got, tt.want)
 			}
@@ -305,3 +334,17 @@ func TestTable(t *testing.T) {
 		t.Errorf("Table output missing rows: %q", got)
 	}
 }
+
+func TestColorizeDiff(t *testing.T) {
+	in := "--- a\n+++ b\n@@ -1 +1 @@\n-old\n+new\n same\n"
+	want := Colorize(ColorBold, "--- a") + "\n" +
+		Colorize(ColorBold, "+++ b") + "\n" +
+		Colorize(ColorCyan, "@@ -1 +1 @@") + "\n" +
+		Colorize(ColorRed, "-old") + "\n" +
+		Colorize(ColorGreen, "+new") + "\n" +
+		" same\n"
+
+	if got := ColorizeDiff(in); got != want {
+		t.Errorf("ColorizeDiff() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,308 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/assets"
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+)
+
+// CacheFileName is the name of the per-repo metadata cache file.
+const CacheFileName = config.CacheFileName
+
+// cacheVersion is bumped whenever cached fields change; older caches are rebuilt.
+const cacheVersion = 4
+
+// cacheEntry is the cached metadata of a single item file.
+type cacheEntry struct {
+	ModTime       int64           `json:"mtime"` // Unix nanoseconds
+	Size          int64           `json:"size"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	ID            string          `json:"id,omitempty"`
+	Owner         string          `json:"owner"`
+	Repo          string          `json:"repo"`
+	Number        int             `json:"number"`
+	ItemType      github.ItemType `json:"type,omitempty"`
+	State         string          `json:"state,omitempty"`
+	Title         string          `json:"title"`
+	Author        string          `json:"author,omitempty"`
+	Assignees     []string        `json:"assignees,omitempty"`
+	Reviewers     []string        `json:"reviewers,omitempty"`
+	Labels        []string        `json:"labels,omitempty"`
+	Created       time.Time       `json:"created"`
+	Updated       time.Time       `json:"updated"`
+	LastPulled    time.Time       `json:"last_pulled"`
+	Closed        time.Time       `json:"closed,omitzero"`
+	Draft         bool            `json:"draft,omitempty"`
+	HeadRef       string          `json:"head_ref,omitempty"`
+	BaseRef       string          `json:"base_ref,omitempty"`
+	Category      string          `json:"category,omitempty"`
+	Parent        int             `json:"parent,omitempty"`
+	Children      []int           `json:"children,omitempty"`
+	Reactions     int             `json:"reactions,omitempty"`
+	Pinned        bool            `json:"pinned,omitempty"`
+	CommentCount  int             `json:"comment_count,omitempty"`
+	ContentHash   string          `json:"content_hash,omitempty"`
+	Modified      bool            `json:"modified,omitempty"`
+}
+
+// metadataCache holds the cached metadata of every item file in a repository,
+// keyed by path relative to the repository directory.
+type metadataCache struct {
+	Version int                    `json:"version"`
+	Files   map[string]*cacheEntry `json:"files"`
+
+	path  string
+	seen  map[string]bool
+	dirty bool
+}
+
+func newCacheEntry(parsed *ParsedFile, info os.FileInfo) *cacheEntry {
+	return &cacheEntry{
+		ModTime:       info.ModTime().UnixNano(),
+		Size:          info.Size(),
+		SchemaVersion: parsed.SchemaVersion,
+		ID:            parsed.ID,
+		Owner:         parsed.Owner,
+		Repo:          parsed.Repo,
+		Number:        parsed.Number,
+		ItemType:      parsed.ItemType,
+		State:         parsed.State,
+		Title:         parsed.Title,
+		Author:        parsed.Author,
+		Assignees:     parsed.Assignees,
+		Reviewers:     parsed.Reviewers,
+		Labels:        parsed.Labels,
+		Created:       parsed.Created,
+		Updated:       parsed.Updated,
+		LastPulled:    parsed.LastPulled,
+		Closed:        parsed.Closed,
+		Draft:         parsed.Draft,
+		HeadRef:       parsed.HeadRef,
+		BaseRef:       parsed.BaseRef,
+		Category:      parsed.Category,
+		Parent:        parsed.Parent,
+		Children:      parsed.Children,
+		Reactions:     parsed.Reactions,
+		Pinned:        parsed.Pinned,
+		CommentCount:  parsed.CommentCount,
+		ContentHash:   parsed.ContentHash,
+		Modified:      parsed.Modified,
+	}
+}
+
+// parsedFile returns the cached metadata as a ParsedFile without body and comments.
+func (e *cacheEntry) parsedFile(path string) *ParsedFile {
+	return &ParsedFile{
+		SchemaVersion: e.SchemaVersion,
+		ID:            e.ID,
+		Owner:         e.Owner,
+		Repo:          e.Repo,
+		Number:        e.Number,
+		Updated:       e.Updated,
+		State:         e.State,
+		Author:        e.Author,
+		Assignees:     e.Assignees,
+		Reviewers:     e.Reviewers,
+		Labels:        e.Labels,
+		Created:       e.Created,
+		LastPulled:    e.LastPulled,
+		Closed:        e.Closed,
+		Draft:         e.Draft,
+		HeadRef:       e.HeadRef,
+		BaseRef:       e.BaseRef,
+		Category:      e.Category,
+		Parent:        e.Parent,
+		Children:      e.Children,
+		Reactions:     e.Reactions,
+		Pinned:        e.Pinned,
+		CommentCount:  e.CommentCount,
+		Title:         e.Title,
+		ItemType:      e.ItemType,
+		ContentHash:   e.ContentHash,
+		Modified:      e.Modified,
+		FilePath:      path,
+	}
+}
+
+// loadCache reads the metadata cache of a repository directory, returning an
+// empty cache if it doesn't exist or can't be used.
+func loadCache(repoDir string) *metadataCache {
+	c := &metadataCache{
+		Version: cacheVersion,
+		Files:   make(map[string]*cacheEntry),
+		path:    filepath.Join(repoDir, CacheFileName),
+		seen:    make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+
+	var stored metadataCache
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != cacheVersion || stored.Files == nil {
+		c.dirty = true // Rebuild
+		return c
+	}
+
+	c.Files = stored.Files
+	return c
+}
+
+// lookup returns the parsed metadata of a file, from the cache if the file
+// hasn't changed since it was cached, otherwise by parsing it.
+func (c *metadataCache) lookup(path, rel string, info os.FileInfo) (*ParsedFile, error) {
+	c.seen[rel] = true
+
+	if e, ok := c.Files[rel]; ok && e.ModTime == info.ModTime().UnixNano() && e.Size == info.Size() {
+		return e.parsedFile(path), nil
+	}
+
+	parsed, err := ParseFile(path)
+	if err != nil {
+		if _, ok := c.Files[rel]; ok {
+			delete(c.Files, rel)
+			c.dirty = true
+		}
+		return nil, err
+	}
+
+	c.Files[rel] = newCacheEntry(parsed, info)
+	c.dirty = true
+
+	// Callers only get metadata, whether it came from the cache or not
+	parsed.Body = ""
+	parsed.Comments = nil
+	return parsed, nil
+}
+
+// save drops entries of deleted files and writes the cache if it changed.
+func (c *metadataCache) save() error {
+	for rel := range c.Files {
+		if !c.seen[rel] {
+			delete(c.Files, rel)
+			c.dirty = true
+		}
+	}
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: write to temp file, then rename
+	tmpPath := c.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata cache: %w", err)
+	}
+	return os.Rename(tmpPath, c.path)
+}
+
+// WalkMetadata is like WalkParsedFiles but only provides frontmatter and title;
+// Body and Comments are left empty. Metadata is read from a per-repo cache and
+// files are only parsed when their size or modification time changed, so it is
+// much faster than WalkParsedFiles on large roots.
+func WalkMetadata(filters WalkFilters, callback func(*ParsedFile) error) error {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return err
+	}
+
+	caches := make(map[string]*metadataCache)
+	walkErr := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip directories we can't read
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			// Skip hidden directories (backups, archives, internal state)
+			if strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			// Skip downloaded attachments beside item files (owner/repo/<type>/assets)
+			if d.Name() == assets.DirName && len(parts) == 4 {
+				return filepath.SkipDir
+			}
+			// Skip other repositories without reading them
+			if filters.Repo != "" && len(parts) <= 2 {
+				want := strings.Split(filters.Repo, "/")
+				if len(want) == 2 && !strings.EqualFold(parts[len(parts)-1], want[len(parts)-1]) {
+					return filepath.SkipDir
+				}
+			}
+			// Load the cache of every walked repository, so deletions are noticed
+			// even when no item file is left
+			if len(parts) == 2 {
+				caches[path] = loadCache(path)
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".md") {
+			return nil
+		}
+
+		var parsed *ParsedFile
+		if len(parts) < 3 {
+			// Not inside a repository directory; nothing to cache it in
+			parsed, err = ParseFile(path)
+		} else {
+			c := caches[filepath.Join(root, parts[0], parts[1])]
+			info, infoErr := d.Info()
+			if infoErr != nil {
+				return nil
+			}
+			parsed, err = c.lookup(path, strings.Join(parts[2:], "/"), info)
+		}
+		if err != nil {
+			return nil // Skip files that can't be parsed
+		}
+
+		// Apply repo filter
+		if filters.Repo != "" && parsed.Owner+"/"+parsed.Repo != filters.Repo {
+			return nil
+		}
+
+		return callback(parsed)
+	})
+
+	// Save caches even if the callback stopped the walk early; only fully
+	// walked caches know which files were deleted.
+	for _, c := range caches {
+		if walkErr != nil {
+			for rel := range c.Files {
+				c.seen[rel] = true
+			}
+		}
+		if err := c.save(); err != nil && walkErr == nil {
+			walkErr = err
+		}
+	}
+
+	return walkErr
+}
+
+// RefreshMetadataCache brings the metadata cache of a repository ("owner/repo")
+// up to date, or of every repository if repo is empty.
+func RefreshMetadataCache(repo string) error {
+	return WalkMetadata(WalkFilters{Repo: repo}, func(*ParsedFile) error { return nil })
+}
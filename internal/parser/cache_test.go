@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+const cacheTestItem = `---
+id: I_1
+owner: o
+repo: r
+number: 1
+updated: 2026-01-01T00:00:00Z
+state: open
+labels: [bug]
+---
+
+<!-- gh-md:content -->
+# Original title
+Body
+<!-- /gh-md:content -->
+`
+
+func walkTitles(t *testing.T, repo string) map[string]string {
+	t.Helper()
+	titles := make(map[string]string)
+	err := WalkMetadata(WalkFilters{Repo: repo}, func(parsed *ParsedFile) error {
+		if parsed.Body != "" || parsed.Comments != nil {
+			t.Errorf("WalkMetadata() returned body or comments for %s", parsed.FilePath)
+		}
+		titles[filepath.Base(parsed.FilePath)] = parsed.Title
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkMetadata() error = %v", err)
+	}
+	return titles
+}
+
+func TestWalkMetadata_Cache(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	dir := filepath.Join(root, "o", "r", "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "1.md")
+	if err := os.WriteFile(path, []byte(cacheTestItem), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := walkTitles(t, "o/r"); got["1.md"] != "Original title" {
+		t.Fatalf("first walk = %v", got)
+	}
+
+	// Tamper with the cache to prove it is read instead of the file
+	cachePath := filepath.Join(root, "o", "r", CacheFileName)
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatalf("cache not written: %v", err)
+	}
+	var c metadataCache
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatal(err)
+	}
+	entry := c.Files["issues/1.md"]
+	if entry == nil || len(entry.Labels) != 1 || entry.Labels[0] != "bug" {
+		t.Fatalf("cache entry = %+v", entry)
+	}
+	entry.Title = "Cached title"
+	data, _ = json.Marshal(&c)
+	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := walkTitles(t, ""); got["1.md"] != "Cached title" {
+		t.Errorf("walk with unchanged file = %v, want cached title", got)
+	}
+
+	// A changed mtime invalidates the entry
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if got := walkTitles(t, ""); got["1.md"] != "Original title" {
+		t.Errorf("walk after touching file = %v, want re-parsed title", got)
+	}
+
+	// Deleted files are dropped from the cache
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := RefreshMetadataCache("o/r"); err != nil {
+		t.Fatalf("RefreshMetadataCache() error = %v", err)
+	}
+	if got := walkTitles(t, ""); len(got) != 0 {
+		t.Errorf("walk after delete = %v, want none", got)
+	}
+	data, _ = os.ReadFile(cachePath)
+	c = metadataCache{}
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Files) != 0 {
+		t.Errorf("cache after delete = %s", data)
+	}
+}
+
+func TestWalkMetadata_RepoFilter(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	for _, repo := range []string{"o/r", "o/other"} {
+		dir := filepath.Join(root, filepath.FromSlash(repo), "issues")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "1.md"), []byte(cacheTestItem), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := walkTitles(t, "o/r"); len(got) != 1 {
+		t.Errorf("walk o/r = %v, want 1 item", got)
+	}
+	if _, err := os.Stat(filepath.Join(root, "o", "other", CacheFileName)); !os.IsNotExist(err) {
+		t.Error("filtered-out repository should not be read")
+	}
+}
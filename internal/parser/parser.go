@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackchuka/gh-md/internal/assets"
 	"github.com/jackchuka/gh-md/internal/config"
 	"github.com/jackchuka/gh-md/internal/github"
 	"github.com/jackchuka/gh-md/internal/writer"
@@ -18,29 +19,48 @@ type ParsedComment struct {
 	ID       string // empty = new comment
 	Author   string
 	Body     string
-	ParentID string // for discussion replies (derived from indentation)
+	Created  time.Time // zero for new comments
+	ParentID string    // for discussion replies (derived from indentation)
+	LocalID  string    // client-side ID of a new-comment draft, set once push starts
+	Draft    bool      // From a new-comment block, posted or not
 }
 
 // ParsedFile represents a parsed markdown file.
 type ParsedFile struct {
 	// From frontmatter
-	ID        string
-	Owner     string
-	Repo      string
-	Number    int
-	Updated   time.Time // For conflict detection
-	State     string    // open/closed from frontmatter
-	Author    string
-	Assignees []string
-	Reviewers []string
-	Labels    []string
-	Created   time.Time
+	SchemaVersion int // 0 = written before schema versioning
+	ID            string
+	Owner         string
+	Repo          string
+	Number        int
+	Updated       time.Time // For conflict detection
+	State         string    // open/closed from frontmatter
+	Closed        time.Time // Zero while open, or for files pulled before it was recorded
+	Author        string
+	Assignees     []string
+	Reviewers     []string
+	Labels        []string
+	Created       time.Time
+	LastPulled    time.Time
+	Draft         bool   // Pull requests only
+	HeadRef       string // Pull requests only
+	BaseRef       string // Pull requests only
+	Category      string // Discussions only
+	Parent        int    // Parent issue number, 0 if none
+	Children      []int  // Sub-issue numbers
+	Reactions     int    // Reactions on the item itself
+	Pinned        bool   // Issues only
 
 	// From content
-	Title    string
-	Body     string
-	ItemType github.ItemType
-	Comments []ParsedComment // Parsed from comments section
+	Title        string
+	Body         string
+	ItemType     github.ItemType
+	Comments     []ParsedComment // Parsed from comments section
+	CommentCount int             // Posted comments; also set when Comments is not loaded
+
+	// Local modification tracking
+	ContentHash string // Hash recorded at pull time, empty for files pulled before tracking
+	Modified    bool   // Content differs from ContentHash
 
 	// Original file path
 	FilePath string
@@ -49,9 +69,21 @@ type ParsedFile struct {
 // frontmatter represents the YAML frontmatter structure.
 type frontmatter struct {
 	writer.BaseFrontmatter `yaml:",inline"`
-	Assignees              []string `yaml:"assignees"`
-	Reviewers              []string `yaml:"reviewers"`
-	Labels                 []string `yaml:"labels"`
+	Assignees              []string   `yaml:"assignees"`
+	Reviewers              []string   `yaml:"reviewers"`
+	Labels                 []string   `yaml:"labels"`
+	Draft                  bool       `yaml:"draft"`
+	Pinned                 bool       `yaml:"pinned"`
+	HeadRef                string     `yaml:"head_ref"`
+	BaseRef                string     `yaml:"base_ref"`
+	Category               string     `yaml:"category"`
+	Parent                 *issueRef  `yaml:"parent"`
+	Children               []issueRef `yaml:"children"`
+}
+
+// issueRef is a parent or sub-issue reference in frontmatter.
+type issueRef struct {
+	Number int `yaml:"number"`
 }
 
 // ParseFile parses a markdown file and returns structured data.
@@ -61,15 +93,20 @@ func ParseFile(path string) (*ParsedFile, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	return parseContent(string(content), path)
+	return ParseContent(string(content), path)
 }
 
-func parseContent(content, path string) (*ParsedFile, error) {
+// ParseContent parses markdown content as if it were read from path.
+// The path is used to detect the item type.
+func ParseContent(content, path string) (*ParsedFile, error) {
 	// Extract frontmatter
 	fm, rest, err := extractFrontmatter(content)
 	if err != nil {
 		return nil, err
 	}
+	if fm.SchemaVersion > writer.SchemaVersion {
+		return nil, fmt.Errorf("%s has schema version %d, newer than supported %d (upgrade gh-md)", path, fm.SchemaVersion, writer.SchemaVersion)
+	}
 
 	// Extract title and body
 	title, body := extractTitleAndBody(rest)
@@ -77,47 +114,66 @@ func parseContent(content, path string) (*ParsedFile, error) {
 	// Extract comments
 	comments := parseComments(rest)
 
+	commentCount := 0
+	for _, c := range comments {
+		if c.ID != "" {
+			commentCount++
+		}
+	}
+
 	// Detect item type from path
 	itemType := detectItemType(path)
 
+	parent := 0
+	if fm.Parent != nil {
+		parent = fm.Parent.Number
+	}
+	var children []int
+	for _, c := range fm.Children {
+		children = append(children, c.Number)
+	}
+
 	return &ParsedFile{
-		ID:        fm.ID,
-		Owner:     fm.Owner,
-		Repo:      fm.Repo,
-		Number:    fm.Number,
-		Updated:   fm.Updated,
-		State:     fm.State,
-		Author:    fm.Author,
-		Assignees: fm.Assignees,
-		Reviewers: fm.Reviewers,
-		Labels:    fm.Labels,
-		Created:   fm.Created,
-		Title:     title,
-		Body:      body,
-		ItemType:  itemType,
-		Comments:  comments,
-		FilePath:  path,
+		SchemaVersion: fm.SchemaVersion,
+		ID:            fm.ID,
+		Owner:         fm.Owner,
+		Repo:          fm.Repo,
+		Number:        fm.Number,
+		Updated:       fm.Updated,
+		State:         fm.State,
+		Author:        fm.Author,
+		Assignees:     fm.Assignees,
+		Reviewers:     fm.Reviewers,
+		Labels:        fm.Labels,
+		Created:       fm.Created,
+		LastPulled:    fm.LastPulled,
+		Closed:        fm.Closed,
+		Draft:         fm.Draft,
+		HeadRef:       fm.HeadRef,
+		BaseRef:       fm.BaseRef,
+		Category:      fm.Category,
+		Parent:        parent,
+		Children:      children,
+		Reactions:     fm.Reactions,
+		Pinned:        fm.Pinned,
+		Title:         title,
+		Body:          body,
+		ItemType:      itemType,
+		Comments:      comments,
+		CommentCount:  commentCount,
+		ContentHash:   writer.StoredContentHash(content),
+		Modified:      writer.IsModified(content),
+		FilePath:      path,
 	}, nil
 }
 
 func extractFrontmatter(content string) (*frontmatter, string, error) {
-	// Normalize CRLF to LF for consistent parsing
-	content = strings.ReplaceAll(content, "\r\n", "\n")
-
 	// Frontmatter is between --- markers
-	if !strings.HasPrefix(content, "---\n") {
-		return nil, "", fmt.Errorf("file does not start with frontmatter")
-	}
-
-	// Find the closing ---
-	endIndex := strings.Index(content[4:], "\n---\n")
-	if endIndex == -1 {
-		return nil, "", fmt.Errorf("frontmatter not closed")
+	fmContent, rest, err := writer.SplitFrontmatter(content)
+	if err != nil {
+		return nil, "", err
 	}
 
-	fmContent := content[4 : 4+endIndex]
-	rest := content[4+endIndex+5:] // Skip past closing ---\n
-
 	var fm frontmatter
 	if err := yaml.Unmarshal([]byte(fmContent), &fm); err != nil {
 		return nil, "", fmt.Errorf("failed to parse frontmatter: %w", err)
@@ -311,6 +367,7 @@ func parseMarkerComments(content string) []commentWithDepth {
 
 // parseNewCommentMarkers parses new comments within <!-- gh-md:new-comment --> markers.
 // Supports reply_to attribute for discussion replies: <!-- gh-md:new-comment reply_to: DC_xxx -->
+// Drafts already posted by an unfinished push carry the created comment ID: posted: IC_xxx
 func parseNewCommentMarkers(content string, _ []commentWithDepth) []commentWithDepth {
 	var result []commentWithDepth
 
@@ -336,18 +393,8 @@ func parseNewCommentMarkers(content string, _ []commentWithDepth) []commentWithD
 		}
 		closeIdx += tagEndIdx
 
-		// Extract the opening tag to check for reply_to
-		openingTag := remaining[startIdx : tagEndIdx+3]
-		var parentID string
-		if replyIdx := strings.Index(openingTag, "reply_to:"); replyIdx != -1 {
-			// Extract the reply_to value
-			afterReplyTo := openingTag[replyIdx+9:]
-			endOfValue := strings.Index(afterReplyTo, "-->")
-			if endOfValue == -1 {
-				endOfValue = len(afterReplyTo)
-			}
-			parentID = strings.TrimSpace(afterReplyTo[:endOfValue])
-		}
+		// Extract the opening tag to check for reply_to, local_id and posted
+		attrs := writer.ParseNewCommentTag(remaining[startIdx : tagEndIdx+3])
 
 		// Extract body between tags
 		bodyStart := tagEndIdx + 3 // after -->
@@ -355,9 +402,11 @@ func parseNewCommentMarkers(content string, _ []commentWithDepth) []commentWithD
 
 		if body != "" {
 			comment := ParsedComment{
-				ID:       "", // New comment, no ID
+				ID:       attrs.Posted, // Empty until a push posts the draft
 				Body:     body,
-				ParentID: parentID,
+				ParentID: attrs.ReplyTo,
+				LocalID:  attrs.LocalID,
+				Draft:    true,
 			}
 
 			result = append(result, commentWithDepth{
@@ -436,6 +485,12 @@ func assignParentIDs(commentsWithDepth []commentWithDepth) []ParsedComment {
 
 // parseCommentBlock parses a single comment block.
 func parseCommentBlock(block string) *ParsedComment {
+	return parseTaggedBlock(block, commentStart, commentEnd)
+}
+
+// parseTaggedBlock parses a comment-like block opened with startTag (followed by
+// metadata lines) and closed with endTag.
+func parseTaggedBlock(block, startTag, endTag string) *ParsedComment {
 	// Extract metadata from the opening tag
 	// Format: <!-- gh-md:comment\nid: xxx\nauthor: xxx\ncreated: xxx\n-->
 	metaEndIdx := strings.Index(block, "-->")
@@ -443,16 +498,19 @@ func parseCommentBlock(block string) *ParsedComment {
 		return nil
 	}
 
-	metaSection := block[len(commentStart):metaEndIdx]
+	metaSection := block[len(startTag):metaEndIdx]
 	metaLines := strings.Split(strings.TrimSpace(metaSection), "\n")
 
 	var id, author, parentID string
+	var created time.Time
 	for _, line := range metaLines {
 		line = strings.TrimSpace(line)
 		if strings.HasPrefix(line, "id:") {
 			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
 		} else if strings.HasPrefix(line, "author:") {
 			author = strings.TrimSpace(strings.TrimPrefix(line, "author:"))
+		} else if strings.HasPrefix(line, "created:") {
+			created, _ = time.Parse(time.RFC3339, strings.TrimSpace(strings.TrimPrefix(line, "created:")))
 		} else if strings.HasPrefix(line, "parent:") {
 			parentID = strings.TrimSpace(strings.TrimPrefix(line, "parent:"))
 		}
@@ -460,7 +518,7 @@ func parseCommentBlock(block string) *ParsedComment {
 
 	// Extract body (content after metadata, before closing tag)
 	bodyStart := metaEndIdx + 3 // Skip past -->
-	bodyEndIdx := strings.Index(block, commentEnd)
+	bodyEndIdx := strings.Index(block, endTag)
 	if bodyEndIdx == -1 {
 		return nil
 	}
@@ -474,6 +532,7 @@ func parseCommentBlock(block string) *ParsedComment {
 		ID:       id,
 		Author:   author,
 		Body:     body,
+		Created:  created,
 		ParentID: parentID,
 	}
 }
@@ -521,6 +580,18 @@ func WalkParsedFiles(filters WalkFilters, callback func(*ParsedFile) error) erro
 			return nil // Skip directories we can't read
 		}
 
+		// Skip hidden directories (backups, archives, internal state)
+		if d.IsDir() && path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		// Skip downloaded attachments beside item files (owner/repo/<type>/assets)
+		if d.IsDir() && d.Name() == assets.DirName {
+			if rel, err := filepath.Rel(root, path); err == nil && len(strings.Split(filepath.ToSlash(rel), "/")) == 4 {
+				return filepath.SkipDir
+			}
+		}
+
 		// Only process .md files
 		if d.IsDir() || !strings.HasSuffix(path, ".md") {
 			return nil
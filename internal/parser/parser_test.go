@@ -3,7 +3,12 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/writer"
 )
 
 func TestParseComments_ExistingMarkerComment(t *testing.T) {
@@ -35,14 +40,17 @@ Existing comment body
 
 ---
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if len(parsed.Comments) != 1 {
 		t.Fatalf("expected 1 comment, got %d", len(parsed.Comments))
 	}
+	if parsed.CommentCount != 1 {
+		t.Errorf("expected comment count 1, got %d", parsed.CommentCount)
+	}
 
 	c := parsed.Comments[0]
 	if c.ID != "IC_abc123" {
@@ -51,6 +59,9 @@ Existing comment body
 	if c.Body != "Existing comment body" {
 		t.Errorf("expected body 'Existing comment body', got %q", c.Body)
 	}
+	if want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC); !c.Created.Equal(want) {
+		t.Errorf("expected created %v, got %v", want, c.Created)
+	}
 	if c.ParentID != "" {
 		t.Errorf("expected no ParentID, got %q", c.ParentID)
 	}
@@ -87,9 +98,9 @@ Existing comment
 New comment using marker format
 <!-- /gh-md:new-comment -->
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if len(parsed.Comments) != 2 {
@@ -142,9 +153,9 @@ Existing comment
 
 <!-- /gh-md:new-comment -->
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	// Should only have 1 comment (the existing one), empty marker ignored
@@ -177,9 +188,9 @@ First new comment
 Second new comment
 <!-- /gh-md:new-comment -->
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if len(parsed.Comments) != 2 {
@@ -225,9 +236,9 @@ Parent comment
   This is a reply
   <!-- /gh-md:new-comment -->
 `
-	parsed, err := parseContent(content, "discussions/1.md")
+	parsed, err := ParseContent(content, "discussions/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if len(parsed.Comments) != 2 {
@@ -243,6 +254,50 @@ Parent comment
 	}
 }
 
+func TestParseComments_LocalIDAndPosted(t *testing.T) {
+	content := `---
+id: D_123
+owner: test
+repo: demo
+number: 1
+updated: 2026-01-01T00:00:00Z
+---
+
+<!-- gh-md:content -->
+# Title
+Body
+<!-- /gh-md:content -->
+
+---
+
+<!-- gh-md:new-comment reply_to: DC_parent local_id: lc_0001 posted: DC_new -->
+Already posted
+<!-- /gh-md:new-comment -->
+
+<!-- gh-md:new-comment local_id: lc_0002 -->
+Not posted yet
+<!-- /gh-md:new-comment -->
+`
+	parsed, err := ParseContent(content, "discussions/1.md")
+	if err != nil {
+		t.Fatalf("ParseContent failed: %v", err)
+	}
+
+	if len(parsed.Comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(parsed.Comments))
+	}
+
+	posted := parsed.Comments[0]
+	if posted.ID != "DC_new" || posted.ParentID != "DC_parent" || posted.LocalID != "lc_0001" {
+		t.Errorf("posted draft = %+v, want ID DC_new, ParentID DC_parent, LocalID lc_0001", posted)
+	}
+
+	pending := parsed.Comments[1]
+	if pending.ID != "" || pending.ParentID != "" || pending.LocalID != "lc_0002" {
+		t.Errorf("pending draft = %+v, want no ID, no ParentID, LocalID lc_0002", pending)
+	}
+}
+
 func TestParseComments_NoNewCommentMarker(t *testing.T) {
 	// File with no new comment markers - only existing comments
 	content := `---
@@ -271,9 +326,9 @@ created: 2026-01-01T00:00:00Z
 Existing comment
 <!-- /gh-md:comment -->
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	// Should have 1 comment (the existing one)
@@ -304,9 +359,9 @@ Body
 New comment on item with no existing comments
 <!-- /gh-md:new-comment -->
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if len(parsed.Comments) != 1 {
@@ -318,6 +373,14 @@ New comment on item with no existing comments
 	}
 }
 
+func TestParseContent_NewerSchemaVersion(t *testing.T) {
+	content := "---\nschema_version: 99\nid: I_1\n---\n\n<!-- gh-md:content -->\n# Title\n<!-- /gh-md:content -->\n"
+	_, err := ParseContent(content, "o/r/issues/1.md")
+	if err == nil || !strings.Contains(err.Error(), "upgrade gh-md") {
+		t.Errorf("ParseContent() error = %v, want an upgrade error", err)
+	}
+}
+
 func TestParseState(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -388,9 +451,9 @@ Body
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			parsed, err := parseContent(tt.content, "issues/1.md")
+			parsed, err := ParseContent(tt.content, "issues/1.md")
 			if err != nil {
-				t.Fatalf("parseContent failed: %v", err)
+				t.Fatalf("ParseContent failed: %v", err)
 			}
 
 			if parsed.State != tt.expected {
@@ -419,9 +482,9 @@ With multiple lines.
 
 ---
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if parsed.Title != "My Issue Title" {
@@ -468,9 +531,9 @@ Comment body
 
 ---
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	// Should only find 1 comment (the actual comment, not the wrapper)
@@ -542,9 +605,9 @@ my reply to the review thread
 
 <!-- /gh-md:review-thread -->
 `
-	parsed, err := parseContent(content, "pulls/1.md")
+	parsed, err := ParseContent(content, "pulls/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	// Should have 1 new comment (the reply) - review comments are read-only
@@ -670,9 +733,9 @@ It has paragraphs.
 And more content.
 <!-- /gh-md:new-comment -->
 `
-	parsed, err := parseContent(content, "issues/1.md")
+	parsed, err := ParseContent(content, "issues/1.md")
 	if err != nil {
-		t.Fatalf("parseContent failed: %v", err)
+		t.Fatalf("ParseContent failed: %v", err)
 	}
 
 	if len(parsed.Comments) != 1 {
@@ -684,3 +747,122 @@ And more content.
 		t.Errorf("expected body:\n%q\ngot:\n%q", expectedBody, parsed.Comments[0].Body)
 	}
 }
+
+func TestWalkParsedFiles_SkipsHiddenDirs(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	content := `---
+schema_version: 1
+id: I_1
+owner: owner
+repo: repo
+number: 1
+state: open
+---
+
+<!-- gh-md:content -->
+# Title
+<!-- /gh-md:content -->
+`
+	for _, dir := range []string{
+		filepath.Join(root, "owner", "repo", "issues"),
+		filepath.Join(root, ".gh-md-backup", "20260101-000000", "owner", "repo", "issues"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "1.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	var paths []string
+	err := WalkParsedFiles(WalkFilters{}, func(p *ParsedFile) error {
+		paths = append(paths, p.FilePath)
+		if p.SchemaVersion != 1 {
+			t.Errorf("SchemaVersion = %d, want 1", p.SchemaVersion)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkParsedFiles() error = %v", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("WalkParsedFiles() visited %v, want only the non-hidden file", paths)
+	}
+}
+
+func TestParseContent_Modified(t *testing.T) {
+	content := writer.StampContentHash(`---
+id: I_1
+owner: test
+repo: demo
+number: 1
+---
+
+<!-- gh-md:content -->
+# Title
+Body
+<!-- /gh-md:content -->
+`)
+
+	parsed, err := ParseContent(content, "issues/1.md")
+	if err != nil {
+		t.Fatalf("ParseContent failed: %v", err)
+	}
+	if parsed.ContentHash == "" {
+		t.Error("expected ContentHash to be set")
+	}
+	if parsed.Modified {
+		t.Error("expected unmodified file")
+	}
+
+	parsed, err = ParseContent(strings.Replace(content, "Body", "Edited", 1), "issues/1.md")
+	if err != nil {
+		t.Fatalf("ParseContent failed: %v", err)
+	}
+	if !parsed.Modified {
+		t.Error("expected modified file")
+	}
+}
+
+func TestParseContent_ItemTypeFields(t *testing.T) {
+	content := `---
+id: PR_1
+owner: test
+repo: demo
+number: 5
+state: open
+last_pulled: 2026-02-01T00:00:00Z
+draft: true
+head_ref: feature
+base_ref: main
+category: Q&A
+parent:
+  number: 2
+  title: Epic
+children:
+  - number: 6
+  - number: 7
+---
+
+<!-- gh-md:content -->
+# Title
+<!-- /gh-md:content -->
+`
+	parsed, err := ParseContent(content, "pulls/5.md")
+	if err != nil {
+		t.Fatalf("ParseContent failed: %v", err)
+	}
+
+	if !parsed.Draft || parsed.HeadRef != "feature" || parsed.BaseRef != "main" || parsed.Category != "Q&A" {
+		t.Errorf("unexpected fields: %+v", parsed)
+	}
+	if parsed.Parent != 2 || len(parsed.Children) != 2 || parsed.Children[1] != 7 {
+		t.Errorf("expected parent 2 and children [6 7], got %d %v", parsed.Parent, parsed.Children)
+	}
+	if !parsed.LastPulled.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected last_pulled %v", parsed.LastPulled)
+	}
+}
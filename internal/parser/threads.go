@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	reviewThreadStart  = "<!-- gh-md:review-thread\n"
+	reviewThreadEnd    = "<!-- /gh-md:review-thread -->"
+	reviewCommentStart = "<!-- gh-md:review-comment\n"
+	reviewCommentEnd   = "<!-- /gh-md:review-comment -->"
+)
+
+// ReviewThread is a pull request review thread stored in an item file.
+type ReviewThread struct {
+	ID       string
+	Path     string
+	Line     int
+	Comments []ParsedComment
+}
+
+// ParseReviewThreads extracts the review threads of a pull request file's content.
+func ParseReviewThreads(content string) []ReviewThread {
+	var threads []ReviewThread
+
+	remaining := content
+	for {
+		startIdx := strings.Index(remaining, reviewThreadStart)
+		if startIdx == -1 {
+			break
+		}
+		endIdx := strings.Index(remaining[startIdx:], reviewThreadEnd)
+		if endIdx == -1 {
+			break
+		}
+		endIdx += startIdx
+
+		block := remaining[startIdx:endIdx]
+		remaining = remaining[endIdx+len(reviewThreadEnd):]
+
+		metaEndIdx := strings.Index(block, "-->")
+		if metaEndIdx == -1 {
+			continue
+		}
+
+		var thread ReviewThread
+		for _, line := range strings.Split(block[len(reviewThreadStart):metaEndIdx], "\n") {
+			key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+			if !ok {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch key {
+			case "id":
+				thread.ID = value
+			case "path":
+				thread.Path = value
+			case "line":
+				thread.Line, _ = strconv.Atoi(value)
+			}
+		}
+		if thread.ID == "" {
+			continue
+		}
+
+		rest := block[metaEndIdx:]
+		for {
+			cStart := strings.Index(rest, reviewCommentStart)
+			if cStart == -1 {
+				break
+			}
+			cEnd := strings.Index(rest[cStart:], reviewCommentEnd)
+			if cEnd == -1 {
+				break
+			}
+			cEnd += cStart + len(reviewCommentEnd)
+
+			if c := parseTaggedBlock(rest[cStart:cEnd], reviewCommentStart, reviewCommentEnd); c != nil {
+				c.ParentID = thread.ID
+				thread.Comments = append(thread.Comments, *c)
+			}
+			rest = rest[cEnd:]
+		}
+
+		threads = append(threads, thread)
+	}
+
+	return threads
+}
@@ -0,0 +1,56 @@
+package parser
+
+import "testing"
+
+func TestParseReviewThreads(t *testing.T) {
+	content := "<!-- gh-md:review-thread\n" +
+		"id: PRRT_1\n" +
+		"path: main.go\n" +
+		"line: 12\n" +
+		"-->\n" +
+		"### `main.go:12`\n\n" +
+		"<!-- gh-md:review-comment\n" +
+		"id: PRRC_1\n" +
+		"author: alice\n" +
+		"created: 2026-01-01T00:00:00Z\n" +
+		"-->\n" +
+		"#### @alice (2026-01-01)\n\n" +
+		"Why not a map?\n" +
+		"<!-- /gh-md:review-comment -->\n\n" +
+		"<!-- gh-md:review-comment\n" +
+		"id: PRRC_2\n" +
+		"author: bob\n" +
+		"created: 2026-01-02T00:00:00Z\n" +
+		"-->\n" +
+		"#### @bob (2026-01-02)\n\n" +
+		"Order matters here.\n" +
+		"<!-- /gh-md:review-comment -->\n\n" +
+		"<!-- gh-md:new-comment reply_to: PRRT_1 -->\n\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- /gh-md:review-thread -->\n\n" +
+		"<!-- gh-md:review-thread\n" +
+		"id: PRRT_2\n" +
+		"path: README.md\n" +
+		"line: 3\n" +
+		"-->\n" +
+		"<!-- /gh-md:review-thread -->\n"
+
+	threads := ParseReviewThreads(content)
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(threads))
+	}
+
+	th := threads[0]
+	if th.ID != "PRRT_1" || th.Path != "main.go" || th.Line != 12 {
+		t.Errorf("unexpected thread metadata: %+v", th)
+	}
+	if len(th.Comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(th.Comments))
+	}
+	if c := th.Comments[1]; c.ID != "PRRC_2" || c.Author != "bob" || c.Body != "Order matters here." || c.ParentID != "PRRT_1" {
+		t.Errorf("unexpected comment: %+v", c)
+	}
+
+	if threads[1].ID != "PRRT_2" || len(threads[1].Comments) != 0 {
+		t.Errorf("unexpected second thread: %+v", threads[1])
+	}
+}
@@ -1,11 +1,23 @@
 package prune
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/google/cel-go/cel"
+
+	"github.com/jackchuka/gh-md/internal/archive"
+	"github.com/jackchuka/gh-md/internal/assets"
+	"github.com/jackchuka/gh-md/internal/config"
 	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/meta"
 	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/jackchuka/gh-md/internal/writer"
 )
 
 // PruneResult represents a file that can be pruned.
@@ -16,6 +28,7 @@ type PruneResult struct {
 	State    string
 	Owner    string
 	Repo     string
+	Rule     string // The prune rule that matched, e.g. "closed over 30 days ago"
 }
 
 // RelativePath returns the path relative to the gh-md root for display.
@@ -24,33 +37,80 @@ func (p *PruneResult) RelativePath() string {
 	return filepath.Join(p.Owner, p.Repo, dirName, filepath.Base(p.Path))
 }
 
-// FindPrunableFiles walks the gh-md root and returns files that should be pruned.
+// Options adjusts how FindPrunableFiles applies each repository's prune policy.
+type Options struct {
+	// Policy overrides fields of the configured policy, e.g. from flags.
+	Policy *config.PrunePolicy
+	// Filter, if set, also prunes items matching this CEL expression.
+	Filter cel.Program
+	// CurrentUser returns the user's login, for keep_assigned and the
+	// filter's user variable. It is called at most once, and only if needed.
+	CurrentUser func() (string, error)
+}
+
+// FindPrunableFiles returns files that should be pruned, using the metadata cache.
 // If repoFilter is non-empty (format: "owner/repo"), only files from that repo are included.
-// Prunable files are:
-// - Issues with state == "closed"
-// - Pull requests with state == "merged" or state == "closed"
-// Discussions are never pruned.
-func FindPrunableFiles(repoFilter string) ([]PruneResult, error) {
+// Each repository's prune policy, with opts.Policy applied on top, decides which
+// files are prunable:
+//   - Closed issues and discussions, and merged or closed pull requests, that were
+//     closed more than prune.closed_days ago
+//   - Open items not updated in prune.stale_months, if set
+//   - Items matching opts.Filter
+//
+// Items carrying one of prune.keep_labels, assigned to the current user with
+// prune.keep_assigned, or pinned with prune.keep_pinned are always kept, as are
+// item types excluded by prune.types. Files with unpushed local changes and
+// drafts not created on GitHub yet are never pruned.
+func FindPrunableFiles(repoFilter string, opts Options) ([]PruneResult, error) {
 	var results []PruneResult
+	matchers := make(map[string]*matcher)
+	now := time.Now()
 
-	err := parser.WalkParsedFiles(parser.WalkFilters{Repo: repoFilter}, func(parsed *parser.ParsedFile) error {
+	user, userErr, userLoaded := "", error(nil), false
+	currentUser := func() (string, error) {
+		if !userLoaded && opts.CurrentUser != nil {
+			user, userErr = opts.CurrentUser()
+			userLoaded = true
+		}
+		return user, userErr
+	}
+
+	err := parser.WalkMetadata(parser.WalkFilters{Repo: repoFilter}, func(parsed *parser.ParsedFile) error {
 		// Skip if no state information
 		if parsed.State == "" {
 			return nil
 		}
+		// Deleting these would lose local work
+		if parsed.Modified || parsed.ID == "" {
+			return nil
+		}
 
-		// Determine if this file should be pruned based on item type and state
-		shouldPrune := false
-		switch parsed.ItemType {
-		case github.ItemTypeIssue, github.ItemTypeDiscussion:
-			// Prune closed issues and discussions
-			shouldPrune = parsed.State == "closed"
-		case github.ItemTypePullRequest:
-			// Prune merged or closed PRs
-			shouldPrune = parsed.State == "merged" || parsed.State == "closed"
+		// Resolve the repo's prune policy
+		repoKey := parsed.Owner + "/" + parsed.Repo
+		m, ok := matchers[repoKey]
+		if !ok {
+			settings, err := meta.ResolveSettings(parsed.Owner, parsed.Repo)
+			if err != nil {
+				return err
+			}
+			settings = settings.Merge(&config.Settings{Prune: opts.Policy})
+			m = &matcher{settings: settings, filter: opts.Filter, now: now}
+			if isSet(m.policy().KeepAssigned) {
+				if m.user, err = currentUser(); err != nil {
+					return err
+				}
+			} else if opts.Filter != nil {
+				// Pruning works offline; the filter's 'user' just won't match anyone
+				m.user, _ = currentUser()
+			}
+			matchers[repoKey] = m
 		}
 
-		if shouldPrune {
+		rule, err := m.rule(parsed)
+		if err != nil {
+			return err
+		}
+		if rule != "" {
 			results = append(results, PruneResult{
 				Path:     parsed.FilePath,
 				ItemType: parsed.ItemType,
@@ -58,6 +118,7 @@ func FindPrunableFiles(repoFilter string) ([]PruneResult, error) {
 				State:    parsed.State,
 				Owner:    parsed.Owner,
 				Repo:     parsed.Repo,
+				Rule:     rule,
 			})
 		}
 
@@ -71,6 +132,260 @@ func FindPrunableFiles(repoFilter string) ([]PruneResult, error) {
 	return results, nil
 }
 
+// matcher applies one repository's prune policy.
+type matcher struct {
+	settings *config.Settings
+	filter   cel.Program
+	user     string
+	now      time.Time
+}
+
+func (m *matcher) policy() *config.PrunePolicy {
+	if m.settings.Prune == nil {
+		return &config.PrunePolicy{}
+	}
+	return m.settings.Prune
+}
+
+// rule returns the rule under which parsed is pruned, or "" if it is kept.
+func (m *matcher) rule(parsed *parser.ParsedFile) (string, error) {
+	if name, ok := parsed.ItemType.FlagName(); ok && !m.settings.PruneIncludesType(name) {
+		return "", nil
+	}
+
+	// Keep rules win over every prune rule
+	policy := m.policy()
+	for _, label := range parsed.Labels {
+		if slices.ContainsFunc(policy.KeepLabels, func(keep string) bool { return strings.EqualFold(keep, label) }) {
+			return "", nil
+		}
+	}
+	if isSet(policy.KeepAssigned) && m.user != "" && slices.Contains(parsed.Assignees, m.user) {
+		return "", nil
+	}
+	if isSet(policy.KeepPinned) && parsed.Pinned {
+		return "", nil
+	}
+
+	state := strings.ToLower(parsed.State)
+	if state == "closed" || state == "merged" {
+		// Files pulled before the close date was recorded fall back to the
+		// last update, which is usually the close itself.
+		closedAt := parsed.Closed
+		if closedAt.IsZero() {
+			closedAt = parsed.Updated
+		}
+		days := intValue(policy.ClosedDays)
+		if days == 0 {
+			return state, nil
+		}
+		if closedAt.Before(m.now.AddDate(0, 0, -days)) {
+			return fmt.Sprintf("%s over %d days ago", state, days), nil
+		}
+	} else if months := intValue(policy.StaleMonths); months > 0 && parsed.Updated.Before(m.now.AddDate(0, -months, 0)) {
+		return fmt.Sprintf("not updated in %d months", months), nil
+	}
+
+	if m.filter != nil {
+		match, err := search.MatchMetadata(m.filter, parsed, m.user)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate filter for %s: %w", parsed.FilePath, err)
+		}
+		if match {
+			return "filter", nil
+		}
+	}
+	return "", nil
+}
+
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
+
+func intValue(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+// StateFetcher fetches the current state of items by node ID.
+type StateFetcher interface {
+	FetchNodeStates(ids []string) (map[string]github.NodeState, error)
+}
+
+// StateChange is a local file whose state was updated by Refresh.
+type StateChange struct {
+	Path     string
+	ItemType github.ItemType
+	Number   int
+	Owner    string
+	Repo     string
+	From     string
+	To       string
+	Skipped  bool // The file has unpushed local changes and was left alone
+}
+
+// RelativePath returns the path relative to the gh-md root for display.
+func (c *StateChange) RelativePath() string {
+	dirName, _ := c.ItemType.DirName()
+	return filepath.Join(c.Owner, c.Repo, dirName, filepath.Base(c.Path))
+}
+
+// Refresh fetches the current state of every prune candidate and every open
+// local item, and updates the state and close date in the frontmatter of files
+// whose state changed on GitHub, so FindPrunableFiles decides on current data.
+// Files with unpushed local changes keep their state and are reported as
+// skipped. Drafts and items that no longer exist on GitHub are left alone.
+func Refresh(fetcher StateFetcher, repoFilter string, opts Options) ([]StateChange, error) {
+	candidates, err := FindPrunableFiles(repoFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	isCandidate := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		isCandidate[c.Path] = true
+	}
+
+	var ids []string
+	files := make(map[string]*parser.ParsedFile)
+	err = parser.WalkMetadata(parser.WalkFilters{Repo: repoFilter}, func(parsed *parser.ParsedFile) error {
+		if parsed.ID == "" || parsed.State == "" {
+			return nil
+		}
+		if strings.EqualFold(parsed.State, "open") || isCandidate[parsed.FilePath] {
+			ids = append(ids, parsed.ID)
+			files[parsed.ID] = parsed
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	remote, err := fetcher.FetchNodeStates(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []StateChange
+	for _, id := range ids {
+		parsed := files[id]
+		state, ok := remote[id]
+		if !ok || strings.EqualFold(parsed.State, state.State) {
+			continue
+		}
+
+		change := StateChange{
+			Path:     parsed.FilePath,
+			ItemType: parsed.ItemType,
+			Number:   parsed.Number,
+			Owner:    parsed.Owner,
+			Repo:     parsed.Repo,
+			From:     strings.ToLower(parsed.State),
+			To:       state.State,
+		}
+		updated, err := updateState(parsed.FilePath, state)
+		if err != nil {
+			return changes, fmt.Errorf("failed to update %s: %w", change.RelativePath(), err)
+		}
+		change.Skipped = !updated
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// updateState writes state to the frontmatter of the item file at path and its
+// base copy. It returns false, without writing, if the file has unpushed changes.
+func updateState(path string, state github.NodeState) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	content := string(data)
+	if writer.IsModified(content) {
+		return false, nil
+	}
+
+	content, err = setState(content, state)
+	if err != nil {
+		return false, err
+	}
+	if err := writer.WriteFile(path, content); err != nil {
+		return false, err
+	}
+
+	base, err := os.ReadFile(writer.BasePath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	content, err = setState(string(base), state)
+	if err != nil {
+		return false, err
+	}
+	return true, writer.WriteFile(writer.BasePath(path), content)
+}
+
+// setState sets the state and close date in file content. Files stamped with a
+// content hash are stamped again, so the change doesn't count as a local edit.
+func setState(content string, state github.NodeState) (string, error) {
+	stamped := writer.StoredContentHash(content) != ""
+
+	content, err := writer.SetFrontmatterField(content, "state", state.State, false)
+	if err != nil {
+		return "", err
+	}
+	if state.ClosedAt.IsZero() {
+		content = writer.RemoveFrontmatterFields(content, "closed")
+	} else if content, err = writer.SetFrontmatterField(content, "closed", state.ClosedAt, false); err != nil {
+		return "", err
+	}
+
+	if stamped {
+		content = writer.StampContentHash(content)
+	}
+	return content, nil
+}
+
+// ArchiveFiles moves the specified files into their repository's archive for the
+// current month, then deletes them. It returns the number of files archived.
+func ArchiveFiles(files []PruneResult) (int, error) {
+	var repoDirs []string
+	byRepo := make(map[string][]PruneResult)
+	for _, f := range files {
+		repoDir := filepath.Dir(filepath.Dir(f.Path))
+		if _, ok := byRepo[repoDir]; !ok {
+			repoDirs = append(repoDirs, repoDir)
+		}
+		byRepo[repoDir] = append(byRepo[repoDir], f)
+	}
+
+	archived := 0
+	now := time.Now()
+	for _, repoDir := range repoDirs {
+		group := byRepo[repoDir]
+		paths := make([]string, len(group))
+		for i, f := range group {
+			paths[i] = f.Path
+		}
+		if err := archive.Add(repoDir, paths, now); err != nil {
+			return archived, fmt.Errorf("failed to archive files: %w", err)
+		}
+		deleted, err := DeleteFiles(group)
+		archived += deleted
+		if err != nil {
+			return archived, err
+		}
+	}
+	return archived, nil
+}
+
 // DeleteFiles deletes the specified files and returns the number of files deleted.
 func DeleteFiles(files []PruneResult) (int, error) {
 	deleted := 0
@@ -78,6 +393,12 @@ func DeleteFiles(files []PruneResult) (int, error) {
 		if err := os.Remove(f.Path); err != nil {
 			return deleted, err
 		}
+		if err := writer.RemoveBase(f.Path); err != nil {
+			return deleted, err
+		}
+		if err := assets.Remove(f.Path); err != nil {
+			return deleted, err
+		}
 		deleted++
 	}
 	return deleted, nil
@@ -3,9 +3,16 @@ package prune
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/jackchuka/gh-md/internal/config"
 	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/search"
+	"github.com/jackchuka/gh-md/internal/writer"
 )
 
 func TestPruneResult_RelativePath(t *testing.T) {
@@ -59,6 +66,265 @@ func TestPruneResult_RelativePath(t *testing.T) {
 	}
 }
 
+func TestMatcher_Rule(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	days := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	intPtr := func(n int) *int { return &n }
+	yes := true
+
+	tests := []struct {
+		name     string
+		policy   *config.PrunePolicy
+		filter   string
+		parsed   parser.ParsedFile
+		wantRule string
+	}{
+		{
+			name:     "closed issue",
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Updated: days(1)},
+			wantRule: "closed",
+		},
+		{
+			name:     "merged pull request",
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypePullRequest, State: "merged", Updated: days(1)},
+			wantRule: "merged",
+		},
+		{
+			name:     "closed discussion",
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypeDiscussion, State: "closed", Updated: days(1)},
+			wantRule: "closed",
+		},
+		{
+			name:   "open issue",
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "open", Updated: days(400)},
+		},
+		{
+			name:   "type excluded",
+			policy: &config.PrunePolicy{Types: []string{config.TypeIssues}},
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeDiscussion, State: "closed"},
+		},
+		{
+			name:     "closed long enough ago",
+			policy:   &config.PrunePolicy{ClosedDays: intPtr(30)},
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Closed: days(45), Updated: days(1)},
+			wantRule: "closed over 30 days ago",
+		},
+		{
+			name:   "closed recently",
+			policy: &config.PrunePolicy{ClosedDays: intPtr(30)},
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Closed: days(10), Updated: days(10)},
+		},
+		{
+			name:     "closed date falls back to updated",
+			policy:   &config.PrunePolicy{ClosedDays: intPtr(30)},
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypePullRequest, State: "merged", Updated: days(31)},
+			wantRule: "merged over 30 days ago",
+		},
+		{
+			name:     "stale open item",
+			policy:   &config.PrunePolicy{StaleMonths: intPtr(6)},
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "open", Updated: days(200)},
+			wantRule: "not updated in 6 months",
+		},
+		{
+			name:   "recently updated open item",
+			policy: &config.PrunePolicy{StaleMonths: intPtr(6)},
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "open", Updated: days(100)},
+		},
+		{
+			name:   "keep label",
+			policy: &config.PrunePolicy{KeepLabels: []string{"Keep"}},
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Labels: []string{"bug", "keep"}},
+		},
+		{
+			name:   "keep assigned",
+			policy: &config.PrunePolicy{KeepAssigned: &yes},
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Assignees: []string{"me"}},
+		},
+		{
+			name:     "assigned to someone else",
+			policy:   &config.PrunePolicy{KeepAssigned: &yes},
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Assignees: []string{"other"}},
+			wantRule: "closed",
+		},
+		{
+			name:   "keep pinned",
+			policy: &config.PrunePolicy{KeepPinned: &yes},
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "closed", Pinned: true},
+		},
+		{
+			name:     "filter matches open item",
+			filter:   `"wontfix" in labels`,
+			parsed:   parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "open", Labels: []string{"wontfix"}, Updated: days(1)},
+			wantRule: "filter",
+		},
+		{
+			name:   "filter does not override keep rules",
+			policy: &config.PrunePolicy{KeepPinned: &yes},
+			filter: "true",
+			parsed: parser.ParsedFile{ItemType: github.ItemTypeIssue, State: "open", Pinned: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &matcher{
+				settings: config.DefaultSettings().Merge(&config.Settings{Prune: tt.policy}),
+				user:     "me",
+				now:      now,
+			}
+			if tt.filter != "" {
+				prg, err := search.CompileCELFilter(tt.filter)
+				if err != nil {
+					t.Fatalf("CompileCELFilter() error = %v", err)
+				}
+				m.filter = prg
+			}
+
+			got, err := m.rule(&tt.parsed)
+			if err != nil {
+				t.Fatalf("rule() error = %v", err)
+			}
+			if got != tt.wantRule {
+				t.Errorf("rule() = %q, want %q", got, tt.wantRule)
+			}
+		})
+	}
+}
+
+type fakeFetcher struct {
+	states map[string]github.NodeState
+	ids    []string
+}
+
+func (f *fakeFetcher) FetchNodeStates(ids []string) (map[string]github.NodeState, error) {
+	f.ids = ids
+	return f.states, nil
+}
+
+func writeRefreshItem(t *testing.T, root, id, num, state string, edit func(string) string) string {
+	t.Helper()
+	content := writer.StampContentHash("---\nid: " + id + "\nnumber: " + num + "\nowner: owner\nrepo: repo\nstate: " + state +
+		"\nupdated: 2026-01-01T00:00:00Z\n---\n<!-- gh-md:content -->\n# Title\nBody\n<!-- /gh-md:content -->\n")
+	if edit != nil {
+		content = edit(content)
+	}
+	path := filepath.Join(root, "owner", "repo", "issues", num+".md")
+	for _, p := range []string{path, writer.BasePath(path)} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestRefresh(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+	t.Setenv(config.EnvConfigFile, filepath.Join(root, "config.yaml"))
+
+	closedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	closedRemotely := writeRefreshItem(t, root, "I_1", "1", "open", nil)
+	reopened := writeRefreshItem(t, root, "I_2", "2", "closed", nil)
+	writeRefreshItem(t, root, "I_3", "3", "open", nil)
+	edited := writeRefreshItem(t, root, "I_4", "4", "open", func(s string) string { return strings.Replace(s, "Body", "Edited", 1) })
+	writeRefreshItem(t, root, "", "5", "open", nil)
+	fetcher := &fakeFetcher{states: map[string]github.NodeState{
+		"I_1": {State: "closed", ClosedAt: closedAt},
+		"I_2": {State: "open"},
+		"I_3": {State: "open"},
+		"I_4": {State: "closed", ClosedAt: closedAt},
+	}}
+
+	changes, err := Refresh(fetcher, "", Options{})
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if len(fetcher.ids) != 4 {
+		t.Errorf("fetched %v, want the open items and the candidate, without the draft", fetcher.ids)
+	}
+
+	got := make(map[int]StateChange)
+	for _, c := range changes {
+		got[c.Number] = c
+	}
+	if len(got) != 3 {
+		t.Fatalf("Refresh() changes = %+v, want #1, #2 and #4", changes)
+	}
+	if c := got[1]; c.From != "open" || c.To != "closed" || c.Skipped {
+		t.Errorf("#1 change = %+v, want open -> closed", c)
+	}
+	if c := got[2]; c.From != "closed" || c.To != "open" || c.Skipped {
+		t.Errorf("#2 change = %+v, want closed -> open", c)
+	}
+	if c := got[4]; !c.Skipped {
+		t.Errorf("#4 change = %+v, want skipped for local changes", c)
+	}
+
+	for _, path := range []string{closedRemotely, writer.BasePath(closedRemotely)} {
+		parsed, err := parser.ParseFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.State != "closed" || !parsed.Closed.Equal(closedAt) {
+			t.Errorf("%s state = %q closed %v, want closed at %v", path, parsed.State, parsed.Closed, closedAt)
+		}
+	}
+	content, err := os.ReadFile(reopened)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if writer.IsModified(string(content)) {
+		t.Error("refreshed file counts as locally modified")
+	}
+	if parsed, _ := parser.ParseFile(reopened); parsed.State != "open" {
+		t.Errorf("#2 state = %q, want open", parsed.State)
+	}
+	if parsed, _ := parser.ParseFile(edited); parsed.State != "open" {
+		t.Errorf("#4 state = %q, want the local state kept", parsed.State)
+	}
+
+	files, err := FindPrunableFiles("", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Number != 1 {
+		t.Errorf("FindPrunableFiles() after Refresh() = %+v, want only #1", files)
+	}
+}
+
+func TestFindPrunableFiles_KeepsLocalWork(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+	t.Setenv(config.EnvConfigFile, filepath.Join(root, "config.yaml"))
+
+	stale := func(s string) string {
+		return writer.StampContentHash(strings.Replace(s, "2026-01-01", "2020-01-01", 1))
+	}
+	writeRefreshItem(t, root, "I_1", "1", "closed", nil)
+	writeRefreshItem(t, root, "I_2", "2", "closed", func(s string) string { return strings.Replace(s, "Body", "Edited", 1) })
+	writeRefreshItem(t, root, "I_3", "3", "open", stale)
+	writeRefreshItem(t, root, "I_4", "4", "open", func(s string) string { return strings.Replace(stale(s), "Body", "Edited", 1) })
+	writeRefreshItem(t, root, "", "5", "open", stale)
+
+	months := 6
+	files, err := FindPrunableFiles("", Options{Policy: &config.PrunePolicy{StaleMonths: &months}})
+	if err != nil {
+		t.Fatalf("FindPrunableFiles() error = %v", err)
+	}
+	var got []int
+	for _, f := range files {
+		got = append(got, f.Number)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("FindPrunableFiles() = %v, want [1 3] without edited files and drafts", got)
+	}
+}
+
 func TestDeleteFiles(t *testing.T) {
 	t.Run("delete existing files", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the push queue file in the gh-md root.
+const FileName = config.QueueFileName
+
+// Entry is a queued push: a snapshot of a local file taken when the push was requested.
+type Entry struct {
+	Path     string    `yaml:"path"` // Relative to the gh-md root
+	QueuedAt time.Time `yaml:"queued_at"`
+	Content  string    `yaml:"content"`
+}
+
+// Queue holds pending pushes in the order they were requested.
+type Queue struct {
+	Entries []Entry `yaml:"entries"`
+}
+
+// Add queues a snapshot of the file at path (relative to the gh-md root).
+// A file that is already queued keeps its position and has its snapshot replaced.
+func (q *Queue) Add(path, content string) {
+	entry := Entry{Path: path, QueuedAt: time.Now().UTC(), Content: content}
+	for i := range q.Entries {
+		if q.Entries[i].Path == path {
+			q.Entries[i] = entry
+			return
+		}
+	}
+	q.Entries = append(q.Entries, entry)
+}
+
+// Remove drops the entry for path, if queued.
+func (q *Queue) Remove(path string) {
+	for i := range q.Entries {
+		if q.Entries[i].Path == path {
+			q.Entries = append(q.Entries[:i], q.Entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Load reads the push queue. Returns an empty queue if the file doesn't exist.
+func Load() (*Queue, error) {
+	path, err := queuePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Queue{}, nil
+		}
+		return nil, err
+	}
+
+	var q Queue
+	if err := yaml.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &q, nil
+}
+
+// Save writes the push queue atomically, removing the file when the queue is empty.
+func Save(q *Queue) error {
+	path, err := queuePath()
+	if err != nil {
+		return err
+	}
+
+	if len(q.Entries) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: write to temp file, then rename
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+func queuePath() (string, error) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, FileName), nil
+}
@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+func TestQueue_AddRemove(t *testing.T) {
+	q := &Queue{}
+	q.Add("o/r/issues/1.md", "one")
+	q.Add("o/r/issues/2.md", "two")
+	q.Add("o/r/issues/1.md", "one v2")
+
+	if len(q.Entries) != 2 {
+		t.Fatalf("len(Entries) = %d, want 2", len(q.Entries))
+	}
+	if q.Entries[0].Path != "o/r/issues/1.md" || q.Entries[0].Content != "one v2" {
+		t.Errorf("Entries[0] = %+v, want replaced snapshot in original position", q.Entries[0])
+	}
+
+	q.Remove("o/r/issues/1.md")
+	if len(q.Entries) != 1 || q.Entries[0].Path != "o/r/issues/2.md" {
+		t.Errorf("Entries after Remove = %+v", q.Entries)
+	}
+}
+
+func TestLoadAndSave(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	q, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(q.Entries) != 0 {
+		t.Fatalf("Load() on missing file returned %d entries", len(q.Entries))
+	}
+
+	q.Add("o/r/issues/1.md", "---\nid: I_1\n---\n\nmulti\nline\n")
+	if err := Save(q); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0].Content != q.Entries[0].Content {
+		t.Errorf("Load() = %+v, want round trip of %+v", loaded.Entries, q.Entries)
+	}
+
+	// Saving an empty queue removes the file
+	loaded.Remove("o/r/issues/1.md")
+	if err := Save(loaded); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, FileName)); !os.IsNotExist(err) {
+		t.Errorf("queue file still exists after saving empty queue")
+	}
+}
@@ -0,0 +1,81 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+// Markdown renders items as a single plain markdown document, one section per
+// item with its metadata, body and pushed comments. Unlike the stored files it
+// has no frontmatter or markers, so it reads well outside gh-md.
+func Markdown(items []*parser.ParsedFile) string {
+	var sb strings.Builder
+	for i, parsed := range items {
+		if i > 0 {
+			sb.WriteString("\n---\n\n")
+		}
+		writeMarkdownItem(&sb, parsed)
+	}
+	return sb.String()
+}
+
+func writeMarkdownItem(sb *strings.Builder, parsed *parser.ParsedFile) {
+	title := parsed.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+	fmt.Fprintf(sb, "## %s\n\n", title)
+
+	ref := fmt.Sprintf("%s/%s#%d", parsed.Owner, parsed.Repo, parsed.Number)
+	if seg, ok := parsed.ItemType.URLSegment(); ok && parsed.Number > 0 {
+		ref = fmt.Sprintf("[%s](https://github.com/%s/%s/%s/%d)", ref, parsed.Owner, parsed.Repo, seg, parsed.Number)
+	}
+	meta := []string{ref}
+	if label, ok := parsed.ItemType.ListLabel(); ok {
+		meta = append(meta, label)
+	}
+	if parsed.State != "" {
+		meta = append(meta, strings.ToLower(parsed.State))
+	}
+	if parsed.Author != "" {
+		meta = append(meta, "@"+parsed.Author)
+	}
+	if !parsed.Created.IsZero() {
+		meta = append(meta, parsed.Created.Format("2006-01-02"))
+	}
+	fmt.Fprintf(sb, "%s\n\n", strings.Join(meta, " · "))
+	if len(parsed.Labels) > 0 {
+		fmt.Fprintf(sb, "Labels: %s\n\n", strings.Join(parsed.Labels, ", "))
+	}
+
+	if body := strings.TrimSpace(parsed.Body); body != "" {
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	} else {
+		sb.WriteString("_No description provided._\n")
+	}
+
+	var comments []parser.ParsedComment
+	for _, c := range parsed.Comments {
+		if c.ID != "" {
+			comments = append(comments, c)
+		}
+	}
+	if len(comments) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n### Comments (%d)\n", len(comments))
+	for _, c := range comments {
+		heading := "@" + c.Author
+		if c.ParentID != "" {
+			heading = "↳ " + heading
+		}
+		if !c.Created.IsZero() {
+			heading += " · " + c.Created.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(sb, "\n**%s**\n\n%s\n", heading, strings.TrimSpace(c.Body))
+	}
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+func TestMarkdown(t *testing.T) {
+	items := []*parser.ParsedFile{
+		{
+			Owner:    "o",
+			Repo:     "r",
+			Number:   1,
+			State:    "OPEN",
+			Author:   "alice",
+			Labels:   []string{"bug"},
+			Title:    "First",
+			ItemType: github.ItemTypeIssue,
+			Created:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			Body:     "Body one",
+			Comments: []parser.ParsedComment{
+				{ID: "C_1", Author: "bob", Body: "Looks good"},
+				{Body: "unpushed draft"},
+			},
+		},
+		{Owner: "o", Repo: "r", Number: 2, State: "MERGED", Title: "Second", ItemType: github.ItemTypePullRequest},
+	}
+
+	got := Markdown(items)
+
+	for _, want := range []string{
+		"## First\n",
+		"[o/r#1](https://github.com/o/r/issues/1) · issue · open · @alice · 2026-01-02",
+		"Labels: bug",
+		"Body one",
+		"### Comments (1)",
+		"**@bob**\n\nLooks good",
+		"\n---\n",
+		"## Second\n",
+		"[o/r#2](https://github.com/o/r/pull/2) · pr · merged",
+		"_No description provided._",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "unpushed draft") {
+		t.Errorf("Markdown() contains draft comment:\n%s", got)
+	}
+}
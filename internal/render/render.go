@@ -0,0 +1,376 @@
+// Package render formats stored items as readable terminal output.
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+// DefaultWidth is used when the terminal width is unknown.
+const DefaultWidth = 80
+
+// minWidth keeps wrapping sane in very narrow preview windows.
+const minWidth = 20
+
+// Options controls rendering.
+type Options struct {
+	Width int  // Wrap width in columns; 0 = DefaultWidth
+	Color bool // Emit ANSI styling
+}
+
+// renderer accumulates output for a single item.
+type renderer struct {
+	opts Options
+	sb   strings.Builder
+}
+
+// Item renders a parsed item file: a header with its metadata, the body as
+// styled markdown and the comments as a thread. File markers are not shown.
+func Item(parsed *parser.ParsedFile, opts Options) string {
+	if opts.Width <= 0 {
+		opts.Width = DefaultWidth
+	}
+	opts.Width = max(opts.Width, minWidth)
+
+	r := &renderer{opts: opts}
+	r.header(parsed)
+	r.rule()
+
+	if strings.TrimSpace(parsed.Body) == "" {
+		r.line(r.style(output.ColorDim+output.ColorItalic, "No description provided."))
+	} else {
+		r.markdown(parsed.Body, "")
+	}
+
+	if len(parsed.Comments) > 0 {
+		r.rule()
+		r.comments(parsed.Comments)
+	}
+
+	return r.sb.String()
+}
+
+func (r *renderer) header(parsed *parser.ParsedFile) {
+	title := parsed.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+	r.wrapped(r.style(output.ColorBold, title), "", "")
+
+	kind := "item"
+	if label, ok := parsed.ItemType.ListLabel(); ok {
+		kind = label
+	}
+	ref := fmt.Sprintf("%s/%s#%d", parsed.Owner, parsed.Repo, parsed.Number)
+	if parsed.ID == "" {
+		ref = fmt.Sprintf("%s/%s (draft, not created yet)", parsed.Owner, parsed.Repo)
+	}
+	status := []string{r.style(output.ColorDim, ref), kind}
+	if parsed.State != "" {
+		status = append(status, r.stateStyle(parsed.State, parsed.Draft))
+	}
+	if parsed.Modified {
+		status = append(status, r.style(output.ColorYellow, "modified locally"))
+	}
+	r.line(strings.Join(status, " · "))
+
+	var dates []string
+	if parsed.Author != "" {
+		dates = append(dates, "@"+parsed.Author)
+	}
+	if !parsed.Created.IsZero() {
+		dates = append(dates, "opened "+parsed.Created.Format("2006-01-02"))
+	}
+	if !parsed.Updated.IsZero() {
+		dates = append(dates, "updated "+parsed.Updated.Format("2006-01-02"))
+	}
+	if len(dates) > 0 {
+		r.line(r.style(output.ColorDim, strings.Join(dates, " · ")))
+	}
+
+	r.field("Labels", parsed.Labels, "", output.ColorYellow)
+	r.field("Assignees", parsed.Assignees, "@", "")
+	r.field("Reviewers", parsed.Reviewers, "@", "")
+	if parsed.HeadRef != "" || parsed.BaseRef != "" {
+		r.field("Branch", []string{parsed.HeadRef + " → " + parsed.BaseRef}, "", output.ColorCyan)
+	}
+	if parsed.Category != "" {
+		r.field("Category", []string{parsed.Category}, "", "")
+	}
+	if parsed.Parent > 0 {
+		r.field("Parent", []string{fmt.Sprintf("#%d", parsed.Parent)}, "", "")
+	}
+	if len(parsed.Children) > 0 {
+		children := make([]string, len(parsed.Children))
+		for i, n := range parsed.Children {
+			children[i] = fmt.Sprintf("#%d", n)
+		}
+		r.field("Sub-issues", children, "", "")
+	}
+}
+
+func (r *renderer) field(name string, values []string, prefix, color string) {
+	if len(values) == 0 {
+		return
+	}
+	styled := make([]string, len(values))
+	for i, v := range values {
+		styled[i] = r.style(color, prefix+v)
+	}
+	label := r.style(output.ColorBold, name+":") + " "
+	r.wrapped(label+strings.Join(styled, ", "), "", strings.Repeat(" ", len(name)+2))
+}
+
+func (r *renderer) stateStyle(state string, draft bool) string {
+	state = strings.ToLower(state)
+	if draft && state == "open" {
+		return r.style(output.ColorDim, "draft")
+	}
+	switch state {
+	case "open":
+		return r.style(output.ColorGreen, state)
+	case "merged":
+		return r.style(output.ColorMagenta, state)
+	case "closed":
+		return r.style(output.ColorRed, state)
+	default:
+		return state
+	}
+}
+
+func (r *renderer) comments(comments []parser.ParsedComment) {
+	r.line(r.style(output.ColorBold, fmt.Sprintf("Comments (%d)", len(comments))))
+
+	depth := make(map[string]int)
+	for _, c := range comments {
+		d := 0
+		if c.ParentID != "" {
+			d = depth[c.ParentID] + 1
+		}
+		if c.ID != "" {
+			depth[c.ID] = d
+		}
+
+		indent := strings.Repeat("  ", d)
+		r.line("")
+
+		author := "@" + c.Author
+		if c.Author == "" {
+			author = "you"
+		}
+		heading := indent
+		if d > 0 {
+			heading = strings.Repeat("  ", d-1) + "↳ "
+		}
+		heading += r.style(output.ColorBold+output.ColorCyan, author)
+		switch {
+		case c.ID == "":
+			heading += " " + r.style(output.ColorYellow, "· new, not pushed")
+		case !c.Created.IsZero():
+			heading += " " + r.style(output.ColorDim, "· "+c.Created.Format("2006-01-02 15:04"))
+		}
+		r.line(heading)
+		r.markdown(c.Body, indent+"  ")
+	}
+}
+
+// rule writes a horizontal separator across the full width.
+func (r *renderer) rule() {
+	r.line("")
+	r.line(r.style(output.ColorDim, strings.Repeat("─", r.opts.Width)))
+	r.line("")
+}
+
+func (r *renderer) line(s string) {
+	r.sb.WriteString(s)
+	r.sb.WriteString("\n")
+}
+
+// style wraps s in ANSI codes when color is enabled.
+func (r *renderer) style(codes, s string) string {
+	if !r.opts.Color || codes == "" || s == "" {
+		return s
+	}
+	return codes + s + output.ColorReset
+}
+
+var (
+	htmlComment  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	headingLine  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	listLine     = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	taskPrefix   = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	ruleLine     = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	fenceLine    = regexp.MustCompile("^\\s*(```|~~~)")
+	imagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)[^)]*\)`)
+	linkPattern  = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	codePattern  = regexp.MustCompile("`([^`]+)`")
+	boldPattern  = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	ansiPattern  = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// markdown renders markdown text line by line: headings, lists, quotes, rules
+// and code blocks are styled and paragraphs are wrapped. Every line is
+// prefixed with indent.
+func (r *renderer) markdown(text, indent string) {
+	text = htmlComment.ReplaceAllString(text, "")
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			r.wrapped(r.inline(strings.Join(paragraph, " ")), indent, indent)
+			paragraph = nil
+		}
+	}
+
+	inCode := false
+	blank := true // Collapse runs of blank lines
+	for _, line := range lines {
+		if fenceLine.MatchString(line) {
+			flush()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			r.line(indent + "  " + r.style(output.ColorCyan, line))
+			blank = false
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+			if !blank {
+				r.line("")
+			}
+			blank = true
+			continue
+
+		case ruleLine.MatchString(line):
+			flush()
+			r.line(indent + r.style(output.ColorDim, strings.Repeat("─", max(r.opts.Width-len(indent), 1))))
+
+		case headingLine.MatchString(trimmed):
+			flush()
+			m := headingLine.FindStringSubmatch(trimmed)
+			codes := output.ColorBold
+			if len(m[1]) <= 2 {
+				codes += output.ColorMagenta
+			}
+			r.wrapped(r.style(codes, m[2]), indent, indent)
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := strings.TrimSpace(strings.TrimLeft(trimmed, ">"))
+			bar := r.style(output.ColorDim, "│ ")
+			r.wrapped(r.style(output.ColorItalic, r.inline(quote)), indent+bar, indent+bar)
+
+		case listLine.MatchString(line):
+			flush()
+			m := listLine.FindStringSubmatch(line)
+			nested := strings.Repeat(" ", len(m[1]))
+			marker := "•"
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				marker = m[2]
+			}
+			item := m[3]
+			if t := taskPrefix.FindStringSubmatch(item); t != nil {
+				marker = "☐"
+				if t[1] != " " {
+					marker = "☑"
+				}
+				item = item[len(t[0]):]
+			}
+			first := indent + nested + marker + " "
+			r.wrapped(r.inline(item), first, indent+nested+strings.Repeat(" ", utf8.RuneCountInString(marker)+1))
+
+		case strings.HasPrefix(trimmed, "|"):
+			// Tables keep their layout
+			flush()
+			r.line(indent + line)
+
+		default:
+			paragraph = append(paragraph, trimmed)
+			blank = false
+			continue
+		}
+		blank = false
+	}
+	flush()
+}
+
+// inline styles bold text, code spans, links and images.
+func (r *renderer) inline(s string) string {
+	s = imagePattern.ReplaceAllStringFunc(s, func(m string) string {
+		alt := imagePattern.FindStringSubmatch(m)[1]
+		if alt == "" {
+			alt = "image"
+		}
+		return r.style(output.ColorDim, "[🖼 "+alt+"]")
+	})
+	s = linkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := linkPattern.FindStringSubmatch(m)
+		return r.style(output.ColorUnderline, sub[1]) + " " + r.style(output.ColorDim, "("+sub[2]+")")
+	})
+	s = codePattern.ReplaceAllStringFunc(s, func(m string) string {
+		return r.style(output.ColorCyan, codePattern.FindStringSubmatch(m)[1])
+	})
+	s = boldPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := boldPattern.FindStringSubmatch(m)
+		return r.style(output.ColorBold, sub[1]+sub[2])
+	})
+	return s
+}
+
+// wrapped writes s word-wrapped to the width, starting the first line with
+// first and continuation lines with rest.
+func (r *renderer) wrapped(s, first, rest string) {
+	for _, line := range wrap(s, r.opts.Width-visibleWidth(first), r.opts.Width-visibleWidth(rest)) {
+		r.line(first + line)
+		first = rest
+	}
+}
+
+// wrap splits s into lines of at most width visible columns (firstWidth for
+// the first line), breaking at spaces. ANSI codes don't count towards the width,
+// and words longer than a line are kept whole.
+func wrap(s string, firstWidth, width int) []string {
+	firstWidth = max(firstWidth, minWidth/2)
+	width = max(width, minWidth/2)
+
+	var lines []string
+	var cur strings.Builder
+	curWidth := 0
+	limit := firstWidth
+
+	for _, word := range strings.Fields(s) {
+		w := visibleWidth(word)
+		if curWidth > 0 && curWidth+1+w > limit {
+			lines = append(lines, cur.String())
+			cur.Reset()
+			curWidth = 0
+			limit = width
+		}
+		if curWidth > 0 {
+			cur.WriteByte(' ')
+			curWidth++
+		}
+		cur.WriteString(word)
+		curWidth += w
+	}
+	if cur.Len() > 0 || len(lines) == 0 {
+		lines = append(lines, cur.String())
+	}
+	return lines
+}
+
+// visibleWidth returns the number of columns s occupies, ignoring ANSI codes.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+func TestItem(t *testing.T) {
+	parsed := &parser.ParsedFile{
+		ID:        "I_1",
+		Owner:     "o",
+		Repo:      "r",
+		Number:    12,
+		State:     "OPEN",
+		Author:    "alice",
+		Labels:    []string{"bug", "ui"},
+		Assignees: []string{"bob"},
+		Title:     "Crash on save",
+		ItemType:  github.ItemTypeIssue,
+		Created:   time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Body: "<!-- template hint -->\n## Steps\n\n- open the app\n- [x] click **save**\n\n" +
+			"```\nraw  code\n```\n\n" + strings.Repeat("word ", 30),
+		Comments: []parser.ParsedComment{
+			{ID: "DC_1", Author: "carol", Body: "Same here", Created: time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)},
+			{ID: "DC_2", Author: "alice", Body: "Thanks", ParentID: "DC_1"},
+			{Body: "My draft reply"},
+		},
+	}
+
+	got := Item(parsed, Options{Width: 40})
+
+	for _, want := range []string{
+		"Crash on save",
+		"o/r#12 · issue · open",
+		"@alice · opened 2026-01-02",
+		"Labels: bug, ui",
+		"Assignees: @bob",
+		"Steps",
+		"• open the app",
+		"☑ click save",
+		"  raw  code",
+		"Comments (3)",
+		"@carol · 2026-01-03 10:00",
+		"↳ @alice",
+		"you · new, not pushed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Item() missing %q in:\n%s", want, got)
+		}
+	}
+
+	for _, unwanted := range []string{"<!--", "gh-md:", "**", "\x1b["} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Item() contains %q:\n%s", unwanted, got)
+		}
+	}
+
+	for _, line := range strings.Split(got, "\n") {
+		if visibleWidth(line) > 40 {
+			t.Errorf("line exceeds width: %q", line)
+		}
+	}
+}
+
+func TestItem_Color(t *testing.T) {
+	parsed := &parser.ParsedFile{Title: "T", State: "merged", Body: "**bold**", ItemType: github.ItemTypePullRequest}
+
+	got := Item(parsed, Options{Width: 40, Color: true})
+	if !strings.Contains(got, "\x1b[35mmerged\x1b[0m") {
+		t.Errorf("state not colored:\n%q", got)
+	}
+	if !strings.Contains(got, "\x1b[1mbold\x1b[0m") {
+		t.Errorf("bold not styled:\n%q", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	got := wrap("aaa bbb \x1b[1mccc\x1b[0m ddd eee", 10, 12)
+	want := []string{"aaa bbb", "\x1b[1mccc\x1b[0m ddd eee"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrap() = %q, want %q", got, want)
+	}
+
+	// Long words are kept whole
+	if got := wrap(strings.Repeat("x", 30), 10, 10); len(got) != 1 {
+		t.Errorf("wrap() split a long word: %q", got)
+	}
+}
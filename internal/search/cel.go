@@ -25,6 +25,19 @@ func NewCELEnv() (*cel.Env, error) {
 		cel.Variable("owner", cel.StringType),
 		cel.Variable("repo", cel.StringType),
 		cel.Variable("number", cel.IntType),
+		cel.Variable("comments", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+		cel.Variable("comment_count", cel.IntType),
+		cel.Variable("last_commenter", cel.StringType),
+		cel.Variable("participants", cel.ListType(cel.StringType)),
+		cel.Variable("draft", cel.BoolType),
+		cel.Variable("head_ref", cel.StringType),
+		cel.Variable("base_ref", cel.StringType),
+		cel.Variable("category", cel.StringType),
+		cel.Variable("parent", cel.IntType),
+		cel.Variable("children", cel.ListType(cel.IntType)),
+		cel.Variable("last_pulled", cel.TimestampType),
+		cel.Variable("closed", cel.TimestampType),
+		cel.Variable("pinned", cel.BoolType),
 	)
 }
 
@@ -3,6 +3,10 @@ package search
 import (
 	"testing"
 	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
 )
 
 func TestNewCELEnv(t *testing.T) {
@@ -270,3 +274,107 @@ func TestEvaluateFilter_NonBooleanResult(t *testing.T) {
 		t.Error("EvaluateFilter() error = nil, want error for non-boolean result")
 	}
 }
+
+func TestMatchParsedFile(t *testing.T) {
+	parsed := &parser.ParsedFile{
+		Owner:    "owner",
+		Repo:     "repo",
+		Number:   7,
+		State:    "OPEN",
+		ItemType: github.ItemTypeIssue,
+		Labels:   []string{"bug"},
+		// Assignees and Reviewers left nil on purpose
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "state is lowercased", expr: `state == "open"`, want: true},
+		{name: "item type label", expr: `item_type == "issue"`, want: true},
+		{name: "label match", expr: `"bug" in labels`, want: true},
+		{name: "nil list is empty", expr: `size(assigned) == 0`, want: true},
+		{name: "user", expr: `user == "me"`, want: true},
+		{name: "no match", expr: `number == 8`, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := CompileCELFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("CompileCELFilter() error = %v", err)
+			}
+			got, err := MatchParsedFile(prg, parsed, "me")
+			if err != nil {
+				t.Fatalf("MatchParsedFile() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MatchParsedFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchParsedFile_Comments(t *testing.T) {
+	now := time.Now()
+	parsed := &parser.ParsedFile{
+		Owner:    "owner",
+		Repo:     "repo",
+		Number:   7,
+		State:    "open",
+		Author:   "alice",
+		ItemType: github.ItemTypePullRequest,
+		Draft:    true,
+		HeadRef:  "feature",
+		BaseRef:  "main",
+		Parent:   3,
+		Children: []int{8, 9},
+		Comments: []parser.ParsedComment{
+			{ID: "IC_1", Author: "me", Body: "LGTM?", Created: now.Add(-2 * time.Hour)},
+			{ID: "IC_2", Author: "bob", Body: "Not yet", Created: now.Add(-time.Hour)},
+			{Body: "unposted draft", Draft: true},
+			{ID: "IC_3", Body: "posted draft", LocalID: "local-1", Draft: true},
+		},
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "recent comment by user", expr: `comments.exists(c, c.author == user && c.created > now - duration("24h"))`, want: true},
+		{name: "comment body", expr: `comments.exists(c, c.body.contains("Not yet"))`, want: true},
+		{name: "drafts not counted", expr: `comment_count == 2`, want: true},
+		{name: "posted drafts have no author", expr: `comments.all(c, c.author != "")`, want: true},
+		{name: "waiting on me", expr: `last_commenter != user && user in participants`, want: true},
+		{name: "participants include author", expr: `participants == ["alice", "me", "bob"]`, want: true},
+		{name: "pr fields", expr: `draft && head_ref == "feature" && base_ref == "main"`, want: true},
+		{name: "sub-issues", expr: `parent == 3 && 9 in children`, want: true},
+		{name: "no category", expr: `category == ""`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := CompileCELFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("CompileCELFilter() error = %v", err)
+			}
+			got, err := MatchParsedFile(prg, parsed, "me")
+			if err != nil {
+				t.Fatalf("MatchParsedFile() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MatchParsedFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinViewsCompile(t *testing.T) {
+	for _, v := range config.ListViews(&config.Settings{}) {
+		if _, err := CompileCELFilter(v.Filter); err != nil {
+			t.Errorf("built-in view %s: %v", v.Name, err)
+		}
+	}
+}
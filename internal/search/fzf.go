@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
@@ -17,9 +18,24 @@ const (
 	ActionViewBrowser Action = "browser"
 	ActionCopyPath    Action = "copy"
 	ActionPullFresh   Action = "pull"
+	ActionComment     Action = "comment"
 	ActionCancel      Action = "cancel"
+
+	// Bulk actions, offered when several items are selected
+	ActionClose       Action = "close"
+	ActionAddLabel    Action = "add-label"
+	ActionRemoveLabel Action = "remove-label"
+	ActionAssignMe    Action = "assign-me"
+	ActionPruneLocal  Action = "prune-local"
+	ActionExport      Action = "export"
 )
 
+// menuEntry is an action offered in an action menu.
+type menuEntry struct {
+	action Action
+	label  string
+}
+
 // CheckFZFInstalled verifies that fzf is available in PATH.
 func CheckFZFInstalled() error {
 	_, err := exec.LookPath("fzf")
@@ -36,43 +52,136 @@ func RunSelector(items []Item, query string, sortBy SortField) (*Item, error) {
 		return nil, fmt.Errorf("no items to search")
 	}
 
-	sortItems(items, sortBy)
+	SortItems(items, sortBy, false)
 
 	// Build the input for fzf with file paths embedded
 	var input strings.Builder
-	for i, item := range items {
-		// Format: filepath|owner/repo|#number|type|[state]|title
-		line := fmt.Sprintf("%s\t%s/%s\t#%d\t%s\t[%s]\t%s",
-			item.FilePath,
-			item.Owner, item.Repo,
-			item.Number,
-			item.Type,
-			item.State,
-			item.Title,
-		)
-		input.WriteString(line)
-		if i < len(items)-1 {
-			input.WriteString("\n")
+	for _, item := range items {
+		input.WriteString(selectorLine(item))
+		input.WriteString("\n")
+	}
+
+	args := selectorArgs("Search gh-md files (Enter=select, Ctrl-P=preview, Esc=cancel)")
+	selected, err := runFZF(args, query, input.String(), items)
+	if err != nil || len(selected) == 0 {
+		return nil, err
+	}
+	return &selected[0], nil
+}
+
+// RunMultiSelector opens fzf with multi-select enabled (Tab marks an item) and
+// returns the selected items, or the highlighted item if none were marked.
+// Key bindings change the filters of state and reload the list in place.
+// Returns nil if the user cancels (Esc).
+func RunMultiSelector(state *SelectorState, query string) ([]Item, error) {
+	if len(state.Items) == 0 {
+		return nil, fmt.Errorf("no items to search")
+	}
+
+	var input strings.Builder
+	if err := state.WriteLines(&input); err != nil {
+		return nil, err
+	}
+
+	header := "Search gh-md files (Tab=mark, Enter=select, Ctrl-P=preview, Esc=cancel)"
+	exe, err := os.Executable()
+	if err != nil {
+		// Without our own executable there is nothing to reload with
+		args := append(selectorArgs(header), "--multi", "--header-lines", "1")
+		return runFZF(args, query, input.String(), state.Items)
+	}
+
+	f, err := os.CreateTemp("", "gh-md-selector-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create selector state: %w", err)
+	}
+	statePath := f.Name()
+	_ = f.Close()
+	defer func() { _ = os.Remove(statePath) }()
+
+	if err := state.Save(statePath); err != nil {
+		return nil, err
+	}
+
+	header += "\nAlt-I/P/D=issues/PRs/discussions, Alt-S=state, Alt-M=mine, Alt-O=sort, Alt-R=pull, Alt-B=browser"
+	args := append(selectorArgs(header), "--multi", "--header-lines", "1")
+	args = append(args, reloadBindings(shellQuote(exe), shellQuote(statePath))...)
+
+	items := state.Items
+	selected, err := runFZF(args, query, input.String(), items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reloads may have refreshed titles and states since
+	if latest, err := LoadSelectorState(statePath); err == nil {
+		for i := range selected {
+			for _, item := range latest.Items {
+				if item.FilePath == selected[i].FilePath {
+					selected[i] = item
+					break
+				}
+			}
 		}
 	}
+	return selected, nil
+}
+
+// reloadBindings returns fzf --bind arguments that change the selector state
+// through the hidden 'list --fzf-reload' command and reload the list, plus
+// bindings to pull or open items without leaving fzf.
+func reloadBindings(exe, statePath string) []string {
+	reload := func(toggle string) string {
+		cmd := fmt.Sprintf("%s list --fzf-reload %s", exe, statePath)
+		if toggle != "" {
+			cmd += " --toggle " + toggle
+		}
+		return "reload(" + cmd + ")"
+	}
+
+	// Keep pull errors on screen until acknowledged
+	pull := fmt.Sprintf("execute(%s pull {1} || { printf '\\nPress Enter to continue'; read -r _; })", exe)
+
+	bindings := []string{
+		"alt-i:" + reload(ToggleIssues),
+		"alt-p:" + reload(TogglePRs),
+		"alt-d:" + reload(ToggleDiscussions),
+		"alt-s:" + reload(ToggleState),
+		"alt-m:" + reload(ToggleMine),
+		"alt-o:" + reload(ToggleSort),
+		"alt-r:" + pull + "+" + reload(""),
+		"alt-b:" + fmt.Sprintf("execute-silent(%s list --fzf-open {+1})", exe),
+	}
 
+	var args []string
+	for _, b := range bindings {
+		args = append(args, "--bind", b)
+	}
+	return args
+}
+
+// selectorArgs returns the fzf arguments shared by the selectors.
+func selectorArgs(header string) []string {
 	// Build fzf command with preview using the first field (filepath)
-	args := []string{
+	return []string{
 		"--ansi",
 		"--delimiter", "\t",
 		"--with-nth", "2..", // Hide the filepath column from display
-		"--preview", "cat {1}", // Preview using the first field (filepath)
+		"--preview", previewCommand(), // Preview using the first field (filepath)
 		"--preview-window", "right:50%:wrap:hidden", // Hidden by default
 		"--bind", "ctrl-p:toggle-preview", // Toggle with ctrl-p
-		"--header", "Search gh-md files (Enter=select, Ctrl-P=preview, Esc=cancel)",
+		"--header", header,
 	}
+}
 
+// runFZF runs fzf on input and maps the selected lines back to items.
+func runFZF(args []string, query, input string, items []Item) ([]Item, error) {
 	if query != "" {
 		args = append(args, "--query", query)
 	}
 
 	cmd := exec.Command("fzf", args...)
-	cmd.Stdin = strings.NewReader(input.String())
+	cmd.Stdin = strings.NewReader(input)
 	cmd.Stderr = os.Stderr
 
 	var stdout bytes.Buffer
@@ -91,49 +200,107 @@ func RunSelector(items []Item, query string, sortBy SortField) (*Item, error) {
 		return nil, err
 	}
 
-	// Parse the selected line
-	selected := strings.TrimSpace(stdout.String())
-	if selected == "" {
-		return nil, nil
-	}
+	return selectedItems(items, stdout.String())
+}
 
-	// Extract the filepath from the first column and find the matching item
-	parts := strings.SplitN(selected, "\t", 2)
-	if len(parts) < 1 {
-		return nil, fmt.Errorf("invalid selection")
+// selectedItems maps fzf output lines back to items using the filepath column.
+func selectedItems(items []Item, out string) ([]Item, error) {
+	byPath := make(map[string]int, len(items))
+	for i := range items {
+		byPath[items[i].FilePath] = i
 	}
 
-	filePath := parts[0]
-	for i := range items {
-		if items[i].FilePath == filePath {
-			return &items[i], nil
+	var selected []Item
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
+		filePath, _, _ := strings.Cut(line, "\t")
+		i, ok := byPath[filePath]
+		if !ok {
+			return nil, fmt.Errorf("selected item not found")
+		}
+		selected = append(selected, items[i])
+	}
+
+	return selected, nil
+}
+
+// previewCommand renders the selected file with the hidden 'render' command of
+// this executable, falling back to printing the raw file.
+func previewCommand() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return "cat {1}"
 	}
+	return shellQuote(exe) + " render --color always {1}"
+}
 
-	return nil, fmt.Errorf("selected item not found")
+// shellQuote quotes s for use as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 // RunActionMenu shows a menu of actions for the selected item.
 func RunActionMenu(item *Item) (Action, error) {
-	actions := []struct {
-		action Action
-		label  string
-	}{
+	actions := []menuEntry{
 		{ActionOpenEditor, "Open in $EDITOR"},
 		{ActionPush, "Push changes to GitHub"},
 		{ActionViewBrowser, "View in browser"},
 		{ActionCopyPath, "Copy file path"},
 		{ActionPullFresh, "Pull fresh from GitHub"},
+		{ActionComment, "Comment"},
 		{ActionCancel, "Cancel"},
 	}
 
+	header := fmt.Sprintf("Action for %s/%s #%d: %s", item.Owner, item.Repo, item.Number, item.Title)
+	return runMenu(header, actions)
+}
+
+// RunBulkActionMenu shows a menu of actions that apply to every selected item.
+func RunBulkActionMenu(items []Item) (Action, error) {
+	actions := []menuEntry{
+		{ActionPush, "Push changes to GitHub"},
+		{ActionPullFresh, "Pull fresh from GitHub"},
+		{ActionViewBrowser, "View all in browser"},
+		{ActionOpenEditor, "Open all in $EDITOR"},
+		{ActionClose, "Close on GitHub"},
+		{ActionAddLabel, "Add label"},
+		{ActionRemoveLabel, "Remove label"},
+		{ActionAssignMe, "Assign to me"},
+		{ActionPruneLocal, "Prune local files"},
+		{ActionExport, "Export to a single markdown file"},
+		{ActionCopyPath, "Copy file paths"},
+		{ActionCancel, "Cancel"},
+	}
+
+	header := fmt.Sprintf("Action for %d selected items", len(items))
+	return runMenu(header, actions)
+}
+
+// runMenu lets the user pick one of actions with fzf.
+func runMenu(header string, actions []menuEntry) (Action, error) {
+	labels := make([]string, len(actions))
+	for i, a := range actions {
+		labels[i] = a.label
+	}
+
+	i, err := Pick(header, labels)
+	if err != nil || i < 0 {
+		return ActionCancel, err
+	}
+	return actions[i].action, nil
+}
+
+// Pick lets the user choose one of labels with fzf and returns its index,
+// or -1 if the user cancelled.
+func Pick(header string, labels []string) (int, error) {
+	// Number the lines so identical labels can be told apart
 	var input strings.Builder
-	for _, a := range actions {
-		input.WriteString(a.label)
-		input.WriteString("\n")
+	for i, label := range labels {
+		fmt.Fprintf(&input, "%d\t%s\n", i, strings.ReplaceAll(label, "\n", " "))
 	}
 
-	header := fmt.Sprintf("Action for %s/%s #%d: %s", item.Owner, item.Repo, item.Number, item.Title)
 	if len(header) > 80 {
 		header = header[:77] + "..."
 	}
@@ -141,7 +308,9 @@ func RunActionMenu(item *Item) (Action, error) {
 	cmd := exec.Command("fzf",
 		"--header", header,
 		"--no-preview",
-		"--height", "~10",
+		"--delimiter", "\t",
+		"--with-nth", "2..",
+		"--height", fmt.Sprintf("~%d", len(labels)+4),
 	)
 	cmd.Stdin = strings.NewReader(input.String())
 	cmd.Stderr = os.Stderr
@@ -153,21 +322,19 @@ func RunActionMenu(item *Item) (Action, error) {
 	if err != nil {
 		// Exit code 130 = Esc/Ctrl-C
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 130 {
-			return ActionCancel, nil
+			return -1, nil
 		}
 		// Exit code 1 = no match
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-			return ActionCancel, nil
+			return -1, nil
 		}
-		return ActionCancel, err
+		return -1, err
 	}
 
-	selected := strings.TrimSpace(stdout.String())
-	for _, a := range actions {
-		if a.label == selected {
-			return a.action, nil
-		}
+	selected, _, _ := strings.Cut(strings.TrimSpace(stdout.String()), "\t")
+	i, err := strconv.Atoi(selected)
+	if err != nil || i < 0 || i >= len(labels) {
+		return -1, nil
 	}
-
-	return ActionCancel, nil
+	return i, nil
 }
@@ -2,12 +2,14 @@ package search
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/cli/go-gh/v2"
 	"github.com/google/cel-go/cel"
+	"github.com/jackchuka/gh-md/internal/archive"
 	"github.com/jackchuka/gh-md/internal/parser"
 )
 
@@ -15,23 +17,56 @@ import (
 type SortField string
 
 const (
-	SortUpdated SortField = "updated" // Latest updated first
-	SortCreated SortField = "created" // Latest created first
-	SortNumber  SortField = "number"  // Highest number first
+	SortUpdated   SortField = "updated"   // Latest updated first
+	SortCreated   SortField = "created"   // Latest created first
+	SortNumber    SortField = "number"    // Highest number first
+	SortComments  SortField = "comments"  // Most comments first
+	SortReactions SortField = "reactions" // Most reactions first
+	SortTitle     SortField = "title"     // Alphabetical
+	SortRepo      SortField = "repo"      // Alphabetical by owner/repo, then highest number first
+
+	SortRelevance SortField = "relevance" // Keep the given order (ranked search results)
 )
 
+// SortFields are the sort orders that can be chosen for local items.
+var SortFields = []SortField{SortUpdated, SortCreated, SortNumber, SortComments, SortReactions, SortTitle, SortRepo}
+
+// ParseSortField converts a --sort value to a SortField. An empty string is SortUpdated.
+func ParseSortField(s string) (SortField, error) {
+	if s == "" {
+		return SortUpdated, nil
+	}
+	for _, f := range SortFields {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(SortFields))
+	for i, f := range SortFields {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("invalid sort %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
 // Item represents a searchable item from local files.
 type Item struct {
-	FilePath string
-	Owner    string
-	Repo     string
-	Number   int
-	Type     string // "issue", "pr", "discussion"
-	State    string // "open", "closed", "merged"
-	Title    string
-	URL      string
-	Created  time.Time
-	Updated  time.Time
+	FilePath  string
+	Owner     string
+	Repo      string
+	Number    int
+	Type      string // "issue", "pr", "discussion"
+	State     string // "open", "closed", "merged"
+	Title     string
+	URL       string
+	Author    string
+	Labels    []string
+	Comments  int // Posted comments
+	Reactions int // Reactions on the item itself
+	Created   time.Time
+	Updated   time.Time
+	Snippet   string // Matching text excerpt, set for full-text search results
+	Archived  bool   // Held in a repository archive; FilePath is where it is restored to
 }
 
 // Filters specifies which items to include in search results.
@@ -42,53 +77,31 @@ type Filters struct {
 	Discussions bool
 }
 
-// DiscoverLocalFiles walks the gh-md root directory and returns all matching items.
-func DiscoverLocalFiles(filters Filters) ([]Item, error) {
-	// If no type filters are set, include all types
-	includeAll := !filters.Issues && !filters.PRs && !filters.Discussions
+// Includes reports whether items of the given type ("issue", "pr", "discussion")
+// pass the type filters. If no type filter is set, all types are included.
+func (f Filters) Includes(itemType string) bool {
+	if !f.Issues && !f.PRs && !f.Discussions {
+		return true
+	}
+	switch itemType {
+	case "issue":
+		return f.Issues
+	case "pr":
+		return f.PRs
+	case "discussion":
+		return f.Discussions
+	}
+	return true
+}
 
+// DiscoverLocalFiles returns all matching items, using the metadata cache.
+func DiscoverLocalFiles(filters Filters) ([]Item, error) {
 	var items []Item
 
-	err := parser.WalkParsedFiles(parser.WalkFilters{Repo: filters.Repo}, func(parsed *parser.ParsedFile) error {
-		itemType := "unknown"
-		if label, ok := parsed.ItemType.ListLabel(); ok {
-			itemType = label
-		}
-
-		// Apply type filters
-		if !includeAll {
-			switch itemType {
-			case "issue":
-				if !filters.Issues {
-					return nil
-				}
-			case "pr":
-				if !filters.PRs {
-					return nil
-				}
-			case "discussion":
-				if !filters.Discussions {
-					return nil
-				}
-			}
-		}
-
-		url := ""
-		if seg, ok := parsed.ItemType.URLSegment(); ok {
-			url = fmt.Sprintf("https://github.com/%s/%s/%s/%d", parsed.Owner, parsed.Repo, seg, parsed.Number)
-		}
-
-		item := Item{
-			FilePath: parsed.FilePath,
-			Owner:    parsed.Owner,
-			Repo:     parsed.Repo,
-			Number:   parsed.Number,
-			Type:     itemType,
-			State:    strings.ToLower(parsed.State),
-			Title:    parsed.Title,
-			URL:      url,
-			Created:  parsed.Created,
-			Updated:  parsed.Updated,
+	err := parser.WalkMetadata(parser.WalkFilters{Repo: filters.Repo}, func(parsed *parser.ParsedFile) error {
+		item := NewItem(parsed)
+		if !filters.Includes(item.Type) {
+			return nil
 		}
 
 		items = append(items, item)
@@ -118,73 +131,38 @@ func DiscoverItems(prg cel.Program, username string, repo string) ([]Item, error
 
 	filters := parser.WalkFilters{Repo: repo}
 
-	err := parser.WalkParsedFiles(filters, func(parsed *parser.ParsedFile) error {
-		// Determine item type label
-		itemType := "unknown"
-		if label, ok := parsed.ItemType.ListLabel(); ok {
-			itemType = label
-		}
-
-		// Build URL
-		url := ""
-		if seg, ok := parsed.ItemType.URLSegment(); ok {
-			url = fmt.Sprintf("https://github.com/%s/%s/%s/%d", parsed.Owner, parsed.Repo, seg, parsed.Number)
-		}
-
-		// Ensure slices are not nil (CEL requires non-nil lists)
-		assigned := parsed.Assignees
-		if assigned == nil {
-			assigned = []string{}
-		}
-		reviewers := parsed.Reviewers
-		if reviewers == nil {
-			reviewers = []string{}
-		}
-		labels := parsed.Labels
-		if labels == nil {
-			labels = []string{}
-		}
-
-		// Build CEL variables map
-		vars := map[string]any{
-			"user":      username,
-			"item_type": itemType,
-			"state":     strings.ToLower(parsed.State),
-			"title":     parsed.Title,
-			"body":      parsed.Body,
-			"author":    parsed.Author,
-			"assigned":  assigned,
-			"reviewers": reviewers,
-			"labels":    labels,
-			"created":   parsed.Created,
-			"updated":   parsed.Updated,
-			"owner":     parsed.Owner,
-			"repo":      parsed.Repo,
-			"number":    parsed.Number,
-		}
-
-		// Evaluate the CEL filter
-		match, err := EvaluateFilter(prg, vars)
+	err := parser.WalkMetadata(filters, func(parsed *parser.ParsedFile) error {
+		match, err := MatchMetadata(prg, parsed, username)
 		if err != nil {
 			// Skip items that fail evaluation (e.g., missing fields)
 			return nil
 		}
-
 		if match {
-			items = append(items, Item{
-				FilePath: parsed.FilePath,
-				Owner:    parsed.Owner,
-				Repo:     parsed.Repo,
-				Number:   parsed.Number,
-				Type:     itemType,
-				State:    strings.ToLower(parsed.State),
-				Title:    parsed.Title,
-				URL:      url,
-				Created:  parsed.Created,
-				Updated:  parsed.Updated,
-			})
+			items = append(items, NewItem(parsed))
 		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
+	return items, nil
+}
+
+// DiscoverArchived discovers the archived items matching the CEL filter.
+// If repo is empty, searches all repos. If repo is "owner/repo", only searches that repo.
+func DiscoverArchived(prg cel.Program, username string, repo string) ([]Item, error) {
+	var items []Item
+
+	err := archive.Walk(repo, func(a *archive.Item) error {
+		match, err := MatchParsedFile(prg, a.Parsed, username)
+		if err != nil || !match {
+			return nil
+		}
+		item := NewItem(a.Parsed)
+		item.Archived = true
+		items = append(items, item)
 		return nil
 	})
 
@@ -195,15 +173,183 @@ func DiscoverItems(prg cel.Program, username string, repo string) ([]Item, error
 	return items, nil
 }
 
-func sortItems(items []Item, field SortField) {
-	sort.Slice(items, func(i, j int) bool {
+// NewItem converts a parsed file to a selectable item.
+func NewItem(parsed *parser.ParsedFile) Item {
+	itemType := "unknown"
+	if label, ok := parsed.ItemType.ListLabel(); ok {
+		itemType = label
+	}
+
+	url := ""
+	if seg, ok := parsed.ItemType.URLSegment(); ok {
+		url = fmt.Sprintf("https://github.com/%s/%s/%s/%d", parsed.Owner, parsed.Repo, seg, parsed.Number)
+	}
+
+	return Item{
+		FilePath:  parsed.FilePath,
+		Owner:     parsed.Owner,
+		Repo:      parsed.Repo,
+		Number:    parsed.Number,
+		Type:      itemType,
+		State:     strings.ToLower(parsed.State),
+		Title:     parsed.Title,
+		URL:       url,
+		Author:    parsed.Author,
+		Labels:    parsed.Labels,
+		Comments:  parsed.CommentCount,
+		Reactions: parsed.Reactions,
+		Created:   parsed.Created,
+		Updated:   parsed.Updated,
+	}
+}
+
+// MatchParsedFile evaluates a compiled CEL filter against a parsed file.
+func MatchParsedFile(prg cel.Program, parsed *parser.ParsedFile, username string) (bool, error) {
+	return EvaluateFilter(prg, celVars(parsed, username))
+}
+
+// MatchMetadata evaluates a compiled CEL filter against cached metadata from
+// parser.WalkMetadata. Body and comments aren't cached, so filters that need
+// them fail to evaluate and the file is parsed instead.
+func MatchMetadata(prg cel.Program, parsed *parser.ParsedFile, username string) (bool, error) {
+	vars := celVars(parsed, username)
+	for _, name := range contentVars {
+		delete(vars, name)
+	}
+	if match, err := EvaluateFilter(prg, vars); err == nil {
+		return match, nil
+	}
+
+	full, err := parser.ParseFile(parsed.FilePath)
+	if err != nil {
+		return false, err
+	}
+	return MatchParsedFile(prg, full, username)
+}
+
+// contentVars are the CEL variables derived from the body and comments, which
+// the metadata cache doesn't hold.
+var contentVars = []string{"body", "comments", "comment_count", "last_commenter", "participants"}
+
+// celVars builds the CEL variables map for a parsed file.
+func celVars(parsed *parser.ParsedFile, username string) map[string]any {
+	itemType := "unknown"
+	if label, ok := parsed.ItemType.ListLabel(); ok {
+		itemType = label
+	}
+
+	// Ensure slices are not nil (CEL requires non-nil lists)
+	assigned := parsed.Assignees
+	if assigned == nil {
+		assigned = []string{}
+	}
+	reviewers := parsed.Reviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	labels := parsed.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	children := make([]int, len(parsed.Children))
+	copy(children, parsed.Children)
+
+	// Only comments pulled from GitHub; drafts have no author or date, even
+	// once posted
+	comments := []map[string]any{}
+	lastCommenter := ""
+	var lastCreated time.Time
+	participants := []string{}
+	seen := make(map[string]bool)
+	addParticipant := func(login string) {
+		if login != "" && !seen[login] {
+			seen[login] = true
+			participants = append(participants, login)
+		}
+	}
+	addParticipant(parsed.Author)
+	for _, c := range parsed.Comments {
+		if c.Draft {
+			continue
+		}
+		comments = append(comments, map[string]any{
+			"author":  c.Author,
+			"body":    c.Body,
+			"created": c.Created,
+		})
+		addParticipant(c.Author)
+		if !c.Created.Before(lastCreated) {
+			lastCreated = c.Created
+			lastCommenter = c.Author
+		}
+	}
+
+	return map[string]any{
+		"user":      username,
+		"item_type": itemType,
+		"state":     strings.ToLower(parsed.State),
+		"title":     parsed.Title,
+		"body":      parsed.Body,
+		"author":    parsed.Author,
+		"assigned":  assigned,
+		"reviewers": reviewers,
+		"labels":    labels,
+		"created":   parsed.Created,
+		"updated":   parsed.Updated,
+		"owner":     parsed.Owner,
+		"repo":      parsed.Repo,
+		"number":    parsed.Number,
+
+		"comments":       comments,
+		"comment_count":  len(comments),
+		"last_commenter": lastCommenter,
+		"participants":   participants,
+		"draft":          parsed.Draft,
+		"head_ref":       parsed.HeadRef,
+		"base_ref":       parsed.BaseRef,
+		"category":       parsed.Category,
+		"parent":         parsed.Parent,
+		"children":       children,
+		"last_pulled":    parsed.LastPulled,
+		"closed":         parsed.Closed,
+		"pinned":         parsed.Pinned,
+	}
+}
+
+// SortItems sorts items in place by field: newest, highest or most first, and
+// alphabetically for titles and repositories. reverse flips the order. Items
+// that compare equal keep their relative order.
+func SortItems(items []Item, field SortField, reverse bool) {
+	if field == SortRelevance {
+		if reverse {
+			slices.Reverse(items)
+		}
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if reverse {
+			i, j = j, i
+		}
+		a, b := items[i], items[j]
 		switch field {
 		case SortCreated:
-			return items[i].Created.After(items[j].Created)
+			return a.Created.After(b.Created)
 		case SortNumber:
-			return items[i].Number > items[j].Number
+			return a.Number > b.Number
+		case SortComments:
+			return a.Comments > b.Comments
+		case SortReactions:
+			return a.Reactions > b.Reactions
+		case SortTitle:
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		case SortRepo:
+			ra, rb := strings.ToLower(a.Owner+"/"+a.Repo), strings.ToLower(b.Owner+"/"+b.Repo)
+			if ra != rb {
+				return ra < rb
+			}
+			return a.Number > b.Number
 		default: // SortUpdated
-			return items[i].Updated.After(items[j].Updated)
+			return a.Updated.After(b.Updated)
 		}
 	})
 }
@@ -0,0 +1,41 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+func TestDiscoverItems_BodyFilterWithCache(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	dir := filepath.Join(root, "o", "r", "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{"1.md": "needle here", "2.md": "nothing"} {
+		content := "---\nid: I_" + name + "\nowner: o\nrepo: r\nnumber: 1\nstate: open\n---\n\n" +
+			"<!-- gh-md:content -->\n# Title\n" + body + "\n<!-- /gh-md:content -->\n"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Run twice: the second run reads metadata from the cache, which has no body
+	for run := 1; run <= 2; run++ {
+		prg, err := CompileCELFilter(`state == "open" && body.contains("needle")`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items, err := DiscoverItems(prg, "me", "o/r")
+		if err != nil {
+			t.Fatalf("DiscoverItems() error = %v", err)
+		}
+		if len(items) != 1 || filepath.Base(items[0].FilePath) != "1.md" {
+			t.Errorf("run %d: DiscoverItems() = %+v, want only 1.md", run, items)
+		}
+	}
+}
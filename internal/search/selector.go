@@ -0,0 +1,334 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+// State filters of the selector.
+const (
+	StateAll    = "all"
+	StateOpen   = "open"
+	StateClosed = "closed" // Closed or merged
+)
+
+// Keys of SelectorState.Toggle.
+const (
+	ToggleIssues      = "issues"
+	TogglePRs         = "prs"
+	ToggleDiscussions = "discussions"
+	ToggleState       = "state"
+	ToggleMine        = "mine"
+	ToggleSort        = "sort"
+)
+
+// SelectorState is the interactive filter state of the fzf selector. It is kept
+// in a temporary file so key bindings can change it and reload the list in place.
+type SelectorState struct {
+	Items       []Item    `json:"items"` // Items the selector was opened with
+	Issues      bool      `json:"issues"`
+	PRs         bool      `json:"prs"`
+	Discussions bool      `json:"discussions"`
+	State       string    `json:"state"`
+	Mine        bool      `json:"mine"`
+	User        string    `json:"user,omitempty"` // Login that Mine matches, set on first use
+	Sort        SortField `json:"sort"`
+	Reverse     bool      `json:"reverse,omitempty"`
+	Limit       int       `json:"limit,omitempty"` // Show at most this many items, 0 = all
+
+	mine map[string]bool // File paths of items authored by, assigned to or awaiting review from User
+}
+
+// NewSelectorState returns a state showing all of items, sorted by sortBy.
+func NewSelectorState(items []Item, sortBy SortField) *SelectorState {
+	return &SelectorState{
+		Items:       items,
+		Issues:      true,
+		PRs:         true,
+		Discussions: true,
+		State:       StateAll,
+		Sort:        sortBy,
+	}
+}
+
+// LoadSelectorState reads a state saved with Save.
+func LoadSelectorState(path string) (*SelectorState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selector state: %w", err)
+	}
+
+	var s SelectorState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse selector state: %w", err)
+	}
+	return &s, nil
+}
+
+// Save writes the state to path.
+func (s *SelectorState) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write selector state: %w", err)
+	}
+	return nil
+}
+
+// Toggle flips a type filter or mine/all, or advances the state filter
+// (all, open, closed) or the sort order to the next value.
+func (s *SelectorState) Toggle(key string) error {
+	switch key {
+	case ToggleIssues:
+		s.Issues = !s.Issues
+	case TogglePRs:
+		s.PRs = !s.PRs
+	case ToggleDiscussions:
+		s.Discussions = !s.Discussions
+	case ToggleMine:
+		s.Mine = !s.Mine
+	case ToggleState:
+		s.State = next([]string{StateAll, StateOpen, StateClosed}, s.State)
+	case ToggleSort:
+		s.Sort = next(s.sortOrders(), s.Sort)
+	default:
+		return fmt.Errorf("unknown toggle %q", key)
+	}
+	return nil
+}
+
+// sortOrders returns the sort orders Toggle cycles through. Relevance is only
+// offered for ranked search results.
+func (s *SelectorState) sortOrders() []SortField {
+	orders := SortFields
+	for _, item := range s.Items {
+		if item.Snippet != "" {
+			return append([]SortField{SortRelevance}, orders...)
+		}
+	}
+	return orders
+}
+
+// next returns the value after cur in values, wrapping around.
+func next[T comparable](values []T, cur T) T {
+	for i, v := range values {
+		if v == cur {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
+
+// Refresh re-reads the metadata of the items, so changes made from the
+// selector (like pulling an item) show up on reload. Items whose files were
+// deleted are dropped. It also determines which items are the user's.
+func (s *SelectorState) Refresh() error {
+	items, parsed, err := refreshItems(s.Items)
+	if err != nil {
+		return err
+	}
+
+	prevMine := s.mine
+	s.mine = make(map[string]bool)
+	for i, item := range items {
+		if parsed[i] == nil {
+			// Archived; its metadata can't be re-read
+			s.mine[item.FilePath] = prevMine[item.FilePath]
+			continue
+		}
+		if s.User != "" && isUsers(parsed[i], s.User) {
+			s.mine[item.FilePath] = true
+		}
+	}
+	s.Items = items
+
+	return nil
+}
+
+// RefreshItems re-reads the state, title and update time of items from their
+// files, dropping items whose files were deleted. Archived items are kept
+// as they are until their files are restored.
+func RefreshItems(items []Item) ([]Item, error) {
+	items, _, err := refreshItems(items)
+	return items, err
+}
+
+// refreshItems is RefreshItems, also returning the metadata of each item,
+// which is nil for archived items.
+func refreshItems(items []Item) ([]Item, []*parser.ParsedFile, error) {
+	parsedByPath := make(map[string]*parser.ParsedFile)
+	err := parser.WalkMetadata(parser.WalkFilters{Repo: SingleRepo(items)}, func(parsed *parser.ParsedFile) error {
+		parsedByPath[parsed.FilePath] = parsed
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var refreshed []Item
+	var metadata []*parser.ParsedFile
+	for _, item := range items {
+		parsed, ok := parsedByPath[item.FilePath]
+		if !ok {
+			if item.Archived {
+				refreshed = append(refreshed, item)
+				metadata = append(metadata, nil)
+			}
+			continue
+		}
+		item.State = strings.ToLower(parsed.State)
+		item.Title = parsed.Title
+		item.Updated = parsed.Updated
+		item.Archived = false // Restored, if it was archived
+		refreshed = append(refreshed, item)
+		metadata = append(metadata, parsed)
+	}
+
+	return refreshed, metadata, nil
+}
+
+// SingleRepo returns the repository ("owner/repo") of the items if they all
+// belong to the same one, so walks can skip other repositories; otherwise "".
+func SingleRepo(items []Item) string {
+	repo := ""
+	for i, item := range items {
+		r := item.Owner + "/" + item.Repo
+		if i > 0 && r != repo {
+			return ""
+		}
+		repo = r
+	}
+	return repo
+}
+
+// isUsers reports whether user authored, is assigned to or was asked to review an item.
+func isUsers(parsed *parser.ParsedFile, user string) bool {
+	if strings.EqualFold(parsed.Author, user) {
+		return true
+	}
+	for _, names := range [][]string{parsed.Assignees, parsed.Reviewers} {
+		for _, name := range names {
+			if strings.EqualFold(name, user) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Visible returns the items passing the current filters, in the current sort
+// order, up to Limit. Mine only takes effect after Refresh.
+func (s *SelectorState) Visible() []Item {
+	var items []Item
+	for _, item := range s.Items {
+		switch item.Type {
+		case "issue":
+			if !s.Issues {
+				continue
+			}
+		case "pr":
+			if !s.PRs {
+				continue
+			}
+		case "discussion":
+			if !s.Discussions {
+				continue
+			}
+		}
+
+		switch s.State {
+		case StateOpen:
+			if item.State != "open" {
+				continue
+			}
+		case StateClosed:
+			if item.State != "closed" && item.State != "merged" {
+				continue
+			}
+		}
+
+		if s.Mine && s.mine != nil && !s.mine[item.FilePath] {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	SortItems(items, s.Sort, s.Reverse)
+	if s.Limit > 0 && len(items) > s.Limit {
+		items = items[:s.Limit]
+	}
+	return items
+}
+
+// Header summarizes the current filters in a single line.
+func (s *SelectorState) Header() string {
+	var types []string
+	for _, t := range []struct {
+		on   bool
+		name string
+	}{{s.Issues, "issues"}, {s.PRs, "prs"}, {s.Discussions, "discussions"}} {
+		if t.on {
+			types = append(types, t.name)
+		}
+	}
+	if len(types) == 0 {
+		types = append(types, "none")
+	}
+
+	owner := "all"
+	if s.Mine {
+		owner = "mine"
+	}
+
+	sortBy := string(s.Sort)
+	if s.Reverse {
+		sortBy += " (reversed)"
+	}
+
+	return fmt.Sprintf("Showing %s · state: %s · %s · sort: %s",
+		strings.Join(types, ", "), s.State, owner, sortBy)
+}
+
+// WriteLines writes the fzf input for the current state: a header line
+// summarizing the filters, followed by a line per visible item.
+func (s *SelectorState) WriteLines(w io.Writer) error {
+	// Like item lines, the header has an (empty) hidden first column
+	if _, err := fmt.Fprintf(w, "\t%s\n", s.Header()); err != nil {
+		return err
+	}
+	for _, item := range s.Visible() {
+		if _, err := fmt.Fprintln(w, selectorLine(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectorLine formats an item for fzf. The first, hidden column is the file path.
+func selectorLine(item Item) string {
+	// Format: filepath|owner/repo|#number|type|[state]|title
+	state := item.State
+	if item.Archived {
+		state += ", archived"
+	}
+	line := fmt.Sprintf("%s\t%s/%s\t#%d\t%s\t[%s]\t%s",
+		item.FilePath,
+		item.Owner, item.Repo,
+		item.Number,
+		item.Type,
+		state,
+		item.Title,
+	)
+	if item.Snippet != "" {
+		line += "\t" + item.Snippet
+	}
+	return line
+}
@@ -0,0 +1,218 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+func TestSelectorState_Toggle(t *testing.T) {
+	s := NewSelectorState([]Item{{Type: "issue"}}, SortUpdated)
+
+	var states []string
+	for range 3 {
+		if err := s.Toggle(ToggleState); err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, s.State)
+	}
+	if got := strings.Join(states, ","); got != "open,closed,all" {
+		t.Errorf("state cycle = %s", got)
+	}
+
+	var sorts []string
+	for range len(SortFields) {
+		_ = s.Toggle(ToggleSort)
+		sorts = append(sorts, string(s.Sort))
+	}
+	if got := strings.Join(sorts, ","); got != "created,number,comments,reactions,title,repo,updated" {
+		t.Errorf("sort cycle = %s", got)
+	}
+
+	// Relevance is offered for search results
+	s = NewSelectorState([]Item{{Snippet: "x"}}, SortRelevance)
+	for range len(SortFields) + 1 {
+		_ = s.Toggle(ToggleSort)
+	}
+	if s.Sort != SortRelevance {
+		t.Errorf("sort after full cycle = %s, want relevance", s.Sort)
+	}
+
+	if err := s.Toggle("bogus"); err == nil {
+		t.Error("expected error for unknown toggle")
+	}
+}
+
+func TestSelectorState_Visible(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	s := NewSelectorState([]Item{
+		{FilePath: "a", Type: "issue", State: "open", Number: 1, Updated: day(1)},
+		{FilePath: "b", Type: "pr", State: "merged", Number: 2, Updated: day(3)},
+		{FilePath: "c", Type: "discussion", State: "open", Number: 3, Updated: day(2)},
+	}, SortUpdated)
+
+	paths := func() string {
+		var p []string
+		for _, item := range s.Visible() {
+			p = append(p, item.FilePath)
+		}
+		return strings.Join(p, ",")
+	}
+
+	if got := paths(); got != "b,c,a" {
+		t.Errorf("Visible() = %s, want b,c,a", got)
+	}
+
+	s.PRs = false
+	if got := paths(); got != "c,a" {
+		t.Errorf("without PRs = %s, want c,a", got)
+	}
+
+	s.PRs = true
+	s.State = StateClosed
+	if got := paths(); got != "b" {
+		t.Errorf("closed = %s, want b", got)
+	}
+
+	s.State = StateAll
+	s.Sort = SortNumber
+	if got := paths(); got != "c,b,a" {
+		t.Errorf("by number = %s, want c,b,a", got)
+	}
+
+	// The base order is kept for relevance
+	s.Sort = SortRelevance
+	if got := paths(); got != "a,b,c" {
+		t.Errorf("by relevance = %s, want a,b,c", got)
+	}
+
+	s.Sort = SortNumber
+	s.Reverse = true
+	s.Limit = 2
+	if got := paths(); got != "a,b" {
+		t.Errorf("reversed with limit = %s, want a,b", got)
+	}
+}
+
+func TestSelectorState_RefreshAndSave(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	dir := filepath.Join(root, "o", "r", "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, author, state string) string {
+		path := filepath.Join(dir, name)
+		content := "---\nid: I_" + name + "\nowner: o\nrepo: r\nnumber: 1\nstate: " + state +
+			"\nauthor: " + author + "\n---\n\n<!-- gh-md:content -->\n# New title\n<!-- /gh-md:content -->\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	mine := write("1.md", "alice", "closed")
+	other := write("2.md", "bob", "open")
+
+	s := NewSelectorState([]Item{
+		{FilePath: mine, Owner: "o", Repo: "r", Type: "issue", State: "open", Title: "Old title"},
+		{FilePath: other, Owner: "o", Repo: "r", Type: "issue", State: "open"},
+		{FilePath: filepath.Join(dir, "3.md"), Owner: "o", Repo: "r", Type: "issue"},
+	}, SortUpdated)
+	s.Mine = true
+	s.User = "Alice"
+
+	if err := s.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Items) != 2 {
+		t.Fatalf("Refresh() kept %d items, want 2 (deleted file dropped)", len(s.Items))
+	}
+
+	visible := s.Visible()
+	if len(visible) != 1 || visible[0].FilePath != mine {
+		t.Fatalf("Visible() = %+v, want only the user's item", visible)
+	}
+	if visible[0].State != "closed" || visible[0].Title != "New title" {
+		t.Errorf("item not refreshed: %+v", visible[0])
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := s.Save(statePath); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSelectorState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Mine || loaded.User != "Alice" || len(loaded.Items) != 2 {
+		t.Errorf("loaded state = %+v", loaded)
+	}
+
+	var out strings.Builder
+	if err := s.WriteLines(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("WriteLines() = %q, want header and one item", out.String())
+	}
+	if lines[0] != "\tShowing issues, prs, discussions · state: all · mine · sort: updated" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], mine+"\t") {
+		t.Errorf("item line = %q", lines[1])
+	}
+}
+
+func TestSelectorState_RefreshKeepsArchived(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	dir := filepath.Join(root, "o", "r", "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	restored := filepath.Join(dir, "1.md")
+	content := "---\nid: I_1\nowner: o\nrepo: r\nnumber: 1\nstate: open\n---\n\n<!-- gh-md:content -->\n# Restored\n<!-- /gh-md:content -->\n"
+	if err := os.WriteFile(restored, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	archived := filepath.Join(dir, "2.md")
+
+	s := NewSelectorState([]Item{
+		{FilePath: restored, Owner: "o", Repo: "r", Type: "issue", State: "closed", Archived: true},
+		{FilePath: archived, Owner: "o", Repo: "r", Type: "issue", State: "closed", Title: "Archived", Archived: true},
+	}, SortUpdated)
+
+	if err := s.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Items) != 2 {
+		t.Fatalf("Refresh() kept %d items, want 2 (archived item kept)", len(s.Items))
+	}
+	if got := s.Items[0]; got.Archived || got.State != "open" || got.Title != "Restored" {
+		t.Errorf("restored item = %+v, want refreshed and no longer archived", got)
+	}
+	if got := s.Items[1]; !got.Archived || got.Title != "Archived" {
+		t.Errorf("archived item = %+v, want kept as is", got)
+	}
+}
+
+func TestSingleRepo(t *testing.T) {
+	a := Item{Owner: "o", Repo: "a"}
+	b := Item{Owner: "o", Repo: "b"}
+	if got := SingleRepo([]Item{a, a}); got != "o/a" {
+		t.Errorf("SingleRepo(same repo) = %q, want o/a", got)
+	}
+	if got := SingleRepo([]Item{a, b, a}); got != "" {
+		t.Errorf("SingleRepo(mixed) = %q, want empty", got)
+	}
+	if got := SingleRepo(nil); got != "" {
+		t.Errorf("SingleRepo(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSortItems(t *testing.T) {
+	items := []Item{
+		{FilePath: "a", Owner: "o", Repo: "zeta", Number: 1, Title: "banana", Comments: 2, Reactions: 0},
+		{FilePath: "b", Owner: "o", Repo: "alpha", Number: 2, Title: "Apple", Comments: 5, Reactions: 3},
+		{FilePath: "c", Owner: "o", Repo: "alpha", Number: 3, Title: "cherry", Comments: 2, Reactions: 9},
+	}
+
+	tests := []struct {
+		field   SortField
+		reverse bool
+		want    string
+	}{
+		{SortComments, false, "b,a,c"}, // Ties keep their order
+		{SortReactions, false, "c,b,a"},
+		{SortTitle, false, "b,a,c"},
+		{SortTitle, true, "c,a,b"},
+		{SortRepo, false, "c,b,a"},
+		{SortNumber, true, "a,b,c"},
+		{SortRelevance, true, "c,b,a"},
+	}
+
+	for _, tt := range tests {
+		sorted := append([]Item(nil), items...)
+		SortItems(sorted, tt.field, tt.reverse)
+
+		var paths []string
+		for _, item := range sorted {
+			paths = append(paths, item.FilePath)
+		}
+		if got := strings.Join(paths, ","); got != tt.want {
+			t.Errorf("SortItems(%s, reverse=%v) = %s, want %s", tt.field, tt.reverse, got, tt.want)
+		}
+	}
+}
+
+func TestParseSortField(t *testing.T) {
+	if f, err := ParseSortField(""); err != nil || f != SortUpdated {
+		t.Errorf("ParseSortField(\"\") = %v, %v", f, err)
+	}
+	if f, err := ParseSortField("reactions"); err != nil || f != SortReactions {
+		t.Errorf("ParseSortField(reactions) = %v, %v", f, err)
+	}
+	if _, err := ParseSortField("relevance"); err == nil {
+		t.Error("expected error for relevance, which only applies to search results")
+	}
+}
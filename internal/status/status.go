@@ -0,0 +1,119 @@
+package status
+
+import (
+	"path/filepath"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+)
+
+// State describes why a local file needs attention.
+type State string
+
+const (
+	StateModified State = "modified" // Edited since last pull
+	StatePending  State = "pending"  // Has new-comment drafts waiting to be pushed
+	StateConflict State = "conflict" // Remote updated since last pull
+	StateDraft    State = "draft"    // Not yet created on GitHub (no ID)
+)
+
+// Entry is a local file with one or more states.
+type Entry struct {
+	File   *parser.ParsedFile
+	States []State
+}
+
+// Has reports whether the entry has the given state.
+func (e *Entry) Has(s State) bool {
+	for _, state := range e.States {
+		if state == s {
+			return true
+		}
+	}
+	return false
+}
+
+// RelativePath returns the path relative to the gh-md root for display.
+func (e *Entry) RelativePath() string {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return e.File.FilePath
+	}
+	rel, err := filepath.Rel(root, e.File.FilePath)
+	if err != nil {
+		return e.File.FilePath
+	}
+	return rel
+}
+
+// Scan walks the gh-md root and returns files with local changes.
+// If repoFilter is non-empty (format: "owner/repo"), only files from that repo are included.
+// Only local states are detected; use CheckConflicts to compare with GitHub.
+func Scan(repoFilter string) ([]Entry, error) {
+	var entries []Entry
+
+	err := parser.WalkParsedFiles(parser.WalkFilters{Repo: repoFilter}, func(parsed *parser.ParsedFile) error {
+		if states := LocalStates(parsed); len(states) > 0 {
+			entries = append(entries, Entry{File: parsed, States: states})
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// LocalStates returns the local states of a parsed file, without asking GitHub.
+func LocalStates(parsed *parser.ParsedFile) []State {
+	var states []State
+
+	if parsed.ID == "" {
+		states = append(states, StateDraft)
+	}
+	if parsed.Modified {
+		states = append(states, StateModified)
+	}
+	for _, c := range parsed.Comments {
+		if c.ID == "" {
+			states = append(states, StatePending)
+			break
+		}
+	}
+
+	return states
+}
+
+// RemoteStateFetcher fetches the remote state of an item.
+type RemoteStateFetcher interface {
+	FetchRemoteState(itemType github.ItemType, owner, repo string, number int) (github.RemoteState, error)
+}
+
+// CheckConflicts marks entries whose remote item was updated after the local copy.
+// Drafts are skipped since they have no remote counterpart. The callback, if non-nil,
+// is called before each fetch. Returns the first fetch error encountered.
+func CheckConflicts(fetcher RemoteStateFetcher, entries []Entry, progress func(e *Entry)) error {
+	for i := range entries {
+		e := &entries[i]
+		if e.Has(StateDraft) || e.File.ItemType == "" {
+			continue
+		}
+
+		if progress != nil {
+			progress(e)
+		}
+
+		remote, err := fetcher.FetchRemoteState(e.File.ItemType, e.File.Owner, e.File.Repo, e.File.Number)
+		if err != nil {
+			return err
+		}
+		if remote.UpdatedAt.After(e.File.Updated) {
+			e.States = append(e.States, StateConflict)
+		}
+	}
+
+	return nil
+}
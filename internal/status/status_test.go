@@ -0,0 +1,140 @@
+package status
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/writer"
+)
+
+const issueTemplate = `---
+id: %ID%
+owner: owner
+repo: repo
+number: %NUM%
+state: open
+updated: 2026-01-01T00:00:00Z
+---
+
+<!-- gh-md:content -->
+# Title
+Body
+<!-- /gh-md:content -->
+
+<!-- gh-md:new-comment -->
+
+<!-- /gh-md:new-comment -->
+`
+
+func writeIssue(t *testing.T, root, id, num string, edit func(string) string) {
+	t.Helper()
+	content := strings.NewReplacer("%ID%", id, "%NUM%", num).Replace(issueTemplate)
+	content = writer.StampContentHash(content)
+	if edit != nil {
+		content = edit(content)
+	}
+
+	dir := filepath.Join(root, "owner", "repo", "issues")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, num+".md"), []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	writeIssue(t, root, "I_1", "1", nil)
+	writeIssue(t, root, "I_2", "2", func(s string) string { return strings.Replace(s, "Body", "Edited", 1) })
+	writeIssue(t, root, "I_3", "3", func(s string) string {
+		return strings.Replace(s, "<!-- gh-md:new-comment -->\n", "<!-- gh-md:new-comment -->\nDraft reply\n", 1)
+	})
+	writeIssue(t, root, "", "4", nil)
+
+	entries, err := Scan("")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	got := make(map[int][]State)
+	for _, e := range entries {
+		got[e.File.Number] = e.States
+	}
+
+	want := map[int][]State{
+		2: {StateModified},
+		3: {StateModified, StatePending},
+		4: {StateDraft},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Scan() returned %v, want %v", got, want)
+	}
+	for num, states := range want {
+		if !slices.Equal(got[num], states) {
+			t.Errorf("#%d states = %v, want %v", num, got[num], states)
+		}
+	}
+}
+
+type fakeFetcher struct {
+	updated map[int]time.Time
+	err     error
+}
+
+func (f *fakeFetcher) FetchRemoteState(_ github.ItemType, _, _ string, number int) (github.RemoteState, error) {
+	if f.err != nil {
+		return github.RemoteState{}, f.err
+	}
+	return github.RemoteState{UpdatedAt: f.updated[number]}, nil
+}
+
+func TestCheckConflicts(t *testing.T) {
+	local := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newEntry := func(num int, states ...State) Entry {
+		return Entry{
+			File:   &parser.ParsedFile{ID: "I", Number: num, Updated: local, ItemType: github.ItemTypeIssue},
+			States: states,
+		}
+	}
+
+	entries := []Entry{
+		newEntry(1, StateModified),
+		newEntry(2, StateModified),
+		newEntry(3, StateDraft),
+	}
+	fetcher := &fakeFetcher{updated: map[int]time.Time{
+		1: local,
+		2: local.Add(time.Hour),
+		3: local.Add(time.Hour),
+	}}
+
+	if err := CheckConflicts(fetcher, entries, nil); err != nil {
+		t.Fatalf("CheckConflicts() error = %v", err)
+	}
+
+	if entries[0].Has(StateConflict) {
+		t.Error("#1 marked as conflict, remote not newer")
+	}
+	if !entries[1].Has(StateConflict) {
+		t.Error("#2 not marked as conflict, remote is newer")
+	}
+	if entries[2].Has(StateConflict) {
+		t.Error("draft #3 should not be checked")
+	}
+
+	fetcher.err = errors.New("boom")
+	if err := CheckConflicts(fetcher, entries[:1], nil); err == nil {
+		t.Error("CheckConflicts() error = nil, want fetch error")
+	}
+}
@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// KeyCode identifies a key press. Printable characters are KeyRune.
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPgUp
+	KeyPgDn
+	KeyHome
+	KeyEnd
+	KeyTab
+	KeyBacktab
+	KeyEnter
+	KeyBackspace
+	KeyEsc
+	KeyCtrlC
+	KeyCtrlD
+	KeyCtrlU
+	KeyUnknown
+)
+
+// Key is a decoded key press.
+type Key struct {
+	Code KeyCode
+	Rune rune // Set for KeyRune
+}
+
+// escapeSequences maps terminal escape sequences (after ESC) to keys.
+var escapeSequences = map[string]KeyCode{
+	"[A":  KeyUp,
+	"[B":  KeyDown,
+	"[C":  KeyRight,
+	"[D":  KeyLeft,
+	"OA":  KeyUp,
+	"OB":  KeyDown,
+	"OC":  KeyRight,
+	"OD":  KeyLeft,
+	"[5~": KeyPgUp,
+	"[6~": KeyPgDn,
+	"[H":  KeyHome,
+	"[F":  KeyEnd,
+	"OH":  KeyHome,
+	"OF":  KeyEnd,
+	"[1~": KeyHome,
+	"[4~": KeyEnd,
+	"[Z":  KeyBacktab,
+}
+
+// decodeKeys splits raw terminal input into key presses. A lone ESC is the
+// Escape key; unknown escape sequences become KeyUnknown.
+func decodeKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) == 1 {
+				keys = append(keys, Key{Code: KeyEsc})
+				b = b[1:]
+				continue
+			}
+			// Sequences end with a letter or '~'
+			end := 1
+			for end < len(b) && end < 8 {
+				ch := b[end]
+				end++
+				if end > 2 && (ch == '~' || (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z')) {
+					break
+				}
+			}
+			code, ok := escapeSequences[string(b[1:end])]
+			if !ok {
+				code = KeyUnknown
+			}
+			keys = append(keys, Key{Code: code})
+			b = b[end:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+			b = b[1:]
+		case c == '\t':
+			keys = append(keys, Key{Code: KeyTab})
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+			b = b[1:]
+		case c == 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+			b = b[1:]
+		case c == 0x04:
+			keys = append(keys, Key{Code: KeyCtrlD})
+			b = b[1:]
+		case c == 0x15:
+			keys = append(keys, Key{Code: KeyCtrlU})
+			b = b[1:]
+		case c < 0x20:
+			keys = append(keys, Key{Code: KeyUnknown})
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, Key{Code: KeyRune, Rune: r})
+			b = b[size:]
+		}
+	}
+	return keys
+}
+
+// terminal is the controlling terminal in raw mode, showing the alternate screen.
+type terminal struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+}
+
+func openTerminal() (*terminal, error) {
+	in, out := os.Stdin, os.Stdout
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, errors.New("the terminal UI needs an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Alternate screen, hidden cursor
+	_, _ = out.WriteString("\x1b[?1049h\x1b[?25l")
+	return &terminal{in: in, out: out, state: state}, nil
+}
+
+// close restores the terminal to how it was before openTerminal.
+func (t *terminal) close() {
+	_, _ = t.out.WriteString("\x1b[?25h\x1b[?1049l")
+	_ = term.Restore(int(t.in.Fd()), t.state)
+}
+
+// size returns the terminal size, defaulting to 80x24.
+func (t *terminal) size() (int, int) {
+	w, h, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+// readKeys blocks until input is available and returns the keys read.
+func (t *terminal) readKeys() ([]Key, error) {
+	buf := make([]byte, 256)
+	n, err := t.in.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return decodeKeys(buf[:n]), nil
+}
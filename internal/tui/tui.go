@@ -0,0 +1,496 @@
+// Package tui is a built-in terminal UI for browsing local items, used instead
+// of fzf when requested or when fzf isn't installed.
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackchuka/gh-md/internal/output"
+	"github.com/jackchuka/gh-md/internal/parser"
+	"github.com/jackchuka/gh-md/internal/render"
+	"github.com/jackchuka/gh-md/internal/search"
+)
+
+// minPreviewWidth is the narrowest terminal that still shows the preview pane.
+const minPreviewWidth = 80
+
+// tab narrows the list to an item type ("" = all).
+type tab struct {
+	name     string
+	itemType string
+}
+
+var tabs = []tab{
+	{"All", ""},
+	{"Issues", "issue"},
+	{"PRs", "pr"},
+	{"Discussions", "discussion"},
+}
+
+// FilterFunc returns the items matching a CEL expression.
+type FilterFunc func(expr string, items []search.Item) ([]search.Item, error)
+
+// Model is the state of the terminal UI. It survives between runs, so the
+// selection, tab and filter are kept while an action runs outside the UI.
+type Model struct {
+	items   []search.Item
+	sortBy  search.SortField
+	reverse bool
+	filter  FilterFunc
+
+	expr     string        // Applied CEL filter
+	filtered []search.Item // Items matching expr; nil = no filter
+
+	tab           int
+	cursor        int
+	offset        int // First visible list row
+	previewOffset int
+	hidePreview   bool
+
+	editing bool   // The filter bar has focus
+	input   []rune // Filter being edited
+	status  string // One-off message shown in the filter bar
+
+	previews map[string][]string // Rendered previews by file path
+	width    int                 // Preview width the cache was rendered at
+}
+
+// NewModel returns a model browsing items, sorted by sortBy (reversed if reverse
+// is set). filter evaluates CEL expressions typed in the filter bar.
+func NewModel(items []search.Item, sortBy search.SortField, reverse bool, filter FilterFunc) *Model {
+	m := &Model{sortBy: sortBy, reverse: reverse, filter: filter}
+	m.SetItems(items)
+	return m
+}
+
+// SetItems replaces the items, e.g. after an action changed them, keeping the
+// selection and reapplying the filter.
+func (m *Model) SetItems(items []search.Item) {
+	selected := m.Selected()
+
+	search.SortItems(items, m.sortBy, m.reverse)
+	m.items = items
+	m.previews = nil
+
+	if m.expr != "" {
+		if err := m.applyFilter(m.expr); err != nil {
+			m.status = err.Error()
+		}
+	}
+
+	if selected != nil {
+		m.Select(func(item search.Item) bool { return item.FilePath == selected.FilePath })
+	}
+}
+
+// Select moves the cursor to the first visible item matching fn.
+func (m *Model) Select(fn func(search.Item) bool) {
+	for i, item := range m.visible() {
+		if fn(item) {
+			m.cursor = i
+			m.previewOffset = 0
+			return
+		}
+	}
+}
+
+// Selected returns the item under the cursor, or nil if the list is empty.
+func (m *Model) Selected() *search.Item {
+	items := m.visible()
+	if len(items) == 0 {
+		return nil
+	}
+	m.cursor = min(m.cursor, len(items)-1)
+	item := items[m.cursor]
+	return &item
+}
+
+// tabItems returns the filtered items of a tab.
+func (m *Model) tabItems(t int) []search.Item {
+	items := m.items
+	if m.filtered != nil {
+		items = m.filtered
+	}
+	if tabs[t].itemType == "" {
+		return items
+	}
+
+	var out []search.Item
+	for _, item := range items {
+		if item.Type == tabs[t].itemType {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func (m *Model) visible() []search.Item {
+	return m.tabItems(m.tab)
+}
+
+func (m *Model) applyFilter(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		m.expr, m.filtered = "", nil
+		return nil
+	}
+
+	filtered, err := m.filter(expr, m.items)
+	if err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	if filtered == nil {
+		filtered = []search.Item{}
+	}
+	search.SortItems(filtered, m.sortBy, m.reverse)
+	m.expr, m.filtered = expr, filtered
+	return nil
+}
+
+// Run shows the terminal UI until the user picks an action on an item or quits
+// (search.ActionCancel with a nil item).
+func Run(m *Model) (search.Action, *search.Item, error) {
+	t, err := openTerminal()
+	if err != nil {
+		return search.ActionCancel, nil, err
+	}
+	defer t.close()
+
+	for {
+		w, h := t.size()
+		_, _ = t.out.WriteString(m.view(w, h))
+
+		keys, err := t.readKeys()
+		if err != nil {
+			return search.ActionCancel, nil, err
+		}
+		for _, k := range keys {
+			action, done := m.handleKey(k, h)
+			if !done {
+				continue
+			}
+			if action == search.ActionCancel {
+				return action, nil, nil
+			}
+			if item := m.Selected(); item != nil {
+				return action, item, nil
+			}
+		}
+	}
+}
+
+// actionKeys maps keys to actions on the selected item.
+var actionKeys = map[rune]search.Action{
+	'e': search.ActionOpenEditor,
+	'p': search.ActionPush,
+	'r': search.ActionPullFresh,
+	'o': search.ActionViewBrowser,
+	'y': search.ActionCopyPath,
+	'c': search.ActionComment,
+}
+
+// handleKey updates the model for a key press. It reports done when the UI
+// should return action; ActionCancel means quit.
+func (m *Model) handleKey(k Key, height int) (search.Action, bool) {
+	if m.editing {
+		m.handleFilterKey(k)
+		return "", false
+	}
+	m.status = ""
+
+	page := max(m.listHeight(height)-1, 1)
+	n := len(m.visible())
+
+	switch k.Code {
+	case KeyCtrlC, KeyEsc:
+		return search.ActionCancel, true
+	case KeyUp:
+		m.move(-1, n)
+	case KeyDown:
+		m.move(1, n)
+	case KeyPgUp:
+		m.move(-page, n)
+	case KeyPgDn:
+		m.move(page, n)
+	case KeyHome:
+		m.move(-n, n)
+	case KeyEnd:
+		m.move(n, n)
+	case KeyTab:
+		m.switchTab(1)
+	case KeyBacktab:
+		m.switchTab(-1)
+	case KeyCtrlD:
+		m.previewOffset += page / 2
+	case KeyCtrlU:
+		m.previewOffset = max(m.previewOffset-page/2, 0)
+	case KeyEnter:
+		if n > 0 {
+			return search.ActionOpenEditor, true
+		}
+	case KeyRune:
+		switch k.Rune {
+		case 'q':
+			return search.ActionCancel, true
+		case 'j':
+			m.move(1, n)
+		case 'k':
+			m.move(-1, n)
+		case 'g':
+			m.move(-n, n)
+		case 'G':
+			m.move(n, n)
+		case '1', '2', '3', '4':
+			m.tab = int(k.Rune - '1')
+			m.cursor, m.offset, m.previewOffset = 0, 0, 0
+		case '/':
+			m.editing = true
+			m.input = []rune(m.expr)
+		case 'v':
+			m.hidePreview = !m.hidePreview
+		default:
+			if action, ok := actionKeys[k.Rune]; ok && n > 0 {
+				return action, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (m *Model) handleFilterKey(k Key) {
+	switch k.Code {
+	case KeyEsc, KeyCtrlC:
+		m.editing = false
+	case KeyEnter:
+		m.editing = false
+		if err := m.applyFilter(string(m.input)); err != nil {
+			m.status = err.Error()
+			return
+		}
+		m.cursor, m.offset, m.previewOffset = 0, 0, 0
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case KeyCtrlU:
+		m.input = nil
+	case KeyRune:
+		m.input = append(m.input, k.Rune)
+	}
+}
+
+func (m *Model) move(delta, n int) {
+	if n == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), n-1)
+	m.previewOffset = 0
+}
+
+func (m *Model) switchTab(delta int) {
+	m.tab = (m.tab + delta + len(tabs)) % len(tabs)
+	m.cursor, m.offset, m.previewOffset = 0, 0, 0
+}
+
+// listHeight returns the number of list rows for a terminal height: everything
+// but the tab bar, filter bar and help line.
+func (m *Model) listHeight(height int) int {
+	return max(height-3, 1)
+}
+
+// view renders a full frame for a terminal of the given size.
+func (m *Model) view(width, height int) string {
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+
+	rows := m.listHeight(height)
+	items := m.visible()
+	if len(items) > 0 {
+		m.cursor = min(m.cursor, len(items)-1)
+	}
+	// Keep the cursor in view
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+
+	listWidth := width
+	var preview []string
+	if !m.hidePreview && width >= minPreviewWidth {
+		listWidth = width * 2 / 5
+		preview = m.preview(width - listWidth - 3)
+		m.previewOffset = min(m.previewOffset, max(len(preview)-rows, 0))
+	}
+
+	m.line(&sb, m.tabBar(), width)
+	m.line(&sb, m.filterBar(), width)
+
+	for row := range rows {
+		i := m.offset + row
+		left := ""
+		if i < len(items) {
+			left = listLine(items[i], multiRepo(m.items), listWidth-1)
+			if i == m.cursor {
+				left = "\x1b[7m" + fit(stripANSI(left), listWidth-1) + output.ColorReset
+			}
+		} else if row == 0 && len(items) == 0 {
+			left = output.Colorize(output.ColorDim, "No items")
+		}
+
+		line := fit(left, listWidth-1)
+		if preview != nil {
+			right := ""
+			if j := m.previewOffset + row; j < len(preview) {
+				right = preview[j]
+			}
+			line += " " + output.Colorize(output.ColorDim, "│") + " " + right
+		}
+		m.line(&sb, line, width)
+	}
+
+	help := "↑↓ move  Tab type  / filter  e edit  p push  r pull  o browser  y copy  c comment  v preview  q quit"
+	sb.WriteString(fit(output.Colorize(output.ColorDim, help), width))
+	sb.WriteString("\x1b[K")
+	return sb.String()
+}
+
+// line writes one frame row, cut to width and clearing the rest of the row.
+func (m *Model) line(sb *strings.Builder, s string, width int) {
+	sb.WriteString(truncate(s, width))
+	sb.WriteString(output.ColorReset + "\x1b[K\r\n")
+}
+
+func (m *Model) tabBar() string {
+	var parts []string
+	for i, t := range tabs {
+		label := fmt.Sprintf(" %s (%d) ", t.name, len(m.tabItems(i)))
+		if i == m.tab {
+			label = "\x1b[7m" + label + output.ColorReset
+		}
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m *Model) filterBar() string {
+	switch {
+	case m.editing:
+		return "/ " + string(m.input) + "\x1b[7m \x1b[0m"
+	case m.status != "":
+		return output.Colorize(output.ColorRed, m.status)
+	case m.expr != "":
+		return output.Colorize(output.ColorCyan, "filter: ") + m.expr
+	default:
+		return output.Colorize(output.ColorDim, `Press / to filter with CEL, e.g. state == "open" && "bug" in labels`)
+	}
+}
+
+// preview returns the rendered lines of the selected item, cached per file.
+func (m *Model) preview(width int) []string {
+	item := m.Selected()
+	if item == nil {
+		return []string{}
+	}
+	if m.previews == nil || m.width != width {
+		m.previews = make(map[string][]string)
+		m.width = width
+	}
+	if lines, ok := m.previews[item.FilePath]; ok {
+		return lines
+	}
+
+	var lines []string
+	parsed, err := parser.ParseFile(item.FilePath)
+	if err != nil {
+		lines = []string{output.Colorize(output.ColorRed, fmt.Sprintf("failed to parse file: %v", err))}
+	} else {
+		lines = strings.Split(strings.TrimRight(render.Item(parsed, render.Options{Width: width, Color: true}), "\n"), "\n")
+	}
+	m.previews[item.FilePath] = lines
+	return lines
+}
+
+// listLine formats an item for the list pane.
+func listLine(item search.Item, withRepo bool, width int) string {
+	color := output.ColorGreen
+	switch item.State {
+	case "closed":
+		color = output.ColorRed
+	case "merged":
+		color = output.ColorMagenta
+	}
+
+	ref := fmt.Sprintf("#%d", item.Number)
+	if withRepo {
+		ref = fmt.Sprintf("%s/%s#%d", item.Owner, item.Repo, item.Number)
+	}
+	line := output.Colorize(color, "●") + " " + output.Colorize(output.ColorDim, ref) + " " + item.Title
+	if item.Archived {
+		line += " " + output.Colorize(output.ColorDim, "(archived)")
+	}
+	return truncate(line, width)
+}
+
+// multiRepo reports whether items span more than one repository.
+func multiRepo(items []search.Item) bool {
+	for _, item := range items {
+		if item.Owner != items[0].Owner || item.Repo != items[0].Repo {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate cuts s to width visible columns, keeping ANSI codes intact.
+func truncate(s string, width int) string {
+	var sb strings.Builder
+	cols := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			// Copy the escape sequence up to its final letter
+			j := i + 1
+			for j < len(s) && !(s[j] >= 'A' && s[j] <= 'Z' || s[j] >= 'a' && s[j] <= 'z') {
+				j++
+			}
+			j = min(j+1, len(s))
+			sb.WriteString(s[i:j])
+			i = j
+			continue
+		}
+		if cols == width {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		sb.WriteString(s[i : i+size])
+		cols++
+		i += size
+	}
+	return sb.String()
+}
+
+// fit truncates or pads s to exactly width visible columns.
+func fit(s string, width int) string {
+	s = truncate(s, width)
+	if pad := width - utf8.RuneCountInString(stripANSI(s)); pad > 0 {
+		s += output.ColorReset + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// stripANSI removes ANSI escape sequences from s.
+func stripANSI(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x1b {
+			for i < len(s) && !(s[i] >= 'A' && s[i] <= 'Z' || s[i] >= 'a' && s[i] <= 'z') {
+				i++
+			}
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
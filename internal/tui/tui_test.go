@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackchuka/gh-md/internal/search"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	got := decodeKeys([]byte("a\x1b[A\x1b[6~\t\x1b[Z\r\x7fé\x1b"))
+	want := []Key{
+		{Code: KeyRune, Rune: 'a'},
+		{Code: KeyUp},
+		{Code: KeyPgDn},
+		{Code: KeyTab},
+		{Code: KeyBacktab},
+		{Code: KeyEnter},
+		{Code: KeyBackspace},
+		{Code: KeyRune, Rune: 'é'},
+		{Code: KeyEsc},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decodeKeys() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("key %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func testItems() []search.Item {
+	return []search.Item{
+		{FilePath: "/r/issues/1.md", Owner: "o", Repo: "r", Number: 1, Type: "issue", State: "open", Title: "First issue"},
+		{FilePath: "/r/pulls/2.md", Owner: "o", Repo: "r", Number: 2, Type: "pr", State: "merged", Title: "A pull request"},
+		{FilePath: "/r/issues/3.md", Owner: "o", Repo: "r", Number: 3, Type: "issue", State: "closed", Title: "Third issue"},
+	}
+}
+
+func press(m *Model, keys string) (search.Action, bool) {
+	var action search.Action
+	var done bool
+	for _, k := range decodeKeys([]byte(keys)) {
+		if action, done = m.handleKey(k, 24); done {
+			break
+		}
+	}
+	return action, done
+}
+
+func TestModel_NavigationAndTabs(t *testing.T) {
+	m := NewModel(testItems(), search.SortNumber, false, nil)
+
+	if got := m.Selected().Number; got != 3 {
+		t.Errorf("initial selection = #%d, want #3 (sorted by number)", got)
+	}
+
+	press(m, "jj")
+	if got := m.Selected().Number; got != 1 {
+		t.Errorf("after jj = #%d, want #1", got)
+	}
+	press(m, "j") // Stays on the last item
+	if got := m.Selected().Number; got != 1 {
+		t.Errorf("after moving past the end = #%d, want #1", got)
+	}
+
+	press(m, "\t\t") // PRs tab
+	items := m.visible()
+	if len(items) != 1 || items[0].Type != "pr" {
+		t.Errorf("PRs tab = %+v", items)
+	}
+
+	press(m, "\x1b[Z") // Back to issues
+	if n := len(m.visible()); n != 2 {
+		t.Errorf("issues tab has %d items, want 2", n)
+	}
+
+	if action, done := press(m, "e"); !done || action != search.ActionOpenEditor {
+		t.Errorf("e = %v, %v", action, done)
+	}
+	if action, done := press(m, "q"); !done || action != search.ActionCancel {
+		t.Errorf("q = %v, %v", action, done)
+	}
+}
+
+func TestModel_Filter(t *testing.T) {
+	var gotExpr string
+	filter := func(expr string, items []search.Item) ([]search.Item, error) {
+		gotExpr = expr
+		if expr == "bad" {
+			return nil, errors.New("syntax error")
+		}
+		var out []search.Item
+		for _, item := range items {
+			if item.State == expr {
+				out = append(out, item)
+			}
+		}
+		return out, nil
+	}
+	m := NewModel(testItems(), search.SortNumber, false, filter)
+
+	// Keys typed in the filter bar aren't actions
+	if _, done := press(m, "/open\r"); done {
+		t.Fatal("typing a filter triggered an action")
+	}
+	if gotExpr != "open" {
+		t.Errorf("filter expression = %q, want open", gotExpr)
+	}
+	if items := m.visible(); len(items) != 1 || items[0].Number != 1 {
+		t.Errorf("filtered items = %+v", items)
+	}
+
+	// Errors are shown and keep the previous filter
+	press(m, "/\x15bad\r")
+	if !strings.Contains(m.status, "syntax error") || m.expr != "open" {
+		t.Errorf("status = %q, expr = %q", m.status, m.expr)
+	}
+
+	// The filter is reapplied when items change
+	items := testItems()
+	items[2].State = "open"
+	m.SetItems(items)
+	if n := len(m.visible()); n != 2 {
+		t.Errorf("after SetItems %d items visible, want 2", n)
+	}
+
+	// An empty filter shows everything again
+	press(m, "/\x15\r")
+	if n := len(m.visible()); n != 3 {
+		t.Errorf("after clearing %d items visible, want 3", n)
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	m := NewModel(testItems(), search.SortNumber, false, nil)
+	m.hidePreview = true
+
+	frame := stripANSI(m.view(60, 10))
+	for _, want := range []string{"All (3)", "Issues (2)", "PRs (1)", "Discussions (0)", "#2 A pull request", "↑↓ move"} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("frame missing %q:\n%s", want, frame)
+		}
+	}
+	if strings.Contains(frame, "o/r#2") {
+		t.Error("repo shown although all items are in one repo")
+	}
+}
+
+func TestTruncateAndFit(t *testing.T) {
+	s := "\x1b[1mhello\x1b[0m world"
+	if got := stripANSI(truncate(s, 7)); got != "hello w" {
+		t.Errorf("truncate() = %q, want %q", got, "hello w")
+	}
+	if got := fit("ab", 5); stripANSI(got) != "ab   " {
+		t.Errorf("fit() = %q", got)
+	}
+	if got := fit("abcdef", 3); stripANSI(got) != "abc" {
+		t.Errorf("fit() = %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackchuka/gh-md/internal/config"
+)
+
+// BaseDirName is the hidden per-repo directory holding the content of each item
+// as it was last pulled, used to show what changed upstream.
+const BaseDirName = config.BaseDirName
+
+// BasePath returns the base copy path for an item file.
+// For <repo>/issues/1.md this is <repo>/.base/issues/1.md.
+func BasePath(path string) string {
+	typeDir := filepath.Dir(path)
+	return filepath.Join(filepath.Dir(typeDir), BaseDirName, filepath.Base(typeDir), filepath.Base(path))
+}
+
+// ReadBase returns the base copy of an item file.
+func ReadBase(path string) (string, error) {
+	data, err := os.ReadFile(BasePath(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("no base copy for %s (pull it again to create one)", path)
+		}
+		return "", fmt.Errorf("failed to read base copy: %w", err)
+	}
+	return string(data), nil
+}
+
+// RemoveBase deletes the base copy of an item file, if any.
+func RemoveBase(path string) error {
+	if err := os.Remove(BasePath(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// writeBase records content as the base copy of an item file.
+func writeBase(path, content string) error {
+	basePath := BasePath(path)
+	if err := os.MkdirAll(filepath.Dir(basePath), 0755); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+	return WriteFile(basePath, content)
+}
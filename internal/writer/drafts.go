@@ -0,0 +1,220 @@
+package writer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	newCommentOpen  = "<!-- gh-md:new-comment"
+	newCommentClose = "<!-- /gh-md:new-comment -->"
+)
+
+// NewCommentAttrs are the attributes of a new-comment opening tag, e.g.
+// "<!-- gh-md:new-comment reply_to: DC_1 local_id: lc_0a1b2c3d -->".
+type NewCommentAttrs struct {
+	ReplyTo string // Parent comment or review thread ID
+	LocalID string // Client-side ID assigned before the first push attempt
+	Posted  string // ID of the comment created on GitHub from this draft
+}
+
+// ParseNewCommentTag parses the attributes of a new-comment opening tag.
+// Unknown attributes are ignored.
+func ParseNewCommentTag(tag string) NewCommentAttrs {
+	tag = strings.TrimPrefix(tag, newCommentOpen)
+	tag = strings.TrimSuffix(tag, "-->")
+
+	var attrs NewCommentAttrs
+	fields := strings.Fields(tag)
+	for i := 0; i+1 < len(fields); i++ {
+		key, ok := strings.CutSuffix(fields[i], ":")
+		if !ok {
+			continue
+		}
+		value := fields[i+1]
+		switch key {
+		case "reply_to":
+			attrs.ReplyTo = value
+		case "local_id":
+			attrs.LocalID = value
+		case "posted":
+			attrs.Posted = value
+		}
+		i++
+	}
+	return attrs
+}
+
+// Tag renders the opening tag for the attributes.
+func (a NewCommentAttrs) Tag() string {
+	var sb strings.Builder
+	sb.WriteString(newCommentOpen)
+	if a.ReplyTo != "" {
+		sb.WriteString(" reply_to: " + a.ReplyTo)
+	}
+	if a.LocalID != "" {
+		sb.WriteString(" local_id: " + a.LocalID)
+	}
+	if a.Posted != "" {
+		sb.WriteString(" posted: " + a.Posted)
+	}
+	sb.WriteString(" -->")
+	return sb.String()
+}
+
+// baseTag returns the tag as written by pull, without push bookkeeping attributes.
+func baseTag(tag string) string {
+	return NewCommentAttrs{ReplyTo: ParseNewCommentTag(tag).ReplyTo}.Tag()
+}
+
+// draftBlock is a new-comment block with its opening tag and raw inner content.
+type draftBlock struct {
+	tag   string // e.g. "<!-- gh-md:new-comment reply_to: DC_1 -->"
+	inner string
+}
+
+// blockSpan locates a new-comment block within content.
+type blockSpan struct {
+	draftBlock
+	start      int // Start of the opening tag
+	innerStart int
+	innerEnd   int
+}
+
+// findNewCommentBlocks returns all well-formed new-comment blocks in content.
+func findNewCommentBlocks(content string) []blockSpan {
+	var spans []blockSpan
+
+	offset := 0
+	for {
+		start := strings.Index(content[offset:], newCommentOpen)
+		if start == -1 {
+			break
+		}
+		start += offset
+
+		tagEnd := strings.Index(content[start:], "-->")
+		if tagEnd == -1 {
+			break
+		}
+		tagEnd += start + len("-->")
+
+		closeIdx := strings.Index(content[tagEnd:], newCommentClose)
+		if closeIdx == -1 {
+			break
+		}
+		closeIdx += tagEnd
+
+		spans = append(spans, blockSpan{
+			draftBlock: draftBlock{tag: content[start:tagEnd], inner: content[tagEnd:closeIdx]},
+			start:      start,
+			innerStart: tagEnd,
+			innerEnd:   closeIdx,
+		})
+		offset = closeIdx + len(newCommentClose)
+	}
+
+	return spans
+}
+
+// extractDrafts returns content with every new-comment block emptied and its tag
+// reset, along with the non-empty drafts that were removed. Drafts already posted
+// to GitHub are dropped, since the pulled content includes them as comments.
+func extractDrafts(content string) (string, []draftBlock) {
+	var drafts []draftBlock
+	var sb strings.Builder
+
+	last := 0
+	for _, span := range findNewCommentBlocks(content) {
+		empty := strings.TrimSpace(span.inner) == ""
+		tag := baseTag(span.tag)
+		if empty && tag == span.tag {
+			continue
+		}
+		if !empty && ParseNewCommentTag(span.tag).Posted == "" {
+			drafts = append(drafts, span.draftBlock)
+		}
+		sb.WriteString(content[last:span.start])
+		sb.WriteString(tag)
+		sb.WriteString("\n\n")
+		last = span.innerEnd
+	}
+	sb.WriteString(content[last:])
+
+	return sb.String(), drafts
+}
+
+// mergeDrafts places drafts into the empty new-comment block with the same reply
+// target in content. Drafts whose block no longer exists are appended at the end
+// so they are never lost.
+func mergeDrafts(content string, drafts []draftBlock) string {
+	for _, d := range drafts {
+		placed := false
+		for _, span := range findNewCommentBlocks(content) {
+			if span.tag == baseTag(d.tag) && strings.TrimSpace(span.inner) == "" {
+				content = content[:span.start] + d.tag + d.inner + content[span.innerEnd:]
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			content = strings.TrimRight(content, "\n") + "\n\n" + d.tag + d.inner + newCommentClose + "\n"
+		}
+	}
+	return content
+}
+
+// AddDraft places body as a new comment draft, replying to replyTo if set, into
+// the empty new-comment block for that target, or appends a block if there is none.
+// The draft is given a local ID, which is returned so it can be pushed on its own.
+func AddDraft(content, body, replyTo string) (string, string) {
+	localID := newLocalID()
+	d := draftBlock{
+		tag:   NewCommentAttrs{ReplyTo: replyTo, LocalID: localID}.Tag(),
+		inner: "\n\n" + strings.TrimSpace(body) + "\n\n",
+	}
+	return mergeDrafts(content, []draftBlock{d}), localID
+}
+
+// AssignLocalIDs gives every non-empty, unposted new-comment block a local_id,
+// so a retried push can recognise drafts it already posted.
+// Reports whether content changed.
+func AssignLocalIDs(content string) (string, bool) {
+	changed := false
+	spans := findNewCommentBlocks(content)
+	// Replace from the end so earlier offsets stay valid
+	for i := len(spans) - 1; i >= 0; i-- {
+		span := spans[i]
+		attrs := ParseNewCommentTag(span.tag)
+		if strings.TrimSpace(span.inner) == "" || attrs.LocalID != "" || attrs.Posted != "" {
+			continue
+		}
+		attrs.LocalID = newLocalID()
+		content = content[:span.start] + attrs.Tag() + content[span.innerStart:]
+		changed = true
+	}
+	return content, changed
+}
+
+// MarkPosted records the ID of the comment created from the draft with localID,
+// so the draft is treated as an existing comment until the next pull.
+// Reports whether a matching draft was found.
+func MarkPosted(content, localID, commentID string) (string, bool) {
+	for _, span := range findNewCommentBlocks(content) {
+		attrs := ParseNewCommentTag(span.tag)
+		if attrs.LocalID != localID {
+			continue
+		}
+		attrs.Posted = commentID
+		return content[:span.start] + attrs.Tag() + content[span.innerStart:], true
+	}
+	return content, false
+}
+
+// newLocalID returns a random client-side comment ID.
+func newLocalID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return "lc_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,110 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNewCommentTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want NewCommentAttrs
+	}{
+		{"<!-- gh-md:new-comment -->", NewCommentAttrs{}},
+		{"<!-- gh-md:new-comment reply_to: DC_1 -->", NewCommentAttrs{ReplyTo: "DC_1"}},
+		{
+			"<!-- gh-md:new-comment reply_to: DC_1 local_id: lc_1 posted: DC_2 -->",
+			NewCommentAttrs{ReplyTo: "DC_1", LocalID: "lc_1", Posted: "DC_2"},
+		},
+		{"<!-- gh-md:new-comment local_id: lc_1 unknown: x -->", NewCommentAttrs{LocalID: "lc_1"}},
+	}
+
+	for _, tt := range tests {
+		got := ParseNewCommentTag(tt.tag)
+		if got != tt.want {
+			t.Errorf("ParseNewCommentTag(%q) = %+v, want %+v", tt.tag, got, tt.want)
+		}
+		if !strings.Contains(tt.tag, "unknown") && got.Tag() != tt.tag {
+			t.Errorf("Tag() = %q, want %q", got.Tag(), tt.tag)
+		}
+	}
+}
+
+func TestAssignLocalIDsAndMarkPosted(t *testing.T) {
+	content := "<!-- gh-md:new-comment reply_to: DC_1 -->\nReply draft\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment -->\n\n<!-- /gh-md:new-comment -->\n"
+
+	assigned, changed := AssignLocalIDs(content)
+	if !changed {
+		t.Fatal("AssignLocalIDs() reported no change")
+	}
+	spans := findNewCommentBlocks(assigned)
+	first := ParseNewCommentTag(spans[0].tag)
+	if first.ReplyTo != "DC_1" || !strings.HasPrefix(first.LocalID, "lc_") {
+		t.Errorf("draft tag = %q, want reply_to kept and local_id assigned", spans[0].tag)
+	}
+	if spans[1].tag != "<!-- gh-md:new-comment -->" {
+		t.Errorf("empty block tag = %q, want unchanged", spans[1].tag)
+	}
+
+	// Assigning again keeps existing IDs
+	if again, changed := AssignLocalIDs(assigned); changed || again != assigned {
+		t.Error("AssignLocalIDs() changed content that already had IDs")
+	}
+
+	posted, ok := MarkPosted(assigned, first.LocalID, "DC_9")
+	if !ok {
+		t.Fatal("MarkPosted() did not find the draft")
+	}
+	if got := ParseNewCommentTag(findNewCommentBlocks(posted)[0].tag).Posted; got != "DC_9" {
+		t.Errorf("posted = %q, want DC_9", got)
+	}
+	if _, ok := MarkPosted(assigned, "lc_missing", "DC_9"); ok {
+		t.Error("MarkPosted() matched an unknown local ID")
+	}
+}
+
+func TestExtractDrafts_PushBookkeeping(t *testing.T) {
+	existing := "<!-- gh-md:new-comment reply_to: DC_1 local_id: lc_1 posted: DC_2 -->\nPosted draft\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment local_id: lc_2 -->\nPending draft\n<!-- /gh-md:new-comment -->\n"
+	fresh := "<!-- gh-md:new-comment reply_to: DC_1 -->\n\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment -->\n\n<!-- /gh-md:new-comment -->\n"
+
+	stripped, drafts := extractDrafts(existing)
+	// Emptied blocks look exactly as pulled, so drafts-only edits hash clean
+	if stripped != fresh {
+		t.Errorf("extractDrafts() =\n%s\nwant:\n%s", stripped, fresh)
+	}
+	if len(drafts) != 1 || !strings.Contains(drafts[0].inner, "Pending draft") {
+		t.Fatalf("extractDrafts() drafts = %+v, want only the unposted draft", drafts)
+	}
+
+	got := mergeDrafts(fresh, drafts)
+	want := "<!-- gh-md:new-comment reply_to: DC_1 -->\n\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment local_id: lc_2 -->\nPending draft\n<!-- /gh-md:new-comment -->\n"
+	if got != want {
+		t.Errorf("mergeDrafts() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAddDraft(t *testing.T) {
+	content := "body\n\n<!-- gh-md:new-comment -->\n\n<!-- /gh-md:new-comment -->\n"
+
+	got, localID := AddDraft(content, "  Hello\n", "")
+	if !strings.HasPrefix(localID, "lc_") {
+		t.Errorf("local ID = %q", localID)
+	}
+	want := "body\n\n<!-- gh-md:new-comment local_id: " + localID + " -->\n\nHello\n\n<!-- /gh-md:new-comment -->\n"
+	if got != want {
+		t.Errorf("AddDraft() = %q, want %q", got, want)
+	}
+
+	// The block is taken now, so another draft gets a block of its own
+	got, localID = AddDraft(got, "Reply", "DC_1")
+	if !strings.HasSuffix(got, "<!-- gh-md:new-comment reply_to: DC_1 local_id: "+localID+" -->\n\nReply\n\n<!-- /gh-md:new-comment -->\n") {
+		t.Errorf("AddDraft() with reply = %q", got)
+	}
+	if n := len(findNewCommentBlocks(got)); n != 2 {
+		t.Errorf("blocks = %d, want 2", n)
+	}
+}
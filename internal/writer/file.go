@@ -1,10 +1,12 @@
 package writer
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/jackchuka/gh-md/internal/assets"
 	"github.com/jackchuka/gh-md/internal/config"
 	"github.com/jackchuka/gh-md/internal/github"
 )
@@ -16,11 +18,27 @@ type Item interface {
 	GetNumber() int
 }
 
+// ErrLocallyModified is returned when the existing file has unpushed local edits
+// that cannot be merged into the freshly pulled content.
+var ErrLocallyModified = errors.New("file has unpushed local changes")
+
+// WriteOptions controls how an item is written over an existing file.
+type WriteOptions struct {
+	// Overwrite discards any local changes in the existing file.
+	Overwrite bool
+	// Assets, if set, downloads GitHub attachments beside the file and rewrites
+	// their links to the local copies.
+	Assets assets.Downloader
+}
+
 // writeItemToFile is a generic helper for writing items to markdown files.
+// Unless opts.Overwrite is set, new-comment drafts in the existing file are carried
+// over, and ErrLocallyModified is returned if the file has any other local edits.
 func writeItemToFile(
 	item Item,
 	getDirFunc func(owner, repo string) (string, error),
 	toMarkdownFunc func() (string, error),
+	opts WriteOptions,
 ) (string, error) {
 	dir, err := getDirFunc(item.GetOwner(), item.GetRepo())
 	if err != nil {
@@ -37,42 +55,89 @@ func writeItemToFile(
 	}
 
 	path := filepath.Join(dir, fmt.Sprintf("%d.md", item.GetNumber()))
-	if err := writeFile(path, content); err != nil {
+
+	// Check for local edits first, so skipped files don't download attachments
+	var drafts []draftBlock
+	if !opts.Overwrite {
+		drafts, err = localDrafts(path)
+		if err != nil {
+			return path, err
+		}
+	}
+
+	if opts.Assets != nil {
+		content, err = assets.Localize(content, path, opts.Assets)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	content = StampContentHash(content)
+	base := content
+	content = mergeDrafts(content, drafts)
+
+	if err := WriteFile(path, content); err != nil {
+		return "", err
+	}
+	if err := writeBase(path, base); err != nil {
 		return "", err
 	}
 
 	return path, nil
 }
 
+// localDrafts returns the new-comment drafts of the existing file at path, to be
+// carried over into the pulled content. Returns ErrLocallyModified if the file has
+// edits other than drafts. Files pulled before modification tracking only have
+// drafts carried over.
+func localDrafts(path string) ([]draftBlock, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read existing file: %w", err)
+	}
+
+	withoutDrafts, drafts := extractDrafts(string(existing))
+	if IsModified(withoutDrafts) {
+		return nil, ErrLocallyModified
+	}
+	return drafts, nil
+}
+
 // WriteIssue writes an issue to the filesystem.
-func WriteIssue(issue *github.Issue) (string, error) {
+func WriteIssue(issue *github.Issue, opts WriteOptions) (string, error) {
 	return writeItemToFile(
 		issue,
 		config.GetIssuesDir,
 		func() (string, error) { return IssueToMarkdown(issue) },
+		opts,
 	)
 }
 
 // WritePullRequest writes a PR to the filesystem.
-func WritePullRequest(pr *github.PullRequest) (string, error) {
+func WritePullRequest(pr *github.PullRequest, opts WriteOptions) (string, error) {
 	return writeItemToFile(
 		pr,
 		config.GetPullsDir,
 		func() (string, error) { return PullRequestToMarkdown(pr) },
+		opts,
 	)
 }
 
 // WriteDiscussion writes a discussion to the filesystem.
-func WriteDiscussion(d *github.Discussion) (string, error) {
+func WriteDiscussion(d *github.Discussion, opts WriteOptions) (string, error) {
 	return writeItemToFile(
 		d,
 		config.GetDiscussionsDir,
 		func() (string, error) { return DiscussionToMarkdown(d) },
+		opts,
 	)
 }
 
-// writeFile writes content to a file atomically by writing to a temp file first.
-func writeFile(path, content string) error {
+// WriteFile writes content to a file atomically by writing to a temp file first.
+func WriteFile(path, content string) error {
 	// Write to temp file first
 	tmpPath := path + ".tmp"
 	if err := os.WriteFile(tmpPath, []byte(content), 0644); err != nil {
@@ -0,0 +1,224 @@
+package writer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackchuka/gh-md/internal/config"
+	"github.com/jackchuka/gh-md/internal/github"
+)
+
+func testIssue(body string) *github.Issue {
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &github.Issue{
+		ID:        "I_1",
+		Owner:     "owner",
+		Repo:      "repo",
+		Number:    1,
+		Title:     "Title",
+		Body:      body,
+		State:     "open",
+		CreatedAt: ts,
+		UpdatedAt: ts,
+		Comments: []github.Comment{
+			{ID: "IC_1", Author: "user1", Body: "First", CreatedAt: ts},
+		},
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	return string(data)
+}
+
+func TestWriteIssue_PreservesLocalChanges(t *testing.T) {
+	t.Setenv(config.EnvRootDir, t.TempDir())
+
+	path, err := WriteIssue(testIssue("Original body"), WriteOptions{})
+	if err != nil {
+		t.Fatalf("WriteIssue() error = %v", err)
+	}
+
+	t.Run("unmodified file is overwritten", func(t *testing.T) {
+		if _, err := WriteIssue(testIssue("Remote edit"), WriteOptions{}); err != nil {
+			t.Fatalf("WriteIssue() error = %v", err)
+		}
+		if !strings.Contains(readTestFile(t, path), "Remote edit") {
+			t.Error("expected file to contain remote body")
+		}
+	})
+
+	t.Run("drafts are carried over", func(t *testing.T) {
+		content := strings.Replace(readTestFile(t, path),
+			"<!-- gh-md:new-comment -->\n\n", "<!-- gh-md:new-comment -->\n\nMy draft\n", 1)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+
+		if _, err := WriteIssue(testIssue("Another remote edit"), WriteOptions{}); err != nil {
+			t.Fatalf("WriteIssue() error = %v", err)
+		}
+
+		got := readTestFile(t, path)
+		if !strings.Contains(got, "Another remote edit") {
+			t.Error("expected file to contain remote body")
+		}
+		if base, _ := ReadBase(path); strings.Contains(base, "My draft") {
+			t.Error("base copy should not contain local drafts")
+		}
+		if !strings.Contains(got, "<!-- gh-md:new-comment -->\n\nMy draft\n") {
+			t.Errorf("expected draft to be preserved:\n%s", got)
+		}
+		if strings.Count(got, "<!-- gh-md:new-comment -->") != 1 {
+			t.Errorf("expected a single new-comment block:\n%s", got)
+		}
+	})
+
+	t.Run("body edits are not overwritten", func(t *testing.T) {
+		content := strings.Replace(readTestFile(t, path), "Another remote edit", "Local edit", 1)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+
+		_, err := WriteIssue(testIssue("Remote again"), WriteOptions{})
+		if !errors.Is(err, ErrLocallyModified) {
+			t.Fatalf("WriteIssue() error = %v, want ErrLocallyModified", err)
+		}
+		if !strings.Contains(readTestFile(t, path), "Local edit") {
+			t.Error("local edit was overwritten")
+		}
+	})
+
+	t.Run("overwrite discards local edits", func(t *testing.T) {
+		if _, err := WriteIssue(testIssue("Remote again"), WriteOptions{Overwrite: true}); err != nil {
+			t.Fatalf("WriteIssue() error = %v", err)
+		}
+		got := readTestFile(t, path)
+		if strings.Contains(got, "Local edit") || strings.Contains(got, "My draft") {
+			t.Errorf("expected local changes to be discarded:\n%s", got)
+		}
+	})
+}
+
+func TestMergeDrafts(t *testing.T) {
+	existing := "<!-- gh-md:new-comment reply_to: DC_1 -->\nReply draft\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment reply_to: DC_gone -->\nOrphan draft\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment -->\n\n<!-- /gh-md:new-comment -->\n"
+	fresh := "<!-- gh-md:new-comment reply_to: DC_1 -->\n\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment -->\n\n<!-- /gh-md:new-comment -->\n"
+
+	stripped, drafts := extractDrafts(existing)
+	if len(drafts) != 2 {
+		t.Fatalf("extractDrafts() returned %d drafts, want 2", len(drafts))
+	}
+	if strings.Contains(stripped, "draft") {
+		t.Errorf("extractDrafts() left draft content:\n%s", stripped)
+	}
+
+	got := mergeDrafts(fresh, drafts)
+	want := "<!-- gh-md:new-comment reply_to: DC_1 -->\nReply draft\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment -->\n\n<!-- /gh-md:new-comment -->\n\n" +
+		"<!-- gh-md:new-comment reply_to: DC_gone -->\nOrphan draft\n<!-- /gh-md:new-comment -->\n"
+	if got != want {
+		t.Errorf("mergeDrafts() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteIssue_NewFile(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(config.EnvRootDir, root)
+
+	path, err := WriteIssue(testIssue("Body"), WriteOptions{})
+	if err != nil {
+		t.Fatalf("WriteIssue() error = %v", err)
+	}
+	if path != filepath.Join(root, "owner", "repo", "issues", "1.md") {
+		t.Errorf("WriteIssue() path = %q", path)
+	}
+	if IsModified(readTestFile(t, path)) {
+		t.Error("freshly written file reported as modified")
+	}
+
+	wantBase := filepath.Join(root, "owner", "repo", BaseDirName, "issues", "1.md")
+	if BasePath(path) != wantBase {
+		t.Errorf("BasePath() = %q, want %q", BasePath(path), wantBase)
+	}
+	base, err := ReadBase(path)
+	if err != nil {
+		t.Fatalf("ReadBase() error = %v", err)
+	}
+	if base != readTestFile(t, path) {
+		t.Error("base copy differs from written file")
+	}
+
+	if err := RemoveBase(path); err != nil {
+		t.Fatalf("RemoveBase() error = %v", err)
+	}
+	if _, err := ReadBase(path); err == nil {
+		t.Error("ReadBase() error = nil after RemoveBase")
+	}
+}
+
+type stubDownloader struct{}
+
+func (stubDownloader) Download(url string) ([]byte, string, error) {
+	return []byte("png"), "image/png", nil
+}
+
+type countingDownloader struct{ calls int }
+
+func (d *countingDownloader) Download(url string) ([]byte, string, error) {
+	d.calls++
+	return []byte("png"), "image/png", nil
+}
+
+func TestWriteIssue_Assets(t *testing.T) {
+	t.Setenv(config.EnvRootDir, t.TempDir())
+
+	url := "https://github.com/user-attachments/assets/abc"
+	path, err := WriteIssue(testIssue("![shot]("+url+")"), WriteOptions{Assets: stubDownloader{}})
+	if err != nil {
+		t.Fatalf("WriteIssue() error = %v", err)
+	}
+
+	got := readTestFile(t, path)
+	if !strings.Contains(got, "![shot](assets/1/abc.png)") || strings.Contains(got, url) {
+		t.Errorf("expected link rewritten to the local copy:\n%s", got)
+	}
+	if IsModified(got) {
+		t.Error("rewritten file should not count as locally modified")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "assets", "1", "abc.png")); err != nil {
+		t.Errorf("asset not downloaded: %v", err)
+	}
+}
+
+func TestWriteIssue_ModifiedSkipsAssetDownloads(t *testing.T) {
+	t.Setenv(config.EnvRootDir, t.TempDir())
+
+	path, err := WriteIssue(testIssue("Original body"), WriteOptions{})
+	if err != nil {
+		t.Fatalf("WriteIssue() error = %v", err)
+	}
+	edited := strings.Replace(readTestFile(t, path), "Original body", "Local edit", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &countingDownloader{}
+	issue := testIssue("![shot](https://github.com/user-attachments/assets/abc)")
+	if _, err := WriteIssue(issue, WriteOptions{Assets: d}); !errors.Is(err, ErrLocallyModified) {
+		t.Fatalf("WriteIssue() error = %v, want ErrLocallyModified", err)
+	}
+	if d.calls != 0 {
+		t.Errorf("downloaded %d attachments for a skipped file, want 0", d.calls)
+	}
+}
@@ -0,0 +1,125 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the current format version of item markdown files.
+// Bump it together with a new migration in internal/migrate whenever the
+// file format changes.
+const SchemaVersion = 1
+
+// SplitFrontmatter splits file content into the raw YAML frontmatter and the
+// markdown that follows it. CRLF line endings are normalized to LF.
+func SplitFrontmatter(content string) (string, string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	if !strings.HasPrefix(content, "---\n") {
+		return "", "", fmt.Errorf("file does not start with frontmatter")
+	}
+
+	endIndex := strings.Index(content[4:], "\n---\n")
+	if endIndex == -1 {
+		return "", "", fmt.Errorf("frontmatter not closed")
+	}
+
+	return content[4 : 4+endIndex+1], content[4+endIndex+5:], nil
+}
+
+// SetFrontmatterField sets a top-level frontmatter key in file content,
+// preserving the order and formatting of all other keys.
+// New keys are inserted first when prepend is true, appended otherwise.
+func SetFrontmatterField(content, key string, value any, prepend bool) (string, error) {
+	fm, rest, err := SplitFrontmatter(content)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := SetYAMLField(fm, key, value, prepend)
+	if err != nil {
+		return "", err
+	}
+
+	return "---\n" + out + "---\n" + rest, nil
+}
+
+// SetYAMLField sets a top-level key in a YAML mapping document,
+// preserving the order and formatting of all other keys.
+// New keys are inserted first when prepend is true, appended otherwise.
+func SetYAMLField(data, key string, value any, prepend bool) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		// Empty document
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("YAML document is not a mapping")
+	}
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	replaced := false
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = &valueNode
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+		if prepend {
+			mapping.Content = append([]*yaml.Node{keyNode, &valueNode}, mapping.Content...)
+		} else {
+			mapping.Content = append(mapping.Content, keyNode, &valueNode)
+		}
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// RemoveFrontmatterFields drops single-line top-level keys from the frontmatter,
+// leaving the rest of the content untouched. Content without frontmatter is
+// returned with line endings normalized.
+func RemoveFrontmatterFields(content string, keys ...string) string {
+	fm, rest, err := SplitFrontmatter(content)
+	if err != nil {
+		return strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	lines := strings.SplitAfter(fm, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		drop := false
+		for _, key := range keys {
+			if strings.HasPrefix(line, key+":") {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			kept = append(kept, line)
+		}
+	}
+
+	return "---\n" + strings.Join(kept, "") + "---\n" + rest
+}
+
+// VolatileFields are frontmatter keys that change on every pull without the
+// item itself changing.
+var VolatileFields = []string{"last_pulled", contentHashKey}
@@ -0,0 +1,105 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantFM   string
+		wantRest string
+		wantErr  bool
+	}{
+		{
+			name:     "basic",
+			content:  "---\nid: I_1\n---\nbody\n",
+			wantFM:   "id: I_1\n",
+			wantRest: "body\n",
+		},
+		{
+			name:     "crlf normalized",
+			content:  "---\r\nid: I_1\r\n---\r\nbody\r\n",
+			wantFM:   "id: I_1\n",
+			wantRest: "body\n",
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Title\n",
+			wantErr: true,
+		},
+		{
+			name:    "unclosed",
+			content: "---\nid: I_1\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, rest, err := SplitFrontmatter(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitFrontmatter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if fm != tt.wantFM {
+				t.Errorf("frontmatter = %q, want %q", fm, tt.wantFM)
+			}
+			if rest != tt.wantRest {
+				t.Errorf("rest = %q, want %q", rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestSetFrontmatterField(t *testing.T) {
+	content := "---\nid: I_1\nstate: open\n---\n\nbody\n"
+
+	t.Run("prepend new key", func(t *testing.T) {
+		got, err := SetFrontmatterField(content, "schema_version", 1, true)
+		if err != nil {
+			t.Fatalf("SetFrontmatterField() error = %v", err)
+		}
+		want := "---\nschema_version: 1\nid: I_1\nstate: open\n---\n\nbody\n"
+		if got != want {
+			t.Errorf("SetFrontmatterField() =\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("replace existing key", func(t *testing.T) {
+		got, err := SetFrontmatterField(content, "state", "closed", false)
+		if err != nil {
+			t.Fatalf("SetFrontmatterField() error = %v", err)
+		}
+		if !strings.Contains(got, "state: closed\n") || strings.Contains(got, "state: open") {
+			t.Errorf("SetFrontmatterField() did not replace state:\n%s", got)
+		}
+		if !strings.HasSuffix(got, "---\n\nbody\n") {
+			t.Errorf("SetFrontmatterField() changed body:\n%s", got)
+		}
+	})
+}
+
+func TestSetYAMLField_EmptyDocument(t *testing.T) {
+	got, err := SetYAMLField("", "schema_version", 1, true)
+	if err != nil {
+		t.Fatalf("SetYAMLField() error = %v", err)
+	}
+	if got != "schema_version: 1\n" {
+		t.Errorf("SetYAMLField() = %q, want %q", got, "schema_version: 1\n")
+	}
+}
+
+func TestRemoveFrontmatterFields(t *testing.T) {
+	content := "---\nid: I_1\nlast_pulled: 2026-01-01T00:00:00Z\ncontent_hash: abc\n---\nlast_pulled: in body\n"
+
+	got := RemoveFrontmatterFields(content, VolatileFields...)
+	want := "---\nid: I_1\n---\nlast_pulled: in body\n"
+	if got != want {
+		t.Errorf("RemoveFrontmatterFields() = %q, want %q", got, want)
+	}
+}
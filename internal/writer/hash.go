@@ -0,0 +1,64 @@
+package writer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// contentHashKey is the frontmatter key holding the hash of the file as written by pull.
+const contentHashKey = "content_hash"
+
+// ContentHash returns the hash of file content, ignoring any content_hash line,
+// line ending style and trailing whitespace at the end of the file.
+func ContentHash(content string) string {
+	stripped, _ := splitContentHash(content)
+	sum := sha256.Sum256([]byte(strings.TrimRight(stripped, " \t\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// StoredContentHash returns the content_hash recorded in the frontmatter, or "" if absent.
+func StoredContentHash(content string) string {
+	_, hash := splitContentHash(content)
+	return hash
+}
+
+// IsModified reports whether content was edited since its hash was stamped.
+// Files without a stored hash are never reported as modified.
+func IsModified(content string) bool {
+	stored := StoredContentHash(content)
+	return stored != "" && stored != ContentHash(content)
+}
+
+// StampContentHash records the hash of content as the last frontmatter key,
+// replacing any existing hash. Content without frontmatter is returned unchanged.
+func StampContentHash(content string) string {
+	stripped, _ := splitContentHash(content)
+	fm, rest, err := SplitFrontmatter(stripped)
+	if err != nil {
+		return content
+	}
+	return "---\n" + fm + contentHashKey + ": " + ContentHash(stripped) + "\n---\n" + rest
+}
+
+// splitContentHash removes the content_hash line from the frontmatter and returns
+// the remaining (LF-normalized) content along with the stored hash value.
+func splitContentHash(content string) (string, string) {
+	fm, rest, err := SplitFrontmatter(content)
+	if err != nil {
+		return strings.ReplaceAll(content, "\r\n", "\n"), ""
+	}
+
+	var hash string
+	lines := strings.SplitAfter(fm, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(line, contentHashKey+":"); ok {
+			hash = strings.TrimSpace(value)
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	return "---\n" + strings.Join(kept, "") + "---\n" + rest, hash
+}
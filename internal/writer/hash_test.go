@@ -0,0 +1,52 @@
+package writer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStampContentHash(t *testing.T) {
+	content := "---\nid: I_1\nstate: open\n---\n\n<!-- gh-md:content -->\n# Title\n\nBody\n<!-- /gh-md:content -->\n"
+
+	stamped := StampContentHash(content)
+
+	if !strings.Contains(stamped, "state: open\ncontent_hash: "+ContentHash(content)+"\n---\n") {
+		t.Errorf("StampContentHash() did not append hash to frontmatter:\n%s", stamped)
+	}
+	if StoredContentHash(stamped) != ContentHash(content) {
+		t.Errorf("StoredContentHash() = %q, want %q", StoredContentHash(stamped), ContentHash(content))
+	}
+	if IsModified(stamped) {
+		t.Error("IsModified() = true for freshly stamped content")
+	}
+
+	// Restamping replaces the existing hash
+	if got := StampContentHash(stamped); got != stamped {
+		t.Errorf("StampContentHash() not idempotent:\n%s", got)
+	}
+}
+
+func TestIsModified(t *testing.T) {
+	stamped := StampContentHash("---\nid: I_1\n---\n\nBody\n")
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{name: "unchanged", content: stamped, want: false},
+		{name: "crlf line endings", content: strings.ReplaceAll(stamped, "\n", "\r\n"), want: false},
+		{name: "trailing newlines", content: stamped + "\n\n", want: false},
+		{name: "body edited", content: strings.Replace(stamped, "Body", "Edited body", 1), want: true},
+		{name: "frontmatter edited", content: strings.Replace(stamped, "id: I_1", "id: I_2", 1), want: true},
+		{name: "no stored hash", content: "---\nid: I_1\n---\n\nBody\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsModified(tt.content); got != tt.want {
+				t.Errorf("IsModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -54,17 +54,20 @@ func writeCommentBody(sb *strings.Builder, tagName, author, body string, created
 
 // BaseFrontmatter contains fields common to all item types.
 type BaseFrontmatter struct {
-	ID         string    `yaml:"id"`
-	URL        string    `yaml:"url"`
-	Number     int       `yaml:"number"`
-	Owner      string    `yaml:"owner"`
-	Repo       string    `yaml:"repo"`
-	Title      string    `yaml:"title"`
-	State      string    `yaml:"state"`
-	Author     string    `yaml:"author,omitempty"`
-	Created    time.Time `yaml:"created"`
-	Updated    time.Time `yaml:"updated"`
-	LastPulled time.Time `yaml:"last_pulled"`
+	SchemaVersion int       `yaml:"schema_version"`
+	ID            string    `yaml:"id"`
+	URL           string    `yaml:"url"`
+	Number        int       `yaml:"number"`
+	Owner         string    `yaml:"owner"`
+	Repo          string    `yaml:"repo"`
+	Title         string    `yaml:"title"`
+	State         string    `yaml:"state"`
+	Author        string    `yaml:"author,omitempty"`
+	Created       time.Time `yaml:"created"`
+	Updated       time.Time `yaml:"updated"`
+	Closed        time.Time `yaml:"closed,omitempty"`
+	Reactions     int       `yaml:"reactions,omitempty"`
+	LastPulled    time.Time `yaml:"last_pulled"`
 }
 
 // IssueReferenceFrontmatter represents a reference to a parent or child issue in frontmatter.
@@ -89,6 +92,7 @@ type IssueFrontmatter struct {
 	BaseFrontmatter  `yaml:",inline"`
 	Labels           []string                     `yaml:"labels,omitempty"`
 	Assignees        []string                     `yaml:"assignees,omitempty"`
+	Pinned           bool                         `yaml:"pinned,omitempty"`
 	Parent           *IssueReferenceFrontmatter   `yaml:"parent,omitempty"`
 	Children         []IssueReferenceFrontmatter  `yaml:"children,omitempty"`
 	SubIssuesSummary *SubIssuesSummaryFrontmatter `yaml:"sub_issues_summary,omitempty"`
@@ -119,20 +123,24 @@ type DiscussionFrontmatter struct {
 func IssueToMarkdown(issue *github.Issue) (string, error) {
 	fm := IssueFrontmatter{
 		BaseFrontmatter: BaseFrontmatter{
-			ID:         issue.ID,
-			URL:        issue.URL,
-			Number:     issue.Number,
-			Owner:      issue.Owner,
-			Repo:       issue.Repo,
-			Title:      issue.Title,
-			State:      issue.State,
-			Author:     issue.Author,
-			Created:    issue.CreatedAt,
-			Updated:    issue.UpdatedAt,
-			LastPulled: time.Now().UTC(),
+			SchemaVersion: SchemaVersion,
+			ID:            issue.ID,
+			URL:           issue.URL,
+			Number:        issue.Number,
+			Owner:         issue.Owner,
+			Repo:          issue.Repo,
+			Title:         issue.Title,
+			State:         issue.State,
+			Author:        issue.Author,
+			Created:       issue.CreatedAt,
+			Updated:       issue.UpdatedAt,
+			Closed:        issue.ClosedAt,
+			Reactions:     issue.Reactions,
+			LastPulled:    time.Now().UTC(),
 		},
 		Labels:    issue.Labels,
 		Assignees: issue.Assignees,
+		Pinned:    issue.Pinned,
 	}
 
 	// Convert parent issue reference
@@ -197,17 +205,20 @@ func IssueToMarkdown(issue *github.Issue) (string, error) {
 func PullRequestToMarkdown(pr *github.PullRequest) (string, error) {
 	fm := PullRequestFrontmatter{
 		BaseFrontmatter: BaseFrontmatter{
-			ID:         pr.ID,
-			URL:        pr.URL,
-			Number:     pr.Number,
-			Owner:      pr.Owner,
-			Repo:       pr.Repo,
-			Title:      pr.Title,
-			State:      pr.State,
-			Author:     pr.Author,
-			Created:    pr.CreatedAt,
-			Updated:    pr.UpdatedAt,
-			LastPulled: time.Now().UTC(),
+			SchemaVersion: SchemaVersion,
+			ID:            pr.ID,
+			URL:           pr.URL,
+			Number:        pr.Number,
+			Owner:         pr.Owner,
+			Repo:          pr.Repo,
+			Title:         pr.Title,
+			State:         pr.State,
+			Author:        pr.Author,
+			Created:       pr.CreatedAt,
+			Updated:       pr.UpdatedAt,
+			Closed:        pr.ClosedAt,
+			Reactions:     pr.Reactions,
+			LastPulled:    time.Now().UTC(),
 		},
 		Draft:       pr.Draft,
 		Labels:      pr.Labels,
@@ -248,17 +259,20 @@ func PullRequestToMarkdown(pr *github.PullRequest) (string, error) {
 func DiscussionToMarkdown(d *github.Discussion) (string, error) {
 	fm := DiscussionFrontmatter{
 		BaseFrontmatter: BaseFrontmatter{
-			ID:         d.ID,
-			URL:        d.URL,
-			Number:     d.Number,
-			Owner:      d.Owner,
-			Repo:       d.Repo,
-			Title:      d.Title,
-			State:      d.State,
-			Author:     d.Author,
-			Created:    d.CreatedAt,
-			Updated:    d.UpdatedAt,
-			LastPulled: time.Now().UTC(),
+			SchemaVersion: SchemaVersion,
+			ID:            d.ID,
+			URL:           d.URL,
+			Number:        d.Number,
+			Owner:         d.Owner,
+			Repo:          d.Repo,
+			Title:         d.Title,
+			State:         d.State,
+			Author:        d.Author,
+			Created:       d.CreatedAt,
+			Updated:       d.UpdatedAt,
+			Closed:        d.ClosedAt,
+			Reactions:     d.Reactions,
+			LastPulled:    time.Now().UTC(),
 		},
 		Category: d.Category,
 		AnswerID: d.AnswerID,